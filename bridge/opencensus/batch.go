@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var errMultipleResources = errors.New("OpenCensus metrics have different resources")
+
+// ConvertedBatch is a batch of OpenCensus metrics converted to OpenTelemetry,
+// with their resource and the instrumentation scope of the bridge.
+type ConvertedBatch struct {
+	// Metrics are the converted metrics.
+	Metrics []metricdata.Metrics
+	// Resource is the resource of the metrics.
+	Resource *resource.Resource
+	// Scope is the instrumentation scope of the bridge.
+	Scope instrumentation.Scope
+}
+
+// ToResourceMetrics returns the metrics of b with their resource and scope.
+func (b ConvertedBatch) ToResourceMetrics() metricdata.ResourceMetrics {
+	return metricdata.ResourceMetrics{
+		Resource: b.Resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope:   b.Scope,
+			Metrics: b.Metrics,
+		}},
+	}
+}
+
+// ConvertWithResource converts OpenCensus metrics that share a resource to
+// OpenTelemetry. The resource of the batch is converted from the OpenCensus
+// resource of the metrics, or is empty if they have none. If the metrics have
+// different resources, an error is returned and the batch is empty, as the
+// metrics cannot be attributed to a single resource. Otherwise, the batch
+// contains the metrics that could be converted, along with any errors. The
+// metrics are converted as configured by opts.
+func ConvertWithResource(ocmetrics []*ocmetricdata.Metric, opts ...MetricOption) (ConvertedBatch, error) {
+	scope := instrumentation.Scope{
+		Name:    scopeName,
+		Version: Version(),
+	}
+	conf := newMetricConfig(opts)
+	rms, err := internal.NewConverter(conf.converterOptions...).ConvertResourceMetrics(ocmetrics, scope)
+	switch len(rms) {
+	case 0:
+		return ConvertedBatch{Resource: resource.Empty(), Scope: scope}, err
+	case 1:
+		return ConvertedBatch{
+			Metrics:  rms[0].ScopeMetrics[0].Metrics,
+			Resource: rms[0].Resource,
+			Scope:    scope,
+		}, err
+	default:
+		return ConvertedBatch{}, errors.Join(fmt.Errorf("%w: %d resources", errMultipleResources, len(rms)), err)
+	}
+}
+
+// ConvertMetricsBatched converts OpenCensus metrics to OpenTelemetry, grouped
+// by resource, in batches of at most maxPointsPerBatch data points, e.g. to
+// export each batch in an OTLP request. Each returned ResourceMetrics is a
+// batch of the metrics of a single resource, in the instrumentation scope of
+// the bridge. Metrics are not split across batches unless they alone have
+// more than maxPointsPerBatch data points, in which case their data points
+// are split into as many batches as needed. A non-positive
+// maxPointsPerBatch does not limit the size of batches. The metrics that
+// could be converted are returned along with any errors. The metrics are
+// converted as configured by opts.
+func ConvertMetricsBatched(ocmetrics []*ocmetricdata.Metric, maxPointsPerBatch int, opts ...MetricOption) ([]*metricdata.ResourceMetrics, error) {
+	scope := instrumentation.Scope{
+		Name:    scopeName,
+		Version: Version(),
+	}
+	conf := newMetricConfig(opts)
+	converterOpts := append(conf.converterOptions, conf.scopeOptions...)
+	rms, err := internal.NewConverter(converterOpts...).ConvertResourceMetrics(ocmetrics, scope)
+	if maxPointsPerBatch < 1 {
+		return rms, err
+	}
+	var batches []*metricdata.ResourceMetrics
+	for _, rm := range rms {
+		var batch *metricdata.ResourceMetrics
+		var points int
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				for _, part := range splitMetric(m, maxPointsPerBatch) {
+					n := pointCount(part.Data)
+					if batch == nil || points+n > maxPointsPerBatch {
+						batch = &metricdata.ResourceMetrics{Resource: rm.Resource}
+						batches = append(batches, batch)
+						points = 0
+					}
+					if last := len(batch.ScopeMetrics) - 1; last < 0 || batch.ScopeMetrics[last].Scope != sm.Scope {
+						batch.ScopeMetrics = append(batch.ScopeMetrics, metricdata.ScopeMetrics{Scope: sm.Scope})
+					}
+					last := &batch.ScopeMetrics[len(batch.ScopeMetrics)-1]
+					last.Metrics = append(last.Metrics, part)
+					points += n
+				}
+			}
+		}
+	}
+	return batches, err
+}
+
+// pointCount returns the number of data points of agg.
+func pointCount(agg metricdata.Aggregation) int {
+	switch a := agg.(type) {
+	case metricdata.Gauge[int64]:
+		return len(a.DataPoints)
+	case metricdata.Gauge[float64]:
+		return len(a.DataPoints)
+	case metricdata.Sum[int64]:
+		return len(a.DataPoints)
+	case metricdata.Sum[float64]:
+		return len(a.DataPoints)
+	case metricdata.Histogram[int64]:
+		return len(a.DataPoints)
+	case metricdata.Histogram[float64]:
+		return len(a.DataPoints)
+	}
+	return 0
+}
+
+// splitMetric returns m split into metrics of at most maxPoints data points,
+// or m if it has no more than maxPoints data points.
+func splitMetric(m metricdata.Metrics, maxPoints int) []metricdata.Metrics {
+	switch a := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.DataPoint[int64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	case metricdata.Gauge[float64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.DataPoint[float64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	case metricdata.Sum[int64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.DataPoint[int64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	case metricdata.Sum[float64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.DataPoint[float64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	case metricdata.Histogram[int64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.HistogramDataPoint[int64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	case metricdata.Histogram[float64]:
+		return splitPoints(m, a.DataPoints, maxPoints, func(p []metricdata.HistogramDataPoint[float64]) metricdata.Aggregation {
+			a.DataPoints = p
+			return a
+		})
+	}
+	return []metricdata.Metrics{m}
+}
+
+// splitPoints returns m split into metrics with at most maxPoints of points
+// each, whose aggregations are returned by withPoints.
+func splitPoints[P any](m metricdata.Metrics, points []P, maxPoints int, withPoints func([]P) metricdata.Aggregation) []metricdata.Metrics {
+	if len(points) <= maxPoints {
+		return []metricdata.Metrics{m}
+	}
+	parts := make([]metricdata.Metrics, 0, (len(points)+maxPoints-1)/maxPoints)
+	for len(points) > 0 {
+		n := maxPoints
+		if n > len(points) {
+			n = len(points)
+		}
+		part := m
+		part.Data = withPoints(points[:n:n])
+		parts = append(parts, part)
+		points = points[n:]
+	}
+	return parts
+}
+
+// ConvertMetricsGrouped converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, grouped by the concrete type of their aggregation, in
+// the order of ocmetrics, e.g. to export them with different exporters. The
+// keys of the returned map are the reflect.Type of:
+//
+//   - metricdata.Gauge[int64] and metricdata.Gauge[float64]
+//   - metricdata.Sum[int64] and metricdata.Sum[float64]
+//   - metricdata.Histogram[float64]
+//
+// Only the types of converted metrics are included. The metrics that could be
+// converted are returned along with any errors.
+func ConvertMetricsGrouped(ocmetrics []*ocmetricdata.Metric, opts ...MetricOption) (map[reflect.Type][]metricdata.Metrics, error) {
+	return internal.NewConverter(newMetricConfig(opts).converterOptions...).ConvertMetricsGrouped(ocmetrics)
+}
+
+// ConvertMetricsByTemporality converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, partitioned by the temporality of the converted sums
+// and histograms, e.g. to export them with different exporters. Gauges have
+// no temporality: they are included in cumulative, as cumulative exporters
+// export the latest value of gauges. Metrics are in the order of ocmetrics
+// within each partition. The metrics that could be converted are returned
+// along with any errors.
+func ConvertMetricsByTemporality(ocmetrics []*ocmetricdata.Metric, opts ...MetricOption) (cumulative, delta []metricdata.Metrics, err error) {
+	return internal.NewConverter(newMetricConfig(opts).converterOptions...).ConvertMetricsByTemporality(ocmetrics)
+}
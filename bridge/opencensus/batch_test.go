@@ -0,0 +1,422 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestConvertWithResource(t *testing.T) {
+	now := time.Now()
+	scope := instrumentation.Scope{Name: scopeName, Version: Version()}
+	ocres := &ocresource.Resource{Type: "host", Labels: map[string]string{"R1": "V1"}}
+	res := resource.NewSchemaless(
+		attribute.String("opencensus.resourcetype", "host"),
+		attribute.String("R1", "V1"),
+	)
+	gauge := func(name string, ocres *ocresource.Resource) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: name, Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)}}},
+			Resource:   ocres,
+		}
+	}
+	expectedGauge := func(name string) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name: name,
+			Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: *attribute.EmptySet(), Time: now, Value: 1},
+			}},
+		}
+	}
+	for _, tc := range []struct {
+		desc      string
+		input     []*ocmetricdata.Metric
+		opts      []MetricOption
+		expected  metricdata.ResourceMetrics
+		expectErr bool
+	}{
+		{
+			desc: "empty",
+			expected: metricdata.ResourceMetrics{
+				Resource:     resource.Empty(),
+				ScopeMetrics: []metricdata.ScopeMetrics{{Scope: scope}},
+			},
+		},
+		{
+			desc:  "shared resource",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", ocres), gauge("foo.com/gauge-b", ocres)},
+			expected: metricdata.ResourceMetrics{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a"), expectedGauge("foo.com/gauge-b")},
+				}},
+			},
+		},
+		{
+			desc:  "no resource",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", nil)},
+			expected: metricdata.ResourceMetrics{
+				Resource: resource.Empty(),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+		},
+		{
+			desc:  "fallback resource",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", nil)},
+			opts:  []MetricOption{WithFallbackResource(res)},
+			expected: metricdata.ResourceMetrics{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+		},
+		{
+			desc:  "source attribute",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", ocres)},
+			opts:  []MetricOption{WithSourceAttribute("source", "oc")},
+			expected: metricdata.ResourceMetrics{
+				Resource: resource.NewSchemaless(append(res.Attributes(), attribute.String("source", "oc"))...),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+		},
+		{
+			desc: "resource attribute types",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", &ocresource.Resource{
+				Type:   "host",
+				Labels: map[string]string{"R1": "V1", "cpus": "4"},
+			})},
+			opts: []MetricOption{WithResourceAttributeTypes(map[string]attribute.Type{"cpus": attribute.INT64})},
+			expected: metricdata.ResourceMetrics{
+				Resource: resource.NewSchemaless(append(res.Attributes(), attribute.Int64("cpus", 4))...),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+		},
+		{
+			desc: "resource grouping keys",
+			input: []*ocmetricdata.Metric{
+				gauge("foo.com/gauge-a", ocres),
+				gauge("foo.com/gauge-b", &ocresource.Resource{Type: "host", Labels: map[string]string{"R1": "V2"}}),
+			},
+			opts: []MetricOption{WithResourceGroupingKeys("opencensus.resourcetype")},
+			expected: metricdata.ResourceMetrics{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a"), expectedGauge("foo.com/gauge-b")},
+				}},
+			},
+		},
+		{
+			desc:  "global resource",
+			input: []*ocmetricdata.Metric{gauge("foo.com/gauge-a", ocres)},
+			opts: []MetricOption{WithGlobalResource(resource.NewSchemaless(
+				attribute.String("R1", "global"),
+				attribute.String("service.name", "svc"),
+			))},
+			expected: metricdata.ResourceMetrics{
+				Resource: resource.NewSchemaless(append(res.Attributes(), attribute.String("service.name", "svc"))...),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+		},
+		{
+			desc: "conversion error",
+			input: []*ocmetricdata.Metric{
+				gauge("foo.com/gauge-a", ocres),
+				{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/summary-a", Type: ocmetricdata.TypeSummary}, Resource: ocres},
+			},
+			expected: metricdata.ResourceMetrics{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedGauge("foo.com/gauge-a")},
+				}},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			batch, err := ConvertWithResource(tc.input, tc.opts...)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, scope, batch.Scope)
+			metricdatatest.AssertEqual(t, tc.expected, batch.ToResourceMetrics())
+		})
+	}
+
+	t.Run("different resources", func(t *testing.T) {
+		batch, err := ConvertWithResource([]*ocmetricdata.Metric{gauge("foo.com/gauge-a", ocres), gauge("foo.com/gauge-b", nil)})
+		assert.ErrorIs(t, err, errMultipleResources)
+		assert.Empty(t, batch.Metrics)
+	})
+}
+
+func TestConvertMetricsBatched(t *testing.T) {
+	now := time.Now()
+	scope := instrumentation.Scope{Name: scopeName, Version: Version()}
+	resA := &ocresource.Resource{Type: "host", Labels: map[string]string{"R1": "A"}}
+	resB := &ocresource.Resource{Type: "host", Labels: map[string]string{"R1": "B"}}
+	gauge := func(name string, points int, ocres *ocresource.Resource) *ocmetricdata.Metric {
+		m := &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "series"}},
+			},
+			Resource: ocres,
+		}
+		for i := 0; i < points; i++ {
+			m.TimeSeries = append(m.TimeSeries, &ocmetricdata.TimeSeries{
+				LabelValues: []ocmetricdata.LabelValue{{Value: fmt.Sprint(i), Present: true}},
+				Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, int64(i))},
+			})
+		}
+		return m
+	}
+	input := []*ocmetricdata.Metric{
+		gauge("foo.com/gauge-a", 2, resA),
+		gauge("foo.com/gauge-b", 1, resA),
+		gauge("foo.com/gauge-d", 1, resB),
+		gauge("foo.com/gauge-c", 5, resA),
+	}
+	for _, tc := range []struct {
+		desc      string
+		maxPoints int
+		// expected are the metric names and point counts of each batch,
+		// along with the R1 resource attribute of the batch.
+		expected [][]string
+	}{
+		{
+			desc:      "no limit",
+			maxPoints: 0,
+			expected: [][]string{
+				{"A", "foo.com/gauge-a:2", "foo.com/gauge-b:1", "foo.com/gauge-c:5"},
+				{"B", "foo.com/gauge-d:1"},
+			},
+		},
+		{
+			desc:      "large limit",
+			maxPoints: 8,
+			expected: [][]string{
+				{"A", "foo.com/gauge-a:2", "foo.com/gauge-b:1", "foo.com/gauge-c:5"},
+				{"B", "foo.com/gauge-d:1"},
+			},
+		},
+		{
+			desc:      "metrics fill batches",
+			maxPoints: 3,
+			expected: [][]string{
+				{"A", "foo.com/gauge-a:2", "foo.com/gauge-b:1"},
+				{"A", "foo.com/gauge-c:3"},
+				{"A", "foo.com/gauge-c:2"},
+				{"B", "foo.com/gauge-d:1"},
+			},
+		},
+		{
+			desc:      "metrics are not split to fill batches",
+			maxPoints: 2,
+			expected: [][]string{
+				{"A", "foo.com/gauge-a:2"},
+				{"A", "foo.com/gauge-b:1"},
+				{"A", "foo.com/gauge-c:2"},
+				{"A", "foo.com/gauge-c:2"},
+				{"A", "foo.com/gauge-c:1"},
+				{"B", "foo.com/gauge-d:1"},
+			},
+		},
+		{
+			desc:      "one point per batch",
+			maxPoints: 1,
+			expected: [][]string{
+				{"A", "foo.com/gauge-a:1"},
+				{"A", "foo.com/gauge-a:1"},
+				{"A", "foo.com/gauge-b:1"},
+				{"A", "foo.com/gauge-c:1"},
+				{"A", "foo.com/gauge-c:1"},
+				{"A", "foo.com/gauge-c:1"},
+				{"A", "foo.com/gauge-c:1"},
+				{"A", "foo.com/gauge-c:1"},
+				{"B", "foo.com/gauge-d:1"},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			batches, err := ConvertMetricsBatched(input, tc.maxPoints)
+			require.NoError(t, err)
+			var got [][]string
+			seen := make(map[string]map[int64]bool)
+			for _, batch := range batches {
+				r1, _ := batch.Resource.Set().Value("R1")
+				summary := []string{r1.AsString()}
+				require.Len(t, batch.ScopeMetrics, 1)
+				assert.Equal(t, scope, batch.ScopeMetrics[0].Scope)
+				for _, m := range batch.ScopeMetrics[0].Metrics {
+					points := m.Data.(metricdata.Gauge[int64]).DataPoints
+					summary = append(summary, fmt.Sprintf("%s:%d", m.Name, len(points)))
+					if seen[m.Name] == nil {
+						seen[m.Name] = make(map[int64]bool)
+					}
+					for _, p := range points {
+						assert.False(t, seen[m.Name][p.Value], "point converted once")
+						seen[m.Name][p.Value] = true
+					}
+				}
+				got = append(got, summary)
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestConvertMetricsBatchedScopePerMetric(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 2))),
+	}
+
+	batches, err := ConvertMetricsBatched(input, 0, WithScopePerMetric())
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	var scopes []instrumentation.Scope
+	for _, sm := range batches[0].ScopeMetrics {
+		require.Len(t, sm.Metrics, 1)
+		assert.Equal(t, sm.Scope.Name, sm.Metrics[0].Name)
+		scopes = append(scopes, sm.Scope)
+	}
+	assert.Equal(t, []instrumentation.Scope{
+		{Name: "foo.com/gauge-a", Version: Version()},
+		{Name: "foo.com/gauge-b", Version: Version()},
+	}, scopes)
+
+	batch, err := ConvertWithResource(input, WithScopePerMetric())
+	require.NoError(t, err)
+	assert.Equal(t, instrumentation.Scope{Name: scopeName, Version: Version()}, batch.Scope, "other conversions use the bridge scope")
+	assert.Len(t, batch.Metrics, 2)
+}
+
+func TestConvertMetricsBatchedSourceLibrary(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("grpc.io/client/roundtrip_latency", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 2))),
+	}
+	library := func(metricName string) string {
+		if strings.HasPrefix(metricName, "grpc.io/") {
+			return "ocgrpc"
+		}
+		return ""
+	}
+
+	batches, err := ConvertMetricsBatched(input, 0, WithSourceLibrary(library))
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	scopes := make(map[string][]string)
+	for _, sm := range batches[0].ScopeMetrics {
+		for _, m := range sm.Metrics {
+			scopes[sm.Scope.Name] = append(scopes[sm.Scope.Name], m.Name)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"ocgrpc":  {"grpc.io/client/roundtrip_latency"},
+		scopeName: {"foo.com/gauge-a"},
+	}, scopes)
+}
+
+func TestConvertMetricsGrouped(t *testing.T) {
+	now := time.Now()
+	dist := ocDistribution(0, nil, 0)
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeFloat64, nil, ocSeries(now, nil, ocmetricdata.NewFloat64Point(now, 1))),
+		ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil, ocSeries(now, nil, ocmetricdata.NewDistributionPoint(now, dist))),
+		ocMetric("", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 2))),
+		ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil),
+	}
+
+	grouped, err := ConvertMetricsGrouped(input, WithDefaultMetricName("unnamed-"))
+	assert.Error(t, err)
+	names := make(map[reflect.Type][]string)
+	for typ, metrics := range grouped {
+		for _, m := range metrics {
+			names[typ] = append(names[typ], m.Name)
+		}
+	}
+	assert.Equal(t, map[reflect.Type][]string{
+		reflect.TypeOf(metricdata.Gauge[int64]{}):       {"foo.com/gauge-a", "unnamed-3"},
+		reflect.TypeOf(metricdata.Sum[float64]{}):       {"foo.com/sum-a"},
+		reflect.TypeOf(metricdata.Histogram[float64]{}): {"foo.com/histogram-a"},
+	}, names)
+}
+
+func TestConvertMetricsByTemporality(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/sum-b", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil),
+	}
+	names := func(metrics []metricdata.Metrics) []string {
+		var names []string
+		for _, m := range metrics {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	cumulative, delta, err := ConvertMetricsByTemporality(input, WithTemporalityByName(func(name string, _ ocmetricdata.Type) metricdata.Temporality {
+		if name == "foo.com/sum-b" {
+			return metricdata.DeltaTemporality
+		}
+		return metricdata.CumulativeTemporality
+	}))
+	assert.Error(t, err)
+	assert.Equal(t, []string{"foo.com/gauge-a", "foo.com/sum-a"}, names(cumulative))
+	assert.Equal(t, []string{"foo.com/sum-b"}, names(delta))
+}
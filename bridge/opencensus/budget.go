@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// ConvertMetricsBudgeted converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, in order, until converting the next metric would make
+// the EstimateSize of the converted metrics exceed maxBytes. It returns the
+// converted metrics and the metrics that remain to be converted, e.g. in the
+// next export cycle. The first converted metric is always returned, even if
+// it exceeds maxBytes on its own, so that repeated calls convert all
+// metrics.
+//
+// Each call is a new conversion. Use the ConvertMetricsBudgeted method of a
+// MetricProducer to check the remaining metrics for name collisions against
+// those of the previous calls.
+func ConvertMetricsBudgeted(ocmetrics []*ocmetricdata.Metric, maxBytes int, opts ...MetricOption) (converted []metricdata.Metrics, remaining []*ocmetricdata.Metric, err error) {
+	return internal.ConvertMetricsBudgeted(ocmetrics, maxBytes, newMetricConfig(opts).converterOptions...)
+}
+
+// ConvertMetricsBudgeted converts ocmetrics like the ConvertMetricsBudgeted
+// function, with the options and state of p, e.g. to delta temporality.
+//
+// Calling ConvertMetricsBudgeted with the returned remaining metrics
+// continues the conversion: the metrics are checked for name collisions and
+// incompatible types against those of the previous calls, and metrics with
+// an empty name are named after their index in the metrics of the first
+// call. Calling it with any other metrics starts a new conversion. Stats and
+// DroppedMetrics report the last call.
+func (p *MetricProducer) ConvertMetricsBudgeted(ocmetrics []*ocmetricdata.Metric, maxBytes int) (converted []metricdata.Metrics, remaining []*ocmetricdata.Metric, err error) {
+	p.converter.mu.Lock()
+	defer p.converter.mu.Unlock()
+	return p.converter.converter.ConvertMetricsBudgeted(ocmetrics, maxBytes)
+}
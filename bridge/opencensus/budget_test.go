@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestConvertMetricsBudgeted(t *testing.T) {
+	now := time.Now()
+	gauge := func(name string) *ocmetricdata.Metric {
+		return ocMetric(name, ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1)))
+	}
+	// The sum has the name of the first gauge.
+	sum := ocMetric("foo.com/gauge-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1)))
+	input := []*ocmetricdata.Metric{gauge("foo.com/gauge-a"), gauge(""), sum}
+	names := func(metrics []metricdata.Metrics) []string {
+		var names []string
+		for _, m := range metrics {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	t.Run("function", func(t *testing.T) {
+		converted, remaining, err := ConvertMetricsBudgeted(input, 0, WithDefaultMetricName("unnamed-"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foo.com/gauge-a"}, names(converted))
+		assert.Equal(t, input[1:], remaining)
+
+		// Each call is a new conversion.
+		converted, remaining, err = ConvertMetricsBudgeted(remaining, 1<<20, WithDefaultMetricName("unnamed-"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"unnamed-0", "foo.com/gauge-a"}, names(converted))
+		assert.Empty(t, remaining)
+
+		converted, remaining, err = ConvertMetricsBudgeted(input, 1<<20, WithDefaultMetricName("unnamed-"))
+		assert.Error(t, err, "incompatible types")
+		assert.Equal(t, []string{"foo.com/gauge-a", "unnamed-1"}, names(converted))
+		assert.Empty(t, remaining)
+	})
+
+	t.Run("producer", func(t *testing.T) {
+		producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithDefaultMetricName("unnamed-"))
+		var got []string
+		var errs int
+		for remaining := input; len(remaining) > 0; {
+			var converted []metricdata.Metrics
+			var err error
+			converted, remaining, err = producer.ConvertMetricsBudgeted(remaining, 0)
+			if err != nil {
+				errs++
+			}
+			got = append(got, names(converted)...)
+		}
+		assert.Equal(t, []string{"foo.com/gauge-a", "unnamed-1"}, got)
+		assert.Equal(t, 1, errs, "the incompatible types across calls are reported")
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// SparseBucket is the count of a histogram bucket with a non-zero count.
+type SparseBucket = internal.SparseBucket
+
+// SparseBucketCounts is a sparse representation of the bucket counts of a
+// histogram data point, for exporters to backends that support sparse
+// histograms. Its Dense method returns the dense bucket counts it
+// represents.
+type SparseBucketCounts = internal.SparseBucketCounts
+
+// CompactHistogram returns the sparse representation of the bucket counts of
+// p: the index and count of each bucket with a non-zero count. p is not
+// modified.
+func CompactHistogram[N int64 | float64](p metricdata.HistogramDataPoint[N]) SparseBucketCounts {
+	return internal.CompactHistogram(p)
+}
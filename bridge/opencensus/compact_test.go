@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCompactHistogram(t *testing.T) {
+	now := time.Now()
+	batch, err := ConvertWithResource([]*ocmetricdata.Metric{
+		ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+			ocSeries(now, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(12, []float64{1, 2, 5}, 0, 3, 0, 1))),
+		),
+	})
+	require.NoError(t, err)
+	require.Len(t, batch.Metrics, 1)
+	histogram, ok := batch.Metrics[0].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+
+	sparse := CompactHistogram(histogram.DataPoints[0])
+	assert.Equal(t, SparseBucketCounts{
+		Len:     4,
+		Buckets: []SparseBucket{{Index: 1, Count: 3}, {Index: 3, Count: 1}},
+	}, sparse)
+	assert.Equal(t, histogram.DataPoints[0].BucketCounts, sparse.Dense())
+}
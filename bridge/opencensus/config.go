@@ -15,7 +15,19 @@
 package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -56,10 +68,908 @@ func WithTracerProvider(tp trace.TracerProvider) TraceOption {
 	})
 }
 
-type metricConfig struct{}
+// newMetricConfig returns a config configured with options.
+func newMetricConfig(options []MetricOption) metricConfig {
+	var conf metricConfig
+	for _, o := range options {
+		conf = o.apply(conf)
+	}
+	return conf
+}
+
+type metricConfig struct {
+	// converterOptions configure the conversion of OpenCensus metrics.
+	converterOptions []internal.Option
+	// enrich returns the attributes added to the data points converted by a
+	// producer, from the context of the collection.
+	enrich func(context.Context) []attribute.KeyValue
+	// scopeOptions configure the scopes of converted metrics. They only
+	// apply to conversions that can return metrics in several scopes.
+	scopeOptions []internal.Option
+}
 
 // MetricOption applies a configuration option value to an OpenCensus bridge
 // MetricProducer.
 type MetricOption interface {
 	apply(metricConfig) metricConfig
 }
+
+// metricOptionFunc applies a set of options to a config.
+type metricOptionFunc func(metricConfig) metricConfig
+
+// apply returns a config with option(s) applied.
+func (o metricOptionFunc) apply(conf metricConfig) metricConfig {
+	return o(conf)
+}
+
+// converterOption returns a MetricOption that configures the conversion of
+// OpenCensus metrics with opt.
+func converterOption(opt internal.Option) MetricOption {
+	return metricOptionFunc(func(conf metricConfig) metricConfig {
+		conf.converterOptions = append(conf.converterOptions, opt)
+		return conf
+	})
+}
+
+// scopeOption returns a MetricOption that configures the scopes of converted
+// metrics with opt.
+func scopeOption(opt internal.Option) MetricOption {
+	return metricOptionFunc(func(conf metricConfig) metricConfig {
+		conf.scopeOptions = append(conf.scopeOptions, opt)
+		return conf
+	})
+}
+
+// WithDefaultMetricName names metrics that have an empty name with prefix
+// followed by the index of the metric in the converted batch, instead of
+// dropping them.
+//
+// By default, metrics with an empty name are dropped and an error is
+// returned.
+func WithDefaultMetricName(prefix string) MetricOption {
+	return converterOption(internal.WithDefaultMetricName(prefix))
+}
+
+// WithLatestGaugePointOnly converts only the point with the latest time of
+// each gauge time series. Sums and histograms are not affected.
+//
+// By default, all points of gauge time series are converted.
+func WithLatestGaugePointOnly() MetricOption {
+	return converterOption(internal.WithLatestGaugePointOnly())
+}
+
+// WithMaxAttributeCount limits the number of attributes of converted data
+// points to n. Only the first n present OpenCensus labels, in the order of
+// the metric descriptor label keys, are kept and the remaining labels are
+// dropped with an error. Data points of time series that have the same
+// attributes once limited are merged as described for
+// WithAttributeAllowList. A negative n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxAttributeCount(n int) MetricOption {
+	return converterOption(internal.WithMaxAttributeCount(n))
+}
+
+// WithAttributeKeyMapper maps the keys of converted attributes with mapper,
+// e.g. to align OpenCensus label keys with semantic conventions. If mapper
+// maps two label keys of a time series to the same attribute key, an error
+// is returned and the value of the last label is used. Data points of time
+// series that have the same attributes after mapping are merged as described
+// for WithAttributeAllowList.
+//
+// By default, label keys are used as attribute keys unchanged.
+func WithAttributeKeyMapper(mapper func(attribute.Key) attribute.Key) MetricOption {
+	return converterOption(internal.WithAttributeKeyMapper(mapper))
+}
+
+// WithMaxHistogramBuckets limits the number of buckets of converted histogram
+// data points to n by merging adjacent buckets, and returns an error noting
+// the downsampling. The total count and sum are preserved. A non-positive n
+// means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxHistogramBuckets(n int) MetricOption {
+	return converterOption(internal.WithMaxHistogramBuckets(n))
+}
+
+// WithSortPointsByTime sorts the converted data points of each time series by
+// time, in ascending order, for producers that emit points out of order.
+//
+// By default, data points are in the order of the OpenCensus points.
+func WithSortPointsByTime() MetricOption {
+	return converterOption(internal.WithSortPointsByTime())
+}
+
+// WithMaxErrors limits the number of errors reported by a conversion to n.
+// Further errors are summarized by a single error with the number of omitted
+// errors. Errors that cause data to be dropped still do so when they are
+// omitted. A negative n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxErrors(n int) MetricOption {
+	return converterOption(internal.WithMaxErrors(n))
+}
+
+// WithFallbackResource sets the resource of metrics that do not have an
+// OpenCensus resource, e.g. resource.Default(), when they are converted by
+// ConvertWithResource or ConvertMetricsBatched.
+//
+// By default, metrics without an OpenCensus resource have an empty resource.
+func WithFallbackResource(res *resource.Resource) MetricOption {
+	return converterOption(internal.WithFallbackResource(res))
+}
+
+// WithAttributeAllowList converts only the OpenCensus labels with one of keys
+// to attributes. All other labels are dropped.
+//
+// Dropping labels can make distinct OpenCensus time series have the same
+// attributes. Their data points that also have the same time are merged: sum
+// values and histogram counts, sums, and bucket counts are added, and gauges
+// keep the value of the last data point. Histogram points with different
+// bounds are kept separately with an error.
+//
+// By default, all labels are converted.
+func WithAttributeAllowList(keys ...string) MetricOption {
+	return converterOption(internal.WithAttributeAllowList(keys...))
+}
+
+// WithTemporalityByName selects the temporality of converted sums and
+// histograms with selector, which is called with the name and OpenCensus
+// type of each cumulative metric. If selector returns an invalid temporality,
+// cumulative temporality is used and an error is returned.
+//
+// Converting to delta temporality is stateful: a producer retains the last
+// value of each time series to compute the change since its previous
+// collection.
+//
+// By default, all sums and histograms have cumulative temporality.
+func WithTemporalityByName(selector func(name string, ocType ocmetricdata.Type) metricdata.Temporality) MetricOption {
+	return converterOption(internal.WithTemporalityByName(selector))
+}
+
+// WithRejectNegativeBounds drops OpenCensus distributions that have negative
+// bucket bounds, and returns an error.
+//
+// By default, negative bounds are converted.
+func WithRejectNegativeBounds() MetricOption {
+	return converterOption(internal.WithRejectNegativeBounds())
+}
+
+// WithMaxDescriptionLength limits the length of converted metric
+// descriptions to n bytes. Longer descriptions are truncated on a rune
+// boundary with an ellipsis, and an error is returned. A non-positive n
+// means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxDescriptionLength(n int) MetricOption {
+	return converterOption(internal.WithMaxDescriptionLength(n))
+}
+
+// WithDetectResets returns an error for each data point of a cumulative sum
+// or histogram that has a later start time than a previous data point with
+// the same attributes, which means the time series was reset. The data
+// points are converted unchanged.
+//
+// By default, resets are not reported.
+func WithDetectResets() MetricOption {
+	return converterOption(internal.WithDetectResets())
+}
+
+// WithAggregationOverride converts each metric as the OpenCensus type
+// returned by override, which is called with the name and declared
+// OpenCensus type of the metric, e.g. to convert a gauge that is actually
+// cumulative to a sum. If override returns a type that cannot be converted,
+// the declared type is used and an error is returned.
+//
+// By default, metrics are converted as their declared type.
+func WithAggregationOverride(override func(name string, ocType ocmetricdata.Type) ocmetricdata.Type) MetricOption {
+	return converterOption(internal.WithAggregationOverride(override))
+}
+
+// NaNPolicy determines how NaN values of data points are converted.
+type NaNPolicy = internal.NaNPolicy
+
+const (
+	// NaNKeep converts NaN values unchanged.
+	NaNKeep = internal.NaNKeep
+	// NaNZero converts NaN values to 0.
+	NaNZero = internal.NaNZero
+	// NaNDrop drops data points with a NaN value and returns an error.
+	NaNDrop = internal.NaNDrop
+)
+
+// WithGaugeNaNPolicy converts NaN values of float64 gauges according to
+// policy. Int64 gauges, sums, and histograms are not affected.
+//
+// By default, NaN values are kept.
+func WithGaugeNaNPolicy(policy NaNPolicy) MetricOption {
+	return converterOption(internal.WithGaugeNaNPolicy(policy))
+}
+
+// WithMetricNamePrefix prepends prefix to the names of converted metrics. A
+// "." separator is inserted between prefix and the name, unless prefix
+// already ends with ".", "/", or "_". The metric names passed to other
+// options are not prefixed.
+//
+// By default, metric names are not prefixed.
+func WithMetricNamePrefix(prefix string) MetricOption {
+	return converterOption(internal.WithMetricNamePrefix(prefix))
+}
+
+// WithLabelDescriptionsAsExemplarAttrs adds the descriptions of the label
+// keys of each histogram as "label.<key>.description" filtered attributes of
+// the exemplars of its first data point that has exemplars.
+//
+// By default, label key descriptions are not converted.
+func WithLabelDescriptionsAsExemplarAttrs() MetricOption {
+	return converterOption(internal.WithLabelDescriptionsAsExemplarAttrs())
+}
+
+// WithValidateUTF8 replaces invalid UTF-8 sequences in converted attribute
+// values with the Unicode replacement character, and returns an error for
+// each such value.
+//
+// By default, attribute values are not validated.
+func WithValidateUTF8() MetricOption {
+	return converterOption(internal.WithValidateUTF8())
+}
+
+// WithDetectNonMonotonic converts cumulative sums whose values decrease as
+// non-monotonic sums, and returns an error for the first decrease of each
+// sum. Detection is stateful: a sum that decreased once is converted as
+// non-monotonic by all later collections of a producer.
+//
+// By default, all sums are converted as monotonic.
+func WithDetectNonMonotonic() MetricOption {
+	return converterOption(internal.WithDetectNonMonotonic())
+}
+
+// WithMonotonicTimestamps moves each data point with the same time as the
+// previous data point of its time series to a nanosecond after it, so that
+// the times of the data points of a time series are distinct.
+//
+// By default, the times of OpenCensus points are converted unchanged.
+func WithMonotonicTimestamps() MetricOption {
+	return converterOption(internal.WithMonotonicTimestamps())
+}
+
+// WithInt64Labels converts the values of the OpenCensus labels with one of
+// keys to int64 attributes. Values that are not base 10 integers in the int64
+// range are converted to string attributes and an error is returned.
+//
+// By default, all label values are converted to string attributes.
+func WithInt64Labels(keys ...string) MetricOption {
+	return converterOption(internal.WithInt64Labels(keys...))
+}
+
+// WithSourceAttribute adds a key attribute with value to the resource of
+// metrics converted by ConvertWithResource or ConvertMetricsBatched, e.g. to
+// identify the OpenCensus producer of the metrics. It replaces a resource
+// attribute with the same key.
+//
+// By default, no attributes are added to resources.
+func WithSourceAttribute(key, value string) MetricOption {
+	return converterOption(internal.WithSourceAttribute(key, value))
+}
+
+// WithHistogramExtremaFromExemplars sets the Min and Max of converted
+// histogram data points to the minimum and maximum values of their
+// exemplars. These are approximations, as OpenCensus distributions do not
+// record their minimum and maximum.
+//
+// By default, the Min and Max of histogram data points are unset.
+func WithHistogramExtremaFromExemplars() MetricOption {
+	return converterOption(internal.WithHistogramExtremaFromExemplars())
+}
+
+// WithView transforms the converted aggregation of each metric with view,
+// which is called with the name and converted aggregation of the metric. If
+// view returns an aggregation of a type other than metricdata.Gauge,
+// metricdata.Sum, or metricdata.Histogram[float64], the converted aggregation
+// is used and an error is returned.
+//
+// By default, converted aggregations are not transformed.
+func WithView(view func(name string, agg metricdata.Aggregation) metricdata.Aggregation) MetricOption {
+	return converterOption(internal.WithView(view))
+}
+
+// WithStringInterner interns the string values of converted attributes, so
+// that equal values share the same memory. Interned values are retained for
+// the lifetime of a producer.
+//
+// By default, label values are not interned.
+func WithStringInterner() MetricOption {
+	return converterOption(internal.WithStringInterner())
+}
+
+// WithSortByStartTime sorts the data points of each converted metric by start
+// time, then time, in ascending order, across all its time series.
+//
+// By default, data points are in the order of the OpenCensus time series and
+// points.
+func WithSortByStartTime() MetricOption {
+	return converterOption(internal.WithSortByStartTime())
+}
+
+// ResetHandling determines how resets of cumulative sums are converted.
+type ResetHandling = internal.ResetHandling
+
+const (
+	// ResetPassthrough converts the data points after a reset unchanged.
+	ResetPassthrough = internal.ResetPassthrough
+	// ResetZero adds a data point with a zero value at the start time of the
+	// first data point after a reset, before that data point.
+	ResetZero = internal.ResetZero
+	// ResetSkip drops the first data point after a reset.
+	ResetSkip = internal.ResetSkip
+)
+
+// WithResetHandling converts resets of cumulative sums according to
+// handling. A sum time series is reset when a data point has a later start
+// time than the previous data points of the time series. Resets between the
+// collections of a producer are detected as well.
+//
+// By default, resets are passed through unchanged.
+func WithResetHandling(handling ResetHandling) MetricOption {
+	return converterOption(internal.WithResetHandling(handling))
+}
+
+// WithBooleanLabels converts the values of the OpenCensus labels with one of
+// keys to bool attributes. Values other than "true" and "false", in any case,
+// are converted to string attributes and an error is returned.
+//
+// By default, all label values are converted to string attributes.
+func WithBooleanLabels(keys ...string) MetricOption {
+	return converterOption(internal.WithBooleanLabels(keys...))
+}
+
+// WithMetricScopedKeyMapper maps the keys of converted attributes with
+// mapper, which is called with the name of the metric and the attribute key,
+// after any mapping by WithAttributeKeyMapper.
+//
+// By default, label keys are used as attribute keys unchanged.
+func WithMetricScopedKeyMapper(mapper func(metricName string, key attribute.Key) attribute.Key) MetricOption {
+	return converterOption(internal.WithMetricScopedKeyMapper(mapper))
+}
+
+// WithValidateBucketSum returns an error for each OpenCensus distribution
+// with bucket counts that do not sum to its count. If drop is true, the data
+// points of these distributions are dropped, otherwise they are converted.
+//
+// By default, bucket counts are not checked.
+func WithValidateBucketSum(drop bool) MetricOption {
+	return converterOption(internal.WithValidateBucketSum(drop))
+}
+
+// WithTimestampSanity drops data points with a time before earliest or after
+// latest, and returns an error for each.
+//
+// By default, data points are converted regardless of their time.
+func WithTimestampSanity(earliest, latest time.Time) MetricOption {
+	return converterOption(internal.WithTimestampSanity(earliest, latest))
+}
+
+// WithStableOrdering sorts converted metrics by name, and their data points
+// by attributes, then time, once a producer sees the same metric names in a
+// different order than in its previous collection.
+//
+// By default, metrics and data points are converted in the OpenCensus order.
+func WithStableOrdering() MetricOption {
+	return converterOption(internal.WithStableOrdering())
+}
+
+// WithExemplarResolver adds the exemplars returned by resolver to those of
+// each converted histogram data point. It is called with the name of the
+// metric, without any prefix, and the converted attributes of each data
+// point.
+//
+// By default, histogram data points only have the exemplars of their
+// OpenCensus buckets.
+func WithExemplarResolver(resolver func(metricName string, attrs attribute.Set) []metricdata.Exemplar[float64]) MetricOption {
+	return converterOption(internal.WithExemplarResolver(resolver))
+}
+
+// WithEmptyAttributeSetKey sets the attributes of converted data points that
+// would have no attributes to a single key attribute with value.
+//
+// By default, these data points have the empty attribute set.
+func WithEmptyAttributeSetKey(key, value string) MetricOption {
+	return converterOption(internal.WithEmptyAttributeSetKey(key, value))
+}
+
+// WithUnitMapper maps the units of converted metrics with mapper, which is
+// called with the name of the metric, without any prefix, and its OpenCensus
+// unit.
+//
+// By default, the OpenCensus unit is used unchanged.
+func WithUnitMapper(mapper func(metricName, unit string) string) MetricOption {
+	return converterOption(internal.WithUnitMapper(mapper))
+}
+
+// WithBoundsRounding rounds the bounds of converted histograms to decimals
+// decimal places. Bucket counts are not changed. Data points with distinct
+// bounds that are equal after rounding are dropped, and an error is
+// returned. A negative decimals disables rounding.
+//
+// By default, bounds are not rounded.
+func WithBoundsRounding(decimals int) MetricOption {
+	return converterOption(internal.WithBoundsRounding(decimals))
+}
+
+// WithNumericLabelTypes converts the values of the OpenCensus labels with the
+// keys of types to attributes of the type of the key: attribute.INT64,
+// attribute.FLOAT64, or attribute.BOOL. Values that cannot be parsed as that
+// type are converted to string attributes and an error is returned.
+//
+// By default, all label values are converted to string attributes.
+func WithNumericLabelTypes(types map[string]attribute.Type) MetricOption {
+	return converterOption(internal.WithNumericLabelTypes(types))
+}
+
+// WithReaderTemporality selects the temporality of converted sums and
+// histograms with selector, e.g. the temporality selector of the exporter
+// of the converted metrics. Cumulative OpenCensus sums are selected as
+// counters, and cumulative distributions as histograms. It replaces the
+// selector set by WithTemporalityByName.
+//
+// By default, all sums and histograms have cumulative temporality.
+func WithReaderTemporality(selector metric.TemporalitySelector) MetricOption {
+	return converterOption(internal.WithReaderTemporality(selector))
+}
+
+// WithTolerateExtraValues converts time series with a different number of
+// label values than their metric has label keys, and returns an error for
+// each. Extra values are converted to attributes with keys of keyPrefix
+// followed by the index of the value.
+//
+// By default, time series with a different number of label values than label
+// keys are dropped, and an error is returned.
+func WithTolerateExtraValues(keyPrefix string) MetricOption {
+	return converterOption(internal.WithTolerateExtraValues(keyPrefix))
+}
+
+// WithMaxExemplarsPerPoint limits the number of exemplars of converted
+// histogram data points to n, retaining those with a trace context, then
+// those with higher values, and returns an error noting the dropped
+// exemplars. A non-positive n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxExemplarsPerPoint(n int) MetricOption {
+	return converterOption(internal.WithMaxExemplarsPerPoint(n))
+}
+
+// WithTimingHistogram calls recorder with the name of each OpenCensus metric
+// and the duration of its conversion, after it is converted.
+//
+// By default, conversion durations are not measured.
+func WithTimingHistogram(recorder func(metricName string, d time.Duration)) MetricOption {
+	return converterOption(internal.WithTimingHistogram(recorder))
+}
+
+// WithClock sets the function called for the current time during conversions,
+// e.g. to measure the durations reported to WithTimingHistogram. It is
+// intended to make conversions deterministic in tests. A nil now is ignored.
+//
+// By default, time.Now is used.
+func WithClock(now func() time.Time) MetricOption {
+	return converterOption(internal.WithClock(now))
+}
+
+// WithPreserveSumOfSquaredDeviation adds the sum of squared deviation of
+// OpenCensus distributions to the attributes of converted histogram data
+// points as a float64 attribute with key. Each data point of a time series
+// then has distinct attributes.
+//
+// By default, the sum of squared deviation is dropped.
+func WithPreserveSumOfSquaredDeviation(key string) MetricOption {
+	return converterOption(internal.WithPreserveSumOfSquaredDeviation(key))
+}
+
+// WithDescriptionFallback sets the description of converted metrics whose
+// OpenCensus description is empty to the one returned by fallback, which is
+// called with the name of the metric, without any prefix.
+//
+// By default, empty descriptions are kept empty.
+func WithDescriptionFallback(fallback func(name string) string) MetricOption {
+	return converterOption(internal.WithDescriptionFallback(fallback))
+}
+
+// SemConvRegistry is a registry of the metric names and attribute keys
+// defined by semantic conventions.
+type SemConvRegistry = internal.SemConvRegistry
+
+// WithSemConvValidation returns an error for each converted metric name, and
+// each attribute key of a metric, that is not valid in registry. Metrics are
+// converted regardless.
+//
+// By default, names and keys are not validated.
+func WithSemConvValidation(registry SemConvRegistry) MetricOption {
+	return converterOption(internal.WithSemConvValidation(registry))
+}
+
+// WithPointFilter converts only the data points for which filter returns
+// true. filter is called with the name of the metric, without any prefix,
+// and the attributes and time of each data point.
+//
+// By default, all data points are converted.
+func WithPointFilter(filter func(metricName string, attrs attribute.Set, t time.Time) bool) MetricOption {
+	return converterOption(internal.WithPointFilter(filter))
+}
+
+// WithAttributeValueMapper maps the values of converted attributes with
+// mapper, which is called with the key, after any key mapping, and the
+// converted value of each attribute.
+//
+// By default, attribute values are not mapped.
+func WithAttributeValueMapper(mapper func(key attribute.Key, v attribute.Value) attribute.Value) MetricOption {
+	return converterOption(internal.WithAttributeValueMapper(mapper))
+}
+
+// WithCounterResetSplit sets the start time of the data point a cumulative
+// sum time series decreased at, and of its later data points, to the time of
+// that data point, as the counter was reset without a new start time.
+//
+// By default, the start times of sum data points are not changed.
+func WithCounterResetSplit() MetricOption {
+	return converterOption(internal.WithCounterResetSplit())
+}
+
+// WithHistogramSumRounding rounds the sums of converted histogram data points
+// to decimals decimal places. A negative decimals disables rounding.
+//
+// By default, sums are not rounded.
+func WithHistogramSumRounding(decimals int) MetricOption {
+	return converterOption(internal.WithHistogramSumRounding(decimals))
+}
+
+// WithSkipEmptyMetrics drops metrics without any data points instead of
+// converting them to metrics with no data points.
+//
+// By default, metrics without data points are converted.
+func WithSkipEmptyMetrics() MetricOption {
+	return converterOption(internal.WithSkipEmptyMetrics())
+}
+
+// InstrumentNameValidation determines how metrics whose names are not valid
+// OpenTelemetry instrument names are converted.
+type InstrumentNameValidation = internal.InstrumentNameValidation
+
+const (
+	// InstrumentNameOff converts metrics with invalid names unchanged.
+	InstrumentNameOff = internal.InstrumentNameOff
+	// InstrumentNameStrict drops metrics with invalid names.
+	InstrumentNameStrict = internal.InstrumentNameStrict
+	// InstrumentNameSanitize rewrites invalid names to valid ones.
+	InstrumentNameSanitize = internal.InstrumentNameSanitize
+)
+
+// WithInstrumentNameValidation converts metrics whose names, including the
+// metric name prefix, are not valid OpenTelemetry instrument names according
+// to validation.
+//
+// By default, names are not validated.
+func WithInstrumentNameValidation(validation InstrumentNameValidation) MetricOption {
+	return converterOption(internal.WithInstrumentNameValidation(validation))
+}
+
+// WithKeyValueBufferPool converts the attributes of each time series in
+// buffers of *[]attribute.KeyValue drawn from pool, to reduce allocations.
+//
+// By default, a new buffer is allocated for the attributes of each time
+// series.
+func WithKeyValueBufferPool(pool *sync.Pool) MetricOption {
+	return converterOption(internal.WithKeyValueBufferPool(pool))
+}
+
+// WithResourceAttributeTypes converts the values of the OpenCensus resource
+// labels with the keys of types to resource attributes of the type of the
+// key: attribute.INT64, attribute.FLOAT64, or attribute.BOOL. Resources are
+// converted by ConvertWithResource and ConvertMetricsBatched.
+//
+// By default, all resource labels are converted to string attributes.
+func WithResourceAttributeTypes(types map[string]attribute.Type) MetricOption {
+	return converterOption(internal.WithResourceAttributeTypes(types))
+}
+
+// IncompatibleTypeHandling determines how metrics with the name of a metric
+// of an incompatible type are converted.
+type IncompatibleTypeHandling = internal.IncompatibleTypeHandling
+
+const (
+	// IncompatibleTypeError drops the metrics with the name of an earlier
+	// metric of another type and returns an error.
+	IncompatibleTypeError = internal.IncompatibleTypeError
+	// IncompatibleTypeFirst drops the metrics with the name of an earlier
+	// metric of another type, keeping the type first seen.
+	IncompatibleTypeFirst = internal.IncompatibleTypeFirst
+	// IncompatibleTypeRename appends the kind of aggregation of the metrics
+	// with the name of an earlier metric of another type to their name.
+	IncompatibleTypeRename = internal.IncompatibleTypeRename
+)
+
+// WithIncompatibleTypeHandling converts metrics that have the same name but
+// are converted to aggregations of different types according to handling.
+//
+// By default, metrics with the name of an earlier metric of another type are
+// dropped and an error is returned.
+func WithIncompatibleTypeHandling(handling IncompatibleTypeHandling) MetricOption {
+	return converterOption(internal.WithIncompatibleTypeHandling(handling))
+}
+
+// WithContextEnrichment adds the attributes returned by enrich to the
+// attributes of each data point converted by a producer. enrich is called
+// with the context of each collection, e.g. to add a tenant from its
+// baggage. Invalid attributes it returns are ignored.
+//
+// By default, no attributes are added.
+func WithContextEnrichment(enrich func(ctx context.Context) []attribute.KeyValue) MetricOption {
+	return metricOptionFunc(func(conf metricConfig) metricConfig {
+		conf.enrich = enrich
+		return conf
+	})
+}
+
+// WithEnrichmentOverride gives the attributes of WithContextEnrichment
+// precedence over the attributes converted from the labels of data points
+// with the same key.
+//
+// By default, the attributes converted from labels take precedence.
+func WithEnrichmentOverride() MetricOption {
+	return converterOption(internal.WithEnrichmentOverride())
+}
+
+// WithUnitOverride replaces the units of the converted metrics named in
+// overrides, without any prefix, with the unit they are mapped to. Overrides
+// take precedence over WithUnitMapper.
+//
+// By default, units are converted unchanged.
+func WithUnitOverride(overrides map[string]string) MetricOption {
+	return converterOption(internal.WithUnitOverride(overrides))
+}
+
+// FloatToIntMode determines how float64 values are converted to int64.
+type FloatToIntMode = internal.FloatToIntMode
+
+const (
+	// FloatToIntNone does not convert float64 values.
+	FloatToIntNone = internal.FloatToIntNone
+	// FloatToIntRound rounds values to the nearest integer, and half away
+	// from zero.
+	FloatToIntRound = internal.FloatToIntRound
+	// FloatToIntFloor rounds values down, towards negative infinity.
+	FloatToIntFloor = internal.FloatToIntFloor
+	// FloatToIntTruncate rounds values towards zero.
+	FloatToIntTruncate = internal.FloatToIntTruncate
+)
+
+// WithFloatGaugeToInt converts OpenCensus float64 gauges to
+// metricdata.Gauge[int64], rounding their values according to mode. Data
+// points with a NaN value, or a value outside of the int64 range, are
+// dropped and an error is returned.
+//
+// By default, float64 gauges are converted to metricdata.Gauge[float64].
+func WithFloatGaugeToInt(mode FloatToIntMode) MetricOption {
+	return converterOption(internal.WithFloatGaugeToInt(mode))
+}
+
+// WithTimestampTruncation truncates the start times and times of converted
+// data points to a multiple of d. A non-positive d disables truncation.
+//
+// By default, times are converted with full precision.
+func WithTimestampTruncation(d time.Duration) MetricOption {
+	return converterOption(internal.WithTimestampTruncation(d))
+}
+
+// WithReportNilMetrics returns an error noting the number and indices of the
+// nil metrics skipped by a conversion.
+//
+// By default, nil metrics are skipped silently.
+func WithReportNilMetrics() MetricOption {
+	return converterOption(internal.WithReportNilMetrics())
+}
+
+// WithUnsupportedAggregationHandler converts the metrics of OpenCensus types
+// that are not supported, e.g. summaries, with handler. If handler returns an
+// error, or no aggregation, the metric is dropped and the error is returned.
+//
+// By default, metrics of unsupported types are dropped with an error.
+func WithUnsupportedAggregationHandler(handler func(*ocmetricdata.Metric) (metricdata.Aggregation, error)) MetricOption {
+	return converterOption(internal.WithUnsupportedAggregationHandler(handler))
+}
+
+// WithMonotonicCorrection raises each value of a cumulative sum that is lower
+// than the previous value of its time series with the same start time to
+// that value, and returns an error noting the number of raised values.
+//
+// By default, the values of sums are converted unchanged.
+func WithMonotonicCorrection() MetricOption {
+	return converterOption(internal.WithMonotonicCorrection())
+}
+
+// WithResourceGroupingKeys groups the metrics converted by
+// ConvertWithResource or ConvertMetricsBatched by the resource attributes
+// with one of keys, instead of by their whole resource. The other resource
+// attributes of all metrics of a group are those of its first metric.
+//
+// By default, metrics are grouped by resource equality.
+func WithResourceGroupingKeys(keys ...string) MetricOption {
+	return converterOption(internal.WithResourceGroupingKeys(keys...))
+}
+
+// WithPointTimeFallback replaces the zero times of OpenCensus points with
+// the start time of their time series, or the current time if it is zero.
+//
+// By default, zero times are converted unchanged.
+func WithPointTimeFallback() MetricOption {
+	return converterOption(internal.WithPointTimeFallback())
+}
+
+// WithScopePerMetric includes each metric converted by ConvertMetricsBatched
+// in its own scope, named after the metric. It does not apply to other
+// conversions, which return the metrics of the bridge scope.
+//
+// By default, all metrics are included in the scope of the bridge.
+func WithScopePerMetric() MetricOption {
+	return scopeOption(internal.WithScopePerMetric())
+}
+
+// ValueTypeMismatchHandling determines how OpenCensus points with a value of
+// another type than that of their metric are converted.
+type ValueTypeMismatchHandling = internal.ValueTypeMismatchHandling
+
+const (
+	// ValueTypeMismatchError drops the metric and returns an error.
+	ValueTypeMismatchError = internal.ValueTypeMismatchError
+	// ValueTypeMismatchDrop drops the point, converts the rest of the
+	// metric, and returns an error.
+	ValueTypeMismatchDrop = internal.ValueTypeMismatchDrop
+	// ValueTypeMismatchCoerce converts int64 values of float64 metrics to
+	// float64, and float64 values of int64 metrics to int64.
+	ValueTypeMismatchCoerce = internal.ValueTypeMismatchCoerce
+)
+
+// WithValueTypeMismatchHandling converts OpenCensus points with a value of
+// another type than that of their metric according to handling.
+//
+// By default, metrics with points of another value type are dropped and an
+// error is returned.
+func WithValueTypeMismatchHandling(handling ValueTypeMismatchHandling) MetricOption {
+	return converterOption(internal.WithValueTypeMismatchHandling(handling))
+}
+
+// WithAttributeCache caches up to 1024 attribute sets converted from the
+// labels of OpenCensus time series across the collections of a producer.
+//
+// By default, attribute sets are not cached.
+func WithAttributeCache() MetricOption {
+	return converterOption(internal.WithAttributeCache())
+}
+
+// WithAttributeCacheSize caches converted attribute sets like
+// WithAttributeCache, keeping at most n attribute sets. A non-positive n
+// disables the cache.
+//
+// By default, attribute sets are not cached.
+func WithAttributeCacheSize(n int) MetricOption {
+	return converterOption(internal.WithAttributeCacheSize(n))
+}
+
+// WithErrorPrefix wraps the error returned by a conversion with prefix. The
+// returned error reads "<prefix>: <errors>".
+//
+// By default, the prefix is "error converting from OpenCensus to
+// OpenTelemetry".
+func WithErrorPrefix(prefix string) MetricOption {
+	return converterOption(internal.WithErrorPrefix(prefix))
+}
+
+// WithSuccessHandler calls handler with each converted metric, as soon as it
+// is converted. handler is not called for dropped metrics.
+//
+// By default, no handler is called.
+func WithSuccessHandler(handler func(m metricdata.Metrics)) MetricOption {
+	return converterOption(internal.WithSuccessHandler(handler))
+}
+
+// WithGlobalResource merges res under the resource of each metric converted
+// by ConvertWithResource or ConvertMetricsBatched. The attributes of the
+// resource of a metric take precedence over those of res with the same key.
+//
+// By default, no global resource is merged.
+func WithGlobalResource(res *resource.Resource) MetricOption {
+	return converterOption(internal.WithGlobalResource(res))
+}
+
+// WithValidateExemplarBounds drops the exemplars of OpenCensus distribution
+// buckets whose value is outside of the bounds of their bucket, and returns
+// an error for each.
+//
+// By default, exemplars are converted without checking their bucket bounds.
+func WithValidateExemplarBounds() MetricOption {
+	return converterOption(internal.WithValidateExemplarBounds())
+}
+
+// WithMaxSeriesAge drops data points with a time more than d before the time
+// of the conversion. A non-positive d keeps all points.
+//
+// By default, data points are converted regardless of their age.
+func WithMaxSeriesAge(d time.Duration) MetricOption {
+	return converterOption(internal.WithMaxSeriesAge(d))
+}
+
+// WithLogger logs each dropped metric at l.V(4), and each warning at l.V(8),
+// in addition to returning them.
+//
+// By default, nothing is logged.
+func WithLogger(l logr.Logger) MetricOption {
+	return converterOption(internal.WithLogger(l))
+}
+
+// AttributeKeyValidation determines how attributes whose keys are not valid
+// are converted.
+type AttributeKeyValidation = internal.AttributeKeyValidation
+
+const (
+	// AttributeKeyWarn converts attributes with invalid keys unchanged, and
+	// returns an error.
+	AttributeKeyWarn = internal.AttributeKeyWarn
+	// AttributeKeyDrop drops attributes with invalid keys, and returns an
+	// error.
+	AttributeKeyDrop = internal.AttributeKeyDrop
+	// AttributeKeySanitize rewrites invalid keys to valid ones, and returns
+	// an error.
+	AttributeKeySanitize = internal.AttributeKeySanitize
+)
+
+// WithValidateAttributeKeys converts the attributes of data points whose
+// keys are not valid according to validation. A valid key is not empty and
+// only contains alphanumeric characters, '_', '.', and '-'.
+//
+// By default, attributes with invalid keys are converted unchanged, and an
+// error is returned.
+func WithValidateAttributeKeys(validation AttributeKeyValidation) MetricOption {
+	return converterOption(internal.WithValidateAttributeKeys(validation))
+}
+
+// WithDeduplicateDescriptors merges OpenCensus metrics with identical
+// descriptors and resources into a single metric with the time series of
+// all of them.
+//
+// By default, each OpenCensus metric is converted to a separate metric.
+func WithDeduplicateDescriptors() MetricOption {
+	return converterOption(internal.WithDeduplicateDescriptors())
+}
+
+// WithSourceLibrary includes each metric converted by ConvertMetricsBatched
+// in the scope named by library for the OpenCensus name of the metric. It
+// does not apply to other conversions, which return the metrics of the
+// bridge scope.
+//
+// By default, all metrics are included in the scope of the bridge.
+func WithSourceLibrary(library func(metricName string) string) MetricOption {
+	return scopeOption(internal.WithSourceLibrary(library))
+}
+
+// EmptyBucketHistogramHandling determines how OpenCensus distributions with a
+// positive count but only empty buckets are converted.
+type EmptyBucketHistogramHandling = internal.EmptyBucketHistogramHandling
+
+const (
+	// EmptyBucketHistogramError drops the metric and returns an error.
+	EmptyBucketHistogramError = internal.EmptyBucketHistogramError
+	// EmptyBucketHistogramDrop drops the data point, and converts the rest
+	// of the metric.
+	EmptyBucketHistogramDrop = internal.EmptyBucketHistogramDrop
+	// EmptyBucketHistogramPlaceInOverflow converts the data point with its
+	// count in the overflow bucket.
+	EmptyBucketHistogramPlaceInOverflow = internal.EmptyBucketHistogramPlaceInOverflow
+)
+
+// WithEmptyBucketHistogramHandling converts OpenCensus distributions with a
+// positive count but only empty buckets according to handling.
+//
+// By default, metrics with such distributions are dropped and an error is
+// returned.
+func WithEmptyBucketHistogramHandling(handling EmptyBucketHistogramHandling) MetricOption {
+	return converterOption(internal.WithEmptyBucketHistogramHandling(handling))
+}
@@ -15,11 +15,24 @@
 package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
 
 import (
+	"context"
+	"math"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.opentelemetry.io/otel/trace/noop"
 )
 
@@ -54,3 +67,1571 @@ func TestNewTraceConfig(t *testing.T) {
 		})
 	}
 }
+
+// ocMetric returns an OpenCensus metric of type typ with label keys keys.
+func ocMetric(name string, typ ocmetricdata.Type, keys []string, series ...*ocmetricdata.TimeSeries) *ocmetricdata.Metric {
+	labelKeys := make([]ocmetricdata.LabelKey, len(keys))
+	for i, k := range keys {
+		labelKeys[i] = ocmetricdata.LabelKey{Key: k}
+	}
+	return &ocmetricdata.Metric{
+		Descriptor: ocmetricdata.Descriptor{
+			Name:      name,
+			Type:      typ,
+			LabelKeys: labelKeys,
+		},
+		TimeSeries: series,
+	}
+}
+
+// ocSeries returns an OpenCensus time series with label values values.
+func ocSeries(start time.Time, values []string, points ...ocmetricdata.Point) *ocmetricdata.TimeSeries {
+	labelValues := make([]ocmetricdata.LabelValue, len(values))
+	for i, v := range values {
+		labelValues[i] = ocmetricdata.NewLabelValue(v)
+	}
+	return &ocmetricdata.TimeSeries{
+		LabelValues: labelValues,
+		Points:      points,
+		StartTime:   start,
+	}
+}
+
+// ocDistribution returns an OpenCensus distribution with bounds and bucket
+// counts counts.
+func ocDistribution(sum float64, bounds []float64, counts ...int64) *ocmetricdata.Distribution {
+	d := &ocmetricdata.Distribution{
+		Sum:           sum,
+		BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+		Buckets:       make([]ocmetricdata.Bucket, len(counts)),
+	}
+	for i, n := range counts {
+		d.Count += n
+		d.Buckets[i].Count = n
+	}
+	return d
+}
+
+func TestMetricOptions(t *testing.T) {
+	start := time.Unix(1000, 0)
+	now := start.Add(time.Minute)
+	for _, tc := range []struct {
+		desc     string
+		opts     []MetricOption
+		input    []*ocmetricdata.Metric
+		expected []metricdata.Metrics
+		wantErr  bool
+	}{
+		{
+			desc: "no options",
+			input: []*ocmetricdata.Metric{
+				ocMetric("", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "WithDefaultMetricName",
+			opts: []MetricOption{WithDefaultMetricName("unnamed-")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "unnamed-0",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithLatestGaugePointOnly",
+			opts: []MetricOption{WithLatestGaugePointOnly()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(now, 2),
+					ocmetricdata.NewInt64Point(start, 1),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 2},
+				}},
+			}},
+		},
+		{
+			desc: "WithMaxAttributeCount",
+			opts: []MetricOption{WithMaxAttributeCount(1)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"a", "b"},
+					ocSeries(start, []string{"1", "x"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(start, []string{"1", "y"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 3},
+					},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithAttributeKeyMapper",
+			opts: []MetricOption{WithAttributeKeyMapper(func(k attribute.Key) attribute.Key {
+				return "oc." + k
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("oc.a", "1")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithMaxHistogramBuckets",
+			opts: []MetricOption{WithMaxHistogramBuckets(2)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(10, []float64{1, 2, 3}, 1, 2, 3, 4))),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        10,
+						Sum:          10,
+						Bounds:       []float64{2},
+						BucketCounts: []uint64{3, 7},
+					}},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithSortPointsByTime",
+			opts: []MetricOption{WithSortPointsByTime()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(now, 2),
+					ocmetricdata.NewInt64Point(start, 1),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: start, Value: 1},
+					{StartTime: start, Time: now, Value: 2},
+				}},
+			}},
+		},
+		{
+			desc: "WithAttributeAllowList",
+			opts: []MetricOption{WithAttributeAllowList("b")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"a", "b"},
+					ocSeries(start, []string{"1", "x"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(start, []string{"2", "x"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("b", "x")), StartTime: start, Time: now, Value: 3},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithRejectNegativeBounds",
+			opts: []MetricOption{WithRejectNegativeBounds()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(1, []float64{-1, 1}, 0, 1, 0))),
+				),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "WithMaxDescriptionLength",
+			opts: []MetricOption{WithMaxDescriptionLength(12)},
+			input: []*ocmetricdata.Metric{{
+				Descriptor: ocmetricdata.Descriptor{
+					Name:        "foo.com/gauge-a",
+					Description: "a testing gauge",
+					Type:        ocmetricdata.TypeGaugeInt64,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))},
+			}},
+			expected: []metricdata.Metrics{{
+				Name:        "foo.com/gauge-a",
+				Description: "a testing...",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithDetectResets",
+			opts: []MetricOption{WithDetectResets()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil,
+					ocSeries(start, nil, ocmetricdata.NewInt64Point(start.Add(time.Second), 5)),
+					ocSeries(start.Add(2*time.Second), nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: start.Add(time.Second), Value: 5},
+						{StartTime: start.Add(2 * time.Second), Time: now, Value: 1},
+					},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithAggregationOverride",
+			opts: []MetricOption{WithAggregationOverride(func(name string, ocType ocmetricdata.Type) ocmetricdata.Type {
+				if name == "foo.com/gauge-a" {
+					return ocmetricdata.TypeCumulativeInt64
+				}
+				return ocType
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: now, Value: 1},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithGaugeNaNPolicy",
+			opts: []MetricOption{WithGaugeNaNPolicy(NaNZero)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeFloat64, nil, ocSeries(start, nil, ocmetricdata.NewFloat64Point(now, math.NaN()))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{
+					{StartTime: start, Time: now, Value: 0},
+				}},
+			}},
+		},
+		{
+			desc: "WithMetricNamePrefix",
+			opts: []MetricOption{WithMetricNamePrefix("foo.com/")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithLabelDescriptionsAsExemplarAttrs",
+			opts: []MetricOption{WithLabelDescriptionsAsExemplarAttrs()},
+			input: []*ocmetricdata.Metric{{
+				Descriptor: ocmetricdata.Descriptor{
+					Name:      "foo.com/histogram-a",
+					Type:      ocmetricdata.TypeCumulativeDistribution,
+					LabelKeys: []ocmetricdata.LabelKey{{Key: "a", Description: "the a label"}},
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{ocSeries(start, []string{"1"}, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+					Count:         1,
+					Sum:           0.5,
+					BucketOptions: &ocmetricdata.BucketOptions{},
+					Buckets:       []ocmetricdata.Bucket{{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}}},
+				}))},
+			}},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attribute.NewSet(attribute.String("a", "1")),
+						StartTime:    start,
+						Time:         now,
+						Count:        1,
+						Sum:          0.5,
+						BucketCounts: []uint64{1},
+						Exemplars: []metricdata.Exemplar[float64]{{
+							FilteredAttributes: []attribute.KeyValue{attribute.String("label.a.description", "the a label")},
+							Time:               now,
+							Value:              0.5,
+						}},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithValidateUTF8",
+			opts: []MetricOption{WithValidateUTF8()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"x\xffy"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("a", "x�y")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithDetectNonMonotonic",
+			opts: []MetricOption{WithDetectNonMonotonic()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(start.Add(time.Second), 5),
+					ocmetricdata.NewInt64Point(now, 3),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: start.Add(time.Second), Value: 5},
+						{StartTime: start, Time: now, Value: 3},
+					},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithMonotonicTimestamps",
+			opts: []MetricOption{WithMonotonicTimestamps()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(now, 1),
+					ocmetricdata.NewInt64Point(now, 2),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+					{StartTime: start, Time: now.Add(time.Nanosecond), Value: 2},
+				}},
+			}},
+		},
+		{
+			desc: "WithInt64Labels",
+			opts: []MetricOption{WithInt64Labels("code")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"code", "path"},
+					ocSeries(start, []string{"200", "/"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.Int64("code", 200), attribute.String("path", "/")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithHistogramExtremaFromExemplars",
+			opts: []MetricOption{WithHistogramExtremaFromExemplars()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         2,
+						Sum:           2,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets: []ocmetricdata.Bucket{
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 1.5, Timestamp: now}},
+						},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          2,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{1, 1},
+						Min:          metricdata.NewExtrema(0.5),
+						Max:          metricdata.NewExtrema(1.5),
+						Exemplars: []metricdata.Exemplar[float64]{
+							{Time: now, Value: 0.5},
+							{Time: now, Value: 1.5},
+						},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithView",
+			opts: []MetricOption{WithView(func(name string, agg metricdata.Aggregation) metricdata.Aggregation {
+				if sum, ok := agg.(metricdata.Sum[int64]); ok && name == "foo.com/sum-a" {
+					return metricdata.Gauge[int64]{DataPoints: sum.DataPoints}
+				}
+				return agg
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithStringInterner",
+			opts: []MetricOption{WithStringInterner()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+				ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/gauge-a",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 1},
+					}},
+				},
+				{
+					Name: "foo.com/gauge-b",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 2},
+					}},
+				},
+			},
+		},
+		{
+			desc: "WithSortByStartTime",
+			opts: []MetricOption{WithSortByStartTime()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"a"},
+					ocSeries(start.Add(time.Second), []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(start, []string{"2"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "2")), StartTime: start, Time: now, Value: 2},
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start.Add(time.Second), Time: now, Value: 1},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithResetHandling",
+			opts: []MetricOption{WithResetHandling(ResetZero)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil,
+					ocSeries(start, nil, ocmetricdata.NewInt64Point(start.Add(time.Second), 5)),
+					ocSeries(start.Add(2*time.Second), nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: start.Add(time.Second), Value: 5},
+						{StartTime: start.Add(2 * time.Second), Time: start.Add(2 * time.Second), Value: 0},
+						{StartTime: start.Add(2 * time.Second), Time: now, Value: 1},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithBooleanLabels",
+			opts: []MetricOption{WithBooleanLabels("cached")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"cached"},
+					ocSeries(start, []string{"TRUE"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.Bool("cached", true)), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithMetricScopedKeyMapper",
+			opts: []MetricOption{WithMetricScopedKeyMapper(func(metricName string, k attribute.Key) attribute.Key {
+				if metricName == "foo.com/gauge-a" {
+					return "gauge." + k
+				}
+				return k
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+				ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/gauge-a",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("gauge.a", "1")), StartTime: start, Time: now, Value: 1},
+					}},
+				},
+				{
+					Name: "foo.com/gauge-b",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 2},
+					}},
+				},
+			},
+		},
+		{
+			desc: "WithValidateBucketSum",
+			opts: []MetricOption{WithValidateBucketSum(true)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         3,
+						Sum:           2,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets:       []ocmetricdata.Bucket{{Count: 1}, {Count: 1}},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{Temporality: metricdata.CumulativeTemporality},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithTimestampSanity",
+			opts: []MetricOption{WithTimestampSanity(start, now)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(now, 1),
+					ocmetricdata.NewInt64Point(now.Add(time.Hour), 2),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithExemplarResolver",
+			opts: []MetricOption{WithExemplarResolver(func(metricName string, attrs attribute.Set) []metricdata.Exemplar[float64] {
+				return []metricdata.Exemplar[float64]{{Time: now, Value: 0.5}}
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(0.5, nil, 1))),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        1,
+						Sum:          0.5,
+						BucketCounts: []uint64{1},
+						Exemplars:    []metricdata.Exemplar[float64]{{Time: now, Value: 0.5}},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithEmptyAttributeSetKey",
+			opts: []MetricOption{WithEmptyAttributeSetKey("series", "default")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("series", "default")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithUnitMapper",
+			opts: []MetricOption{WithUnitMapper(func(metricName, unit string) string {
+				if unit == "1" {
+					return "{request}"
+				}
+				return unit
+			})},
+			input: []*ocmetricdata.Metric{{
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/sum-a",
+					Unit: ocmetricdata.UnitDimensionless,
+					Type: ocmetricdata.TypeCumulativeInt64,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))},
+			}},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Unit: "{request}",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: now, Value: 1},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithBoundsRounding",
+			opts: []MetricOption{WithBoundsRounding(2)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(3, []float64{0.9999999999, 2.5}, 1, 1, 0))),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          3,
+						Bounds:       []float64{1, 2.5},
+						BucketCounts: []uint64{1, 1, 0},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithNumericLabelTypes",
+			opts: []MetricOption{WithNumericLabelTypes(map[string]attribute.Type{"ratio": attribute.FLOAT64})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"ratio"},
+					ocSeries(start, []string{"0.5"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.Float64("ratio", 0.5)), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithReaderTemporality",
+			opts: []MetricOption{WithReaderTemporality(func(metric.InstrumentKind) metricdata.Temporality {
+				return metricdata.DeltaTemporality
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: now, Value: 1},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithTolerateExtraValues",
+			opts: []MetricOption{WithTolerateExtraValues("extra.")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1", "x"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("a", "1"), attribute.String("extra.1", "x")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithMaxExemplarsPerPoint",
+			opts: []MetricOption{WithMaxExemplarsPerPoint(1)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         2,
+						Sum:           2,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets: []ocmetricdata.Bucket{
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 1.5, Timestamp: now}},
+						},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          2,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{1, 1},
+						Exemplars:    []metricdata.Exemplar[float64]{{Time: now, Value: 1.5}},
+					}},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithPreserveSumOfSquaredDeviation",
+			opts: []MetricOption{WithPreserveSumOfSquaredDeviation("ssd")},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:                 2,
+						Sum:                   2,
+						SumOfSquaredDeviation: 0.5,
+						BucketOptions:         &ocmetricdata.BucketOptions{},
+						Buckets:               []ocmetricdata.Bucket{{Count: 2}},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attribute.NewSet(attribute.Float64("ssd", 0.5)),
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          2,
+						BucketCounts: []uint64{2},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithDescriptionFallback",
+			opts: []MetricOption{WithDescriptionFallback(func(name string) string {
+				return "the " + name + " metric"
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(now, 1))),
+			},
+			expected: []metricdata.Metrics{{
+				Name:        "foo.com/gauge-a",
+				Description: "the foo.com/gauge-a metric",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithPointFilter",
+			opts: []MetricOption{WithPointFilter(func(metricName string, attrs attribute.Set, t time.Time) bool {
+				v, _ := attrs.Value("a")
+				return v.AsString() != "drop"
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"keep"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(start, []string{"drop"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("a", "keep")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithAttributeValueMapper",
+			opts: []MetricOption{WithAttributeValueMapper(func(key attribute.Key, v attribute.Value) attribute.Value {
+				if key == "path" {
+					return attribute.StringValue("/redacted")
+				}
+				return v
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a", "path"},
+					ocSeries(start, []string{"1", "/users/42"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attribute.NewSet(attribute.String("a", "1"), attribute.String("path", "/redacted")), StartTime: start, Time: now, Value: 1},
+				}},
+			}},
+		},
+		{
+			desc: "WithCounterResetSplit",
+			opts: []MetricOption{WithCounterResetSplit()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(start, nil,
+					ocmetricdata.NewInt64Point(start.Add(time.Second), 5),
+					ocmetricdata.NewInt64Point(now, 2),
+				)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: start.Add(time.Second), Value: 5},
+						{StartTime: now, Time: now, Value: 2},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithHistogramSumRounding",
+			opts: []MetricOption{WithHistogramSumRounding(1)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(0.30000000000000004, nil, 2))),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          0.3,
+						BucketCounts: []uint64{2},
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithSkipEmptyMetrics",
+			opts: []MetricOption{WithSkipEmptyMetrics()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil),
+				ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-b",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithInstrumentNameValidation sanitize",
+			opts: []MetricOption{WithInstrumentNameValidation(InstrumentNameSanitize)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("1 gauge", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "oc_1_gauge",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithInstrumentNameValidation strict",
+			opts: []MetricOption{WithInstrumentNameValidation(InstrumentNameStrict)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("1 gauge", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "WithKeyValueBufferPool",
+			opts: []MetricOption{WithKeyValueBufferPool(&sync.Pool{
+				New: func() any {
+					buf := make([]attribute.KeyValue, 0, 4)
+					return &buf
+				},
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(time.Time{}, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(time.Time{}, []string{"2"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), Time: now, Value: 1},
+						{Attributes: attribute.NewSet(attribute.String("a", "2")), Time: now, Value: 2},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithIncompatibleTypeHandling",
+			opts: []MetricOption{WithIncompatibleTypeHandling(IncompatibleTypeRename)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/a", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+				ocMetric("foo.com/a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, ocDistribution(2, nil, 1))),
+				),
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/a",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{
+							Time:  now,
+							Value: 1,
+						}},
+					},
+				},
+				{
+					Name: "foo.com/a_histogram",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							StartTime:    start,
+							Time:         now,
+							Count:        1,
+							Sum:          2,
+							BucketCounts: []uint64{1},
+						}},
+					},
+				},
+			},
+		},
+		{
+			desc: "WithUnitOverride",
+			opts: []MetricOption{WithUnitOverride(map[string]string{"foo.com/gauge-a": "By"})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Unit: "By",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithFloatGaugeToInt",
+			opts: []MetricOption{WithFloatGaugeToInt(FloatToIntFloor)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeFloat64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewFloat64Point(now, 1.5)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+			// Rounding values is reported as a warning.
+			wantErr: true,
+		},
+		{
+			desc: "WithTimestampTruncation",
+			opts: []MetricOption{WithTimestampTruncation(time.Second)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil,
+					ocSeries(start.Add(100*time.Millisecond), nil,
+						ocmetricdata.NewInt64Point(now.Add(100*time.Millisecond), 1),
+						ocmetricdata.NewInt64Point(now.Add(900*time.Millisecond), 2),
+					),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{{
+						StartTime: start,
+						Time:      now,
+						Value:     2,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithReportNilMetrics",
+			opts: []MetricOption{WithReportNilMetrics()},
+			input: []*ocmetricdata.Metric{
+				nil,
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithUnsupportedAggregationHandler",
+			opts: []MetricOption{WithUnsupportedAggregationHandler(func(m *ocmetricdata.Metric) (metricdata.Aggregation, error) {
+				return metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Time: now, Value: int64(len(m.TimeSeries))}},
+				}, nil
+			})},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil, ocSeries(start, nil)),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/summary-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now,
+						Value: 1,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithMonotonicCorrection",
+			opts: []MetricOption{WithMonotonicCorrection()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil,
+					ocSeries(start, nil,
+						ocmetricdata.NewInt64Point(start.Add(time.Second), 5),
+						ocmetricdata.NewInt64Point(now, 3),
+					),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{StartTime: start, Time: start.Add(time.Second), Value: 5},
+						{StartTime: start, Time: now, Value: 5},
+					},
+				},
+			}},
+			// Raised values are reported as a warning.
+			wantErr: true,
+		},
+		{
+			desc: "WithPointTimeFallback",
+			opts: []MetricOption{
+				WithPointTimeFallback(),
+				WithClock(func() time.Time { return now }),
+			},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(time.Time{}, 1)),
+					ocSeries(time.Time{}, []string{"2"}, ocmetricdata.NewInt64Point(time.Time{}, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: start, Value: 1},
+						{Attributes: attribute.NewSet(attribute.String("a", "2")), Time: now, Value: 2},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithValueTypeMismatchHandling",
+			opts: []MetricOption{WithValueTypeMismatchHandling(ValueTypeMismatchCoerce)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeFloat64, nil,
+					ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{
+						Time:  now,
+						Value: 2,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithValidateExemplarBounds",
+			opts: []MetricOption{WithValidateExemplarBounds()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         2,
+						Sum:           2,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets: []ocmetricdata.Bucket{
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.7, Timestamp: now}},
+						},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          2,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{1, 1},
+						Exemplars:    []metricdata.Exemplar[float64]{{Time: now, Value: 0.5}},
+					}},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "WithMaxSeriesAge",
+			opts: []MetricOption{
+				WithMaxSeriesAge(time.Hour),
+				WithClock(func() time.Time { return now }),
+			},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil,
+					ocSeries(time.Time{}, nil,
+						ocmetricdata.NewInt64Point(now.Add(-2*time.Hour), 1),
+						ocmetricdata.NewInt64Point(now.Add(-time.Minute), 2),
+					),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Time:  now.Add(-time.Minute),
+						Value: 2,
+					}},
+				},
+			}},
+		},
+		{
+			desc: "WithValidateAttributeKeys",
+			opts: []MetricOption{WithValidateAttributeKeys(AttributeKeySanitize)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"a b", ""},
+					ocSeries(start, []string{"1", "x"}, ocmetricdata.NewInt64Point(now, 1)),
+					ocSeries(start, []string{"1", "y"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/sum-a",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a_b", "1")), StartTime: start, Time: now, Value: 3},
+					},
+				},
+			}},
+			// Invalid keys are reported as warnings.
+			wantErr: true,
+		},
+		{
+			desc: "WithDeduplicateDescriptors",
+			opts: []MetricOption{WithDeduplicateDescriptors()},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(time.Time{}, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+				),
+				ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+					ocSeries(time.Time{}, []string{"2"}, ocmetricdata.NewInt64Point(now, 2)),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "1")), Time: now, Value: 1},
+						{Attributes: attribute.NewSet(attribute.String("a", "2")), Time: now, Value: 2},
+					},
+				},
+			}},
+		},
+		{
+			desc: "WithEmptyBucketHistogramHandling",
+			opts: []MetricOption{WithEmptyBucketHistogramHandling(EmptyBucketHistogramPlaceInOverflow)},
+			input: []*ocmetricdata.Metric{
+				ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil,
+					ocSeries(start, nil, ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         2,
+						Sum:           4,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets:       []ocmetricdata.Bucket{{}, {}},
+					})),
+				),
+			},
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						StartTime:    start,
+						Time:         now,
+						Count:        2,
+						Sum:          4,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{0, 2},
+					}},
+				},
+			}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return tc.input }, tc.opts...)
+			output, err := producer.Produce(context.Background())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			var metrics []metricdata.Metrics
+			if len(output) > 0 {
+				require.Len(t, output, 1)
+				metrics = output[0].Metrics
+			}
+			metricdatatest.AssertEqual(t,
+				metricdata.ScopeMetrics{Metrics: tc.expected},
+				metricdata.ScopeMetrics{Metrics: metrics})
+		})
+	}
+}
+
+func TestWithMaxErrors(t *testing.T) {
+	now := time.Now()
+	unnamed := ocMetric("", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1)))
+	input := []*ocmetricdata.Metric{unnamed, unnamed, unnamed}
+
+	_, err := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithMaxErrors(1)).Produce(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "+2 more errors")
+}
+
+func TestWithErrorPrefix(t *testing.T) {
+	input := []*ocmetricdata.Metric{ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil)}
+
+	_, err := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithErrorPrefix("my producer")).Produce(context.Background())
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "my producer: "), err.Error())
+}
+
+func TestWithSuccessHandler(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil),
+		ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 2))),
+	}
+	var converted []string
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input },
+		WithSuccessHandler(func(m metricdata.Metrics) {
+			converted = append(converted, m.Name)
+		}),
+	)
+
+	_, err := producer.Produce(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"foo.com/gauge-a", "foo.com/gauge-b"}, converted, "dropped metrics are not handled")
+}
+
+func TestWithLogger(t *testing.T) {
+	input := []*ocmetricdata.Metric{ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil)}
+	var logged []string
+	logger := funcr.New(func(prefix, args string) {
+		logged = append(logged, args)
+	}, funcr.Options{Verbosity: 4})
+
+	_, err := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithLogger(logger)).Produce(context.Background())
+	require.Error(t, err)
+	require.Len(t, logged, 1)
+	assert.Contains(t, logged[0], `"msg"="OpenCensus metric dropped" "metric"="foo.com/summary-a"`)
+}
+
+func TestWithTemporalityByName(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var input []*ocmetricdata.Metric
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input },
+		WithTemporalityByName(func(string, ocmetricdata.Type) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}))
+	sum := func(points ...metricdata.DataPoint[int64]) []metricdata.ScopeMetrics {
+		return []metricdata.ScopeMetrics{{Metrics: []metricdata.Metrics{{
+			Name: "foo.com/sum-a",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.DeltaTemporality,
+				IsMonotonic: true,
+				DataPoints:  points,
+			},
+		}}}}
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		time     time.Time
+		value    int64
+		expected []metricdata.ScopeMetrics
+	}{
+		{
+			desc:     "first collection",
+			time:     start.Add(time.Second),
+			value:    5,
+			expected: sum(metricdata.DataPoint[int64]{StartTime: start, Time: start.Add(time.Second), Value: 5}),
+		},
+		{
+			desc:     "second collection",
+			time:     start.Add(2 * time.Second),
+			value:    8,
+			expected: sum(metricdata.DataPoint[int64]{StartTime: start.Add(time.Second), Time: start.Add(2 * time.Second), Value: 3}),
+		},
+		{
+			desc:     "repeated collection",
+			time:     start.Add(2 * time.Second),
+			value:    8,
+			expected: sum(),
+		},
+	} {
+		input = []*ocmetricdata.Metric{
+			ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(start, nil, ocmetricdata.NewInt64Point(tc.time, tc.value))),
+		}
+		output, err := producer.Produce(context.Background())
+		require.NoError(t, err, tc.desc)
+		require.Len(t, output, 1, tc.desc)
+		output[0].Scope = instrumentation.Scope{}
+		metricdatatest.AssertEqual(t, tc.expected[0], output[0])
+	}
+}
+
+func TestWithStableOrdering(t *testing.T) {
+	now := time.Now()
+	gaugeA := ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1)))
+	gaugeB := ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1)))
+	var input []*ocmetricdata.Metric
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithStableOrdering())
+
+	for _, tc := range []struct {
+		desc     string
+		input    []*ocmetricdata.Metric
+		expected []string
+	}{
+		{
+			desc:     "first collection",
+			input:    []*ocmetricdata.Metric{gaugeB, gaugeA},
+			expected: []string{"foo.com/gauge-b", "foo.com/gauge-a"},
+		},
+		{
+			desc:     "reordered collection",
+			input:    []*ocmetricdata.Metric{gaugeA, gaugeB},
+			expected: []string{"foo.com/gauge-a", "foo.com/gauge-b"},
+		},
+		{
+			desc:     "sorted after reordering",
+			input:    []*ocmetricdata.Metric{gaugeB, gaugeA},
+			expected: []string{"foo.com/gauge-a", "foo.com/gauge-b"},
+		},
+	} {
+		input = tc.input
+		output, err := producer.Produce(context.Background())
+		require.NoError(t, err, tc.desc)
+		require.Len(t, output, 1, tc.desc)
+		var names []string
+		for _, m := range output[0].Metrics {
+			names = append(names, m.Name)
+		}
+		assert.Equal(t, tc.expected, names, tc.desc)
+	}
+}
+
+func TestWithAttributeCache(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+			ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+			ocSeries(now, []string{"2"}, ocmetricdata.NewInt64Point(now, 2)),
+		),
+	}
+	for _, opt := range []MetricOption{WithAttributeCache(), WithAttributeCacheSize(1)} {
+		producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, opt)
+		for i := 0; i < 2; i++ {
+			output, err := producer.Produce(context.Background())
+			require.NoError(t, err)
+			require.Len(t, output, 1)
+			metricdatatest.AssertEqual(t, metricdata.ScopeMetrics{
+				Scope: instrumentation.Scope{Name: scopeName, Version: Version()},
+				Metrics: []metricdata.Metrics{{
+					Name: "foo.com/gauge-a",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: now, Time: now, Value: 1},
+							{Attributes: attribute.NewSet(attribute.String("a", "2")), StartTime: now, Time: now, Value: 2},
+						},
+					},
+				}},
+			}, output[0])
+		}
+	}
+}
+
+func TestWithTimingHistogram(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := now
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+		ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+	}
+	durations := make(map[string]time.Duration)
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input },
+		WithClock(func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		}),
+		WithTimingHistogram(func(metricName string, d time.Duration) {
+			durations[metricName] = d
+		}),
+	)
+
+	_, err := producer.Produce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"foo.com/gauge-a": time.Second,
+		"foo.com/gauge-b": time.Second,
+	}, durations)
+}
+
+// semConvRegistry is a SemConvRegistry of the metric names and attribute
+// keys it holds.
+type semConvRegistry map[string]bool
+
+func (r semConvRegistry) ValidMetric(name string) bool { return r[name] }
+
+func (r semConvRegistry) ValidAttribute(key string) bool { return r[key] }
+
+func TestWithSemConvValidation(t *testing.T) {
+	now := time.Now()
+	registry := semConvRegistry{"foo.com/gauge-a": true, "a": true}
+	for _, tc := range []struct {
+		desc    string
+		input   *ocmetricdata.Metric
+		wantErr bool
+	}{
+		{
+			desc:  "valid",
+			input: ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"}, ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1))),
+		},
+		{
+			desc:    "invalid name",
+			input:   ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, []string{"a"}, ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1))),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid attribute key",
+			input:   ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"b"}, ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1))),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return []*ocmetricdata.Metric{tc.input} }, WithSemConvValidation(registry))
+			output, err := producer.Produce(context.Background())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, output, 1, "metrics are converted regardless")
+			assert.Len(t, output[0].Metrics, 1)
+		})
+	}
+}
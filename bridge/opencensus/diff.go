@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Change is the kind of difference between two converted metrics.
+type Change = internal.Change
+
+const (
+	// MetricAdded is a metric only present in the second batch.
+	MetricAdded = internal.MetricAdded
+	// MetricRemoved is a metric only present in the first batch.
+	MetricRemoved = internal.MetricRemoved
+	// AggregationChanged is a metric with a different aggregation type.
+	AggregationChanged = internal.AggregationChanged
+	// UnitChanged is a metric with a different unit.
+	UnitChanged = internal.UnitChanged
+	// DescriptionChanged is a metric with a different description.
+	DescriptionChanged = internal.DescriptionChanged
+	// AttributesChanged is a metric whose data points have different
+	// attribute sets.
+	AttributesChanged = internal.AttributesChanged
+)
+
+// MetricDiff is a structural difference between two converted metrics with
+// the same name.
+type MetricDiff = internal.MetricDiff
+
+// DiffMetrics returns the structural differences between the converted
+// metrics a and b, e.g. to validate that upgrading an OpenCensus producer did
+// not change the shape of its metrics. Metrics are matched by name. Added and
+// removed metrics, and changed aggregation types, units, descriptions, and
+// data point attribute sets are reported. Data point values are not compared.
+//
+// Differences are in the order of a, followed by the metrics only in b in
+// their order. If a batch has metrics with the same name, only the first is
+// compared.
+func DiffMetrics(a, b []metricdata.Metrics) []MetricDiff {
+	return internal.DiffMetrics(a, b)
+}
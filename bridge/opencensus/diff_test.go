@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDiffMetrics(t *testing.T) {
+	now := time.Now()
+	convert := func(ocmetrics ...*ocmetricdata.Metric) []metricdata.Metrics {
+		batch, err := ConvertWithResource(ocmetrics)
+		require.NoError(t, err)
+		return batch.Metrics
+	}
+	before := convert(
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+			ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1)),
+		),
+		ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+	)
+	after := convert(
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeFloat64, []string{"a"},
+			ocSeries(now, []string{"2"}, ocmetricdata.NewFloat64Point(now, 1)),
+		),
+		ocMetric("foo.com/sum-b", ocmetricdata.TypeCumulativeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+	)
+
+	var changes []Change
+	for _, d := range DiffMetrics(before, after) {
+		changes = append(changes, d.Change)
+	}
+	assert.Equal(t, []Change{AggregationChanged, AttributesChanged, MetricRemoved, MetricAdded}, changes)
+	assert.Empty(t, DiffMetrics(before, before))
+}
@@ -59,5 +59,7 @@
 //   - Summary-typed metrics are dropped
 //   - GaugeDistribution-typed metrics are dropped
 //   - Histogram's SumOfSquaredDeviation field is dropped
-//   - Exemplars on Histograms are dropped
+//   - The "SampleRate" attachment of Histogram exemplars is converted to the
+//     "exemplar.sample_rate" filtered attribute, as OpenTelemetry exemplars
+//     do not have a weight
 package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
@@ -15,6 +15,7 @@
 package opencensus_test
 
 import (
+	ocmetric "go.opencensus.io/metric"
 	octrace "go.opencensus.io/trace"
 
 	"go.opentelemetry.io/otel"
@@ -42,3 +43,16 @@ func ExampleNewMetricProducer() {
 	// Add the reader to your MeterProvider.
 	_ = metric.NewMeterProvider(metric.WithReader(reader))
 }
+
+func ExampleNewOpenCensusProducer() {
+	// Create an OpenCensus registry holding the metrics to bridge.
+	registry := ocmetric.NewRegistry()
+	// Create a producer converting the metrics read from the registry.
+	bridge := opencensus.NewOpenCensusProducer(registry.Read)
+	// Register the producer with your reader. The metrics are converted on
+	// each collection cycle of the reader, e.g. when a pull exporter, such as
+	// the prometheus exporter, is scraped.
+	reader := metric.NewManualReader(metric.WithProducer(bridge))
+	// Add the reader to your MeterProvider.
+	_ = metric.NewMeterProvider(metric.WithReader(reader))
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"context"
+	"errors"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// RegisterGaugeCallbacks registers an observable gauge with meter for each
+// OpenCensus gauge returned by fetch, and a callback that observes the
+// latest point of each time series of these gauges. The callback calls fetch
+// and converts its gauges as configured by opts on each collection. Int64
+// gauges are registered as Int64ObservableGauge, and float64 gauges as
+// Float64ObservableGauge, with the converted name, description, and unit of
+// the OpenCensus gauge.
+//
+// Only the gauges returned by fetch when RegisterGaugeCallbacks is called are
+// registered, and gauges whose instrument cannot be created are not
+// observed. Other metrics returned by fetch are ignored, as are gauges
+// converted to other aggregations, e.g. with WithAggregationOverride.
+func RegisterGaugeCallbacks(meter metric.Meter, fetch func() []*ocmetricdata.Metric, opts ...MetricOption) error {
+	convOpts := append(newMetricConfig(opts).converterOptions, internal.WithLatestGaugePointOnly())
+	convert := func() ([]metricdata.Metrics, error) {
+		return internal.ConvertMetrics(gauges(fetch()), convOpts...)
+	}
+
+	int64Gauges := make(map[string]metric.Int64ObservableGauge)
+	float64Gauges := make(map[string]metric.Float64ObservableGauge)
+	var instruments []metric.Observable
+	otelmetrics, errs := convert()
+	for _, m := range otelmetrics {
+		switch m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			if _, ok := int64Gauges[m.Name]; ok {
+				continue
+			}
+			g, err := meter.Int64ObservableGauge(m.Name, metric.WithDescription(m.Description), metric.WithUnit(m.Unit))
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			int64Gauges[m.Name] = g
+			instruments = append(instruments, g)
+		case metricdata.Gauge[float64]:
+			if _, ok := float64Gauges[m.Name]; ok {
+				continue
+			}
+			g, err := meter.Float64ObservableGauge(m.Name, metric.WithDescription(m.Description), metric.WithUnit(m.Unit))
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			float64Gauges[m.Name] = g
+			instruments = append(instruments, g)
+		}
+	}
+	if len(instruments) == 0 {
+		return errs
+	}
+
+	_, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		otelmetrics, err := convert()
+		for _, m := range otelmetrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				g, ok := int64Gauges[m.Name]
+				if !ok {
+					continue
+				}
+				for _, p := range data.DataPoints {
+					o.ObserveInt64(g, p.Value, metric.WithAttributeSet(p.Attributes))
+				}
+			case metricdata.Gauge[float64]:
+				g, ok := float64Gauges[m.Name]
+				if !ok {
+					continue
+				}
+				for _, p := range data.DataPoints {
+					o.ObserveFloat64(g, p.Value, metric.WithAttributeSet(p.Attributes))
+				}
+			}
+		}
+		return err
+	}, instruments...)
+	return errors.Join(errs, err)
+}
+
+// gauges returns the OpenCensus gauges of ocmetrics that can be observed
+// with observable gauges.
+func gauges(ocmetrics []*ocmetricdata.Metric) []*ocmetricdata.Metric {
+	var gauges []*ocmetricdata.Metric
+	for _, ocm := range ocmetrics {
+		if ocm == nil {
+			continue
+		}
+		switch ocm.Descriptor.Type {
+		case ocmetricdata.TypeGaugeInt64, ocmetricdata.TypeGaugeFloat64:
+			gauges = append(gauges, ocm)
+		}
+	}
+	return gauges
+}
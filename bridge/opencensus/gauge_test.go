@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestRegisterGaugeCallbacks(t *testing.T) {
+	now := time.Now()
+	var value int64
+	fetch := func() []*ocmetricdata.Metric {
+		value++
+		return []*ocmetricdata.Metric{
+			{
+				Descriptor: ocmetricdata.Descriptor{
+					Name:        "foo.com/gauge-a",
+					Description: "an int testing gauge",
+					Unit:        ocmetricdata.UnitBytes,
+					Type:        ocmetricdata.TypeGaugeInt64,
+					LabelKeys:   []ocmetricdata.LabelKey{{Key: "a"}},
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{
+					{
+						LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+						Points: []ocmetricdata.Point{
+							ocmetricdata.NewInt64Point(now.Add(-time.Second), -1),
+							ocmetricdata.NewInt64Point(now, value),
+						},
+					},
+				},
+			}, {
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/gauge-b",
+					Type: ocmetricdata.TypeGaugeFloat64,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{
+					{
+						Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 1.5)},
+					},
+				},
+			}, {
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/sum-a",
+					Type: ocmetricdata.TypeCumulativeInt64,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{
+					{
+						Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+					},
+				},
+			}, {
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/summary-a",
+					Type: ocmetricdata.TypeSummary,
+				},
+			},
+		}
+	}
+
+	reader := metric.NewManualReader()
+	meter := metric.NewMeterProvider(metric.WithReader(reader)).Meter("test")
+	require.NoError(t, RegisterGaugeCallbacks(meter, fetch))
+
+	for _, expected := range []int64{2, 3} {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		metricdatatest.AssertEqual(t, metricdata.ScopeMetrics{
+			Scope: rm.ScopeMetrics[0].Scope,
+			Metrics: []metricdata.Metrics{
+				{
+					Name:        "foo.com/gauge-a",
+					Description: "an int testing gauge",
+					Unit:        "By",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{
+							{
+								Attributes: attribute.NewSet(attribute.String("a", "1")),
+								Value:      expected,
+							},
+						},
+					},
+				}, {
+					Name: "foo.com/gauge-b",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{
+							{
+								Attributes: attribute.NewSet(),
+								Value:      1.5,
+							},
+						},
+					},
+				},
+			},
+		}, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp())
+	}
+}
+
+func TestRegisterGaugeCallbacksOptions(t *testing.T) {
+	now := time.Now()
+	fetch := func() []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{
+			ocMetric("gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+			ocMetric("gauge-b", ocmetricdata.TypeGaugeFloat64, nil, ocSeries(now, nil, ocmetricdata.NewFloat64Point(now, 1.5))),
+		}
+	}
+
+	reader := metric.NewManualReader()
+	meter := metric.NewMeterProvider(metric.WithReader(reader)).Meter("test")
+	require.NoError(t, RegisterGaugeCallbacks(meter, fetch,
+		WithMetricNamePrefix("foo.com/"),
+		WithAggregationOverride(func(name string, ocType ocmetricdata.Type) ocmetricdata.Type {
+			if name == "gauge-b" {
+				return ocmetricdata.TypeCumulativeFloat64
+			}
+			return ocType
+		}),
+	))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metricdatatest.AssertEqual(t, metricdata.ScopeMetrics{
+		Scope: rm.ScopeMetrics[0].Scope,
+		Metrics: []metricdata.Metrics{{
+			Name: "foo.com/gauge-a",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{{Attributes: attribute.NewSet(), Value: 1}},
+			},
+		}},
+	}, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp())
+}
+
+// failingMeter is a meter that fails to create float64 observable gauges,
+// and records the instruments of registered callbacks.
+type failingMeter struct {
+	noop.Meter
+	registered []otelmetric.Observable
+}
+
+func (*failingMeter) Float64ObservableGauge(string, ...otelmetric.Float64ObservableGaugeOption) (otelmetric.Float64ObservableGauge, error) {
+	return nil, errors.New("float64 gauges are not supported")
+}
+
+func (m *failingMeter) RegisterCallback(f otelmetric.Callback, instruments ...otelmetric.Observable) (otelmetric.Registration, error) {
+	m.registered = instruments
+	return m.Meter.RegisterCallback(f, instruments...)
+}
+
+func TestRegisterGaugeCallbacksInstrumentError(t *testing.T) {
+	now := time.Now()
+	fetch := func() []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{
+			ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil, ocSeries(now, nil, ocmetricdata.NewInt64Point(now, 1))),
+			ocMetric("foo.com/gauge-b", ocmetricdata.TypeGaugeFloat64, nil, ocSeries(now, nil, ocmetricdata.NewFloat64Point(now, 1.5))),
+		}
+	}
+
+	meter := &failingMeter{}
+	assert.Error(t, RegisterGaugeCallbacks(meter, fetch))
+	require.Len(t, meter.registered, 1)
+	assert.NotNil(t, meter.registered[0])
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// ConvertGRPCMetrics converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, with conversions for the metrics of the OpenCensus
+// gRPC plugin, whose names start with "grpc.io/":
+//
+//   - The grpc_client_method and grpc_server_method labels, of value
+//     "package.Service/Method", are converted to the rpc.service and
+//     rpc.method attributes, split on the last '/'.
+//   - The grpc_client_status and grpc_server_status labels are converted to
+//     the rpc.grpc.status_code attribute, with the numeric status code as
+//     value. Unknown status names are kept as strings.
+//   - The dimensionless units of RPC and message counts are replaced with
+//     the "{call}" and "{message}" UCUM annotations.
+//
+// The gRPC conversions replace the mappers of WithMetricScopedKeyMapper and
+// WithUnitMapper in opts.
+func ConvertGRPCMetrics(ocmetrics []*ocmetricdata.Metric, opts ...MetricOption) ([]metricdata.Metrics, error) {
+	return internal.ConvertGRPCMetrics(ocmetrics, newMetricConfig(opts).converterOptions...)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConvertGRPCMetrics(t *testing.T) {
+	now := time.Now()
+	completed := ocMetric("grpc.io/client/completed_rpcs", ocmetricdata.TypeCumulativeInt64,
+		[]string{"grpc_client_method", "grpc_client_status"},
+		ocSeries(now, []string{"pkg.Service/Method", "NOT_FOUND"}, ocmetricdata.NewInt64Point(now, 1)),
+	)
+	completed.Descriptor.Unit = ocmetricdata.UnitDimensionless
+	input := []*ocmetricdata.Metric{
+		completed,
+		ocMetric("", ocmetricdata.TypeGaugeInt64, []string{"a"}, ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1))),
+	}
+
+	output, err := ConvertGRPCMetrics(input, WithDefaultMetricName("unnamed-"))
+	require.NoError(t, err)
+	metricdatatest.AssertEqual(t, metricdata.ScopeMetrics{Metrics: []metricdata.Metrics{
+		{
+			Name: "grpc.io/client/completed_rpcs",
+			Unit: "{call}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: attribute.NewSet(
+						attribute.String("rpc.service", "pkg.Service"),
+						attribute.String("rpc.method", "Method"),
+						attribute.Int64("rpc.grpc.status_code", 5),
+					),
+					StartTime: now,
+					Time:      now,
+					Value:     1,
+				}},
+			},
+		},
+		{
+			Name: "unnamed-1",
+			Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: now, Time: now, Value: 1},
+			}},
+		},
+	}}, metricdata.ScopeMetrics{Metrics: output})
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// HashMetric returns a 64-bit FNV-1a hash of the structure and values of m,
+// e.g. to skip exporting metrics that did not change since their last
+// export. Equal metrics have equal hashes, and metrics that differ in what is
+// hashed have different hashes with high probability. The hash does not
+// depend on the process, so it can be compared across restarts.
+//
+// The hash covers, in order:
+//
+//   - the name and unit of m
+//   - the kind of aggregation of m, e.g. "Sum[int64]"
+//   - the temporality of sums and histograms, and if sums are monotonic
+//   - for each data point, in order: its attributes, its value for gauges
+//     and sums, and its count, sum, bounds, bucket counts, minimum, and
+//     maximum for histograms
+//
+// The description of m, the start times and times of data points, and
+// exemplars are not hashed. Use HashMetricWithTimestamps to hash the start
+// times and times of data points.
+func HashMetric(m metricdata.Metrics) uint64 {
+	return internal.HashMetric(m)
+}
+
+// HashMetricWithTimestamps returns a hash of m like HashMetric that also
+// covers the start time and time of each data point, hashed after its
+// attributes.
+func HashMetricWithTimestamps(m metricdata.Metrics) uint64 {
+	return internal.HashMetricWithTimestamps(m)
+}
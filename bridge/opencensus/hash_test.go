@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHashMetric(t *testing.T) {
+	start := time.Unix(1000, 0)
+	convert := func(now time.Time, value int64) metricdata.Metrics {
+		batch, err := ConvertWithResource([]*ocmetricdata.Metric{
+			ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"a"},
+				ocSeries(start, []string{"1"}, ocmetricdata.NewInt64Point(now, value)),
+			),
+		})
+		require.NoError(t, err)
+		require.Len(t, batch.Metrics, 1)
+		return batch.Metrics[0]
+	}
+	m := convert(start.Add(time.Second), 1)
+	later := convert(start.Add(time.Minute), 1)
+	changed := convert(start.Add(time.Second), 2)
+
+	assert.Equal(t, HashMetric(m), HashMetric(later))
+	assert.NotEqual(t, HashMetric(m), HashMetric(changed))
+	assert.NotEqual(t, HashMetricWithTimestamps(m), HashMetricWithTimestamps(later))
+	assert.Equal(t, HashMetricWithTimestamps(m), HashMetricWithTimestamps(convert(start.Add(time.Second), 1)))
+}
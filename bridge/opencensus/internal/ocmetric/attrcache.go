@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultAttributeCacheSize is the number of attribute sets cached by
+// WithAttributeCache.
+const defaultAttributeCacheSize = 1024
+
+// attributeCache is a least recently used cache of converted attribute sets.
+type attributeCache struct {
+	size int
+	// lru holds the cache entries, most recently used first.
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// attributeCacheEntry is an attribute set cached by its key.
+type attributeCacheEntry struct {
+	key string
+	set attribute.Set
+}
+
+func newAttributeCache(size int) *attributeCache {
+	return &attributeCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the attribute set cached for key, and marks it as the most
+// recently used.
+func (a *attributeCache) get(key string) (attribute.Set, bool) {
+	e, ok := a.entries[key]
+	if !ok {
+		return attribute.Set{}, false
+	}
+	a.lru.MoveToFront(e)
+	return e.Value.(*attributeCacheEntry).set, true
+}
+
+// put caches set for key, evicting the least recently used attribute set if
+// the cache is full.
+func (a *attributeCache) put(key string, set attribute.Set) {
+	if e, ok := a.entries[key]; ok {
+		e.Value.(*attributeCacheEntry).set = set
+		a.lru.MoveToFront(e)
+		return
+	}
+	a.entries[key] = a.lru.PushFront(&attributeCacheEntry{key: key, set: set})
+	if a.lru.Len() > a.size {
+		oldest := a.lru.Back()
+		a.lru.Remove(oldest)
+		delete(a.entries, oldest.Value.(*attributeCacheEntry).key)
+	}
+}
+
+// len returns the number of cached attribute sets.
+func (a *attributeCache) len() int {
+	return a.lru.Len()
+}
+
+// attributeCacheKey returns the key the attributes converted from keys and
+// values of the current metric are cached by. Keys and values are prefixed
+// with their length so that distinct labels have distinct cache keys.
+func (c *Converter) attributeCacheKey(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) string {
+	var b strings.Builder
+	write := func(s string) {
+		b.WriteString(strconv.Itoa(len(s)))
+		b.WriteByte(':')
+		b.WriteString(s)
+	}
+	write(c.metricName)
+	for i, lv := range values {
+		write(keys[i].Key)
+		if lv.Present {
+			b.WriteByte('+')
+			write(lv.Value)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
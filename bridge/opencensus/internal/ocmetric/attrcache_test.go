@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestAttributeCacheEviction(t *testing.T) {
+	cache := newAttributeCache(2)
+	a := attribute.NewSet(attribute.String("k", "a"))
+	b := attribute.NewSet(attribute.String("k", "b"))
+	c := attribute.NewSet(attribute.String("k", "c"))
+
+	cache.put("a", a)
+	cache.put("b", b)
+	got, ok := cache.get("a")
+	require.True(t, ok)
+	assert.True(t, got.Equals(&a))
+
+	// b is the least recently used entry.
+	cache.put("c", c)
+	assert.Equal(t, 2, cache.len())
+	_, ok = cache.get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	got, ok = cache.get("a")
+	require.True(t, ok)
+	assert.True(t, got.Equals(&a))
+	got, ok = cache.get("c")
+	require.True(t, ok)
+	assert.True(t, got.Equals(&c))
+
+	// Replacing an entry does not evict another.
+	cache.put("a", b)
+	assert.Equal(t, 2, cache.len())
+	got, ok = cache.get("a")
+	require.True(t, ok)
+	assert.True(t, got.Equals(&b))
+}
+
+func TestConverterAttributeCache(t *testing.T) {
+	const n = 10
+	ocmetrics := make([]*ocmetricdata.Metric, n)
+	for i := range ocmetrics {
+		ocmetrics[i] = &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				// Metrics with the same labels do not share attribute sets,
+				// as keys may be mapped by metric name.
+				Name:      fmt.Sprintf("foo.com/gauge-%d", i),
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "a" + strconv.Itoa(i%3), Present: true},
+						{},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), int64(i))},
+				},
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "a" + strconv.Itoa(i%3), Present: true},
+						{Value: "", Present: true},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), int64(i))},
+				},
+			},
+		}
+	}
+	expected, err := ConvertMetrics(ocmetrics)
+	require.NoError(t, err)
+
+	for _, size := range []int{1, 4, 2 * n, 4 * n} {
+		t.Run(strconv.Itoa(size), func(t *testing.T) {
+			c := NewConverter(WithAttributeCacheSize(size))
+			for i := 0; i < 3; i++ {
+				output, err := c.ConvertMetrics(ocmetrics)
+				require.NoError(t, err)
+				require.Len(t, output, n)
+				for j := range output {
+					metricdatatest.AssertEqual(t, expected[j], output[j])
+				}
+				assert.LessOrEqual(t, c.attrCache.len(), size)
+			}
+			want := size
+			if want > 2*n {
+				want = 2 * n
+			}
+			assert.Equal(t, want, c.attrCache.len())
+
+			c.Reset()
+			assert.Equal(t, 0, c.attrCache.len())
+		})
+	}
+
+	c := NewConverter(WithAttributeCache())
+	_, err = c.ConvertMetrics(ocmetrics)
+	require.NoError(t, err)
+	assert.Equal(t, defaultAttributeCacheSize, c.attrCache.size)
+	assert.Nil(t, NewConverter().attrCache, "disabled by default")
+}
+
+func TestConverterAttributeCacheWarnings(t *testing.T) {
+	ocmetrics := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "a", Present: true},
+						{Value: "b", Present: true},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), 1)},
+				},
+			},
+		},
+	}
+	c := NewConverter(WithAttributeCache(), WithMaxAttributeCount(1))
+	for i := 0; i < 2; i++ {
+		output, err := c.ConvertMetrics(ocmetrics)
+		assert.ErrorIs(t, err, errTooManyAttributes, "conversion %d", i)
+		require.Len(t, output, 1)
+	}
+	assert.Equal(t, 0, c.attrCache.len(), "sets with warnings are not cached")
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// budgetState is the state of a budgeted conversion retained between the
+// conversions of its chunks.
+type budgetState struct {
+	// remaining are the metrics that remained to be converted after the
+	// last chunk.
+	remaining []*ocmetricdata.Metric
+	// offset is the index of the first remaining metric in the metrics of
+	// the first chunk.
+	offset int
+	// merged are the metrics merged with identical descriptors, by index in
+	// the metrics of the first chunk.
+	merged map[int]*ocmetricdata.Metric
+	// next is the first remaining metric, converted by the last chunk
+	// although it did not fit in its budget, and nextErr its errors.
+	next    metricdata.Metrics
+	nextErr error
+}
+
+// ConvertMetricsBudgeted converts metric data from OpenCensus to
+// OpenTelemetry like ConvertMetrics with opts, in order, until converting
+// the next metric would make the EstimateSize of the converted metrics
+// exceed maxBytes, as described for the ConvertMetricsBudgeted method of
+// Converter. Use a Converter to convert the remaining metrics.
+func ConvertMetricsBudgeted(ocmetrics []*ocmetricdata.Metric, maxBytes int, opts ...Option) (converted []metricdata.Metrics, remaining []*ocmetricdata.Metric, err error) {
+	return NewConverter(opts...).ConvertMetricsBudgeted(ocmetrics, maxBytes)
+}
+
+// ConvertMetricsBudgeted converts metric data from OpenCensus to
+// OpenTelemetry like ConvertMetrics, in order, until converting the next
+// metric would make the EstimateSize of the converted metrics exceed
+// maxBytes. It returns the converted metrics and the metrics that remain to
+// be converted, e.g. in the next export cycle. The first converted metric is
+// always returned, even if it exceeds maxBytes on its own, so that repeated
+// calls convert all metrics.
+//
+// Calling ConvertMetricsBudgeted with the returned remaining metrics
+// continues the conversion: the metrics are checked for name collisions and
+// incompatible types against those of the previous chunks, metrics with an
+// empty name are named after their index in the metrics of the first chunk,
+// and the metric that did not fit in the previous budget is not converted
+// again. Calling it with any other metrics starts a new conversion. Stats,
+// DroppedMetrics, and the maximum number of errors apply to each chunk.
+// Metrics are converted in their order, regardless of WithStableOrdering.
+//
+// The errors of the metric that did not fit in the budget are returned with
+// the chunk that returns the metric.
+func (c *Converter) ConvertMetricsBudgeted(ocmetrics []*ocmetricdata.Metric, maxBytes int) (converted []metricdata.Metrics, remaining []*ocmetricdata.Metric, err error) {
+	b := c.budget
+	c.budget = nil
+	continued := b != nil && len(ocmetrics) > 0 && len(ocmetrics) == len(b.remaining) && &ocmetrics[0] == &b.remaining[0]
+	if !continued {
+		c.instrumentNames = make(map[string]string)
+		c.metricKinds = make(map[string]aggregationKind)
+		b = &budgetState{merged: c.deduplicateDescriptors(ocmetrics)}
+	}
+	c.beginConversion()
+
+	var size int
+	var nilIndices []int
+	for n, ocm := range ocmetrics {
+		i := b.offset + n
+		var m metricdata.Metrics
+		var ok bool
+		var metricErr error
+		if n == 0 && continued {
+			m, ok, metricErr = b.next, true, b.nextErr
+		} else {
+			if ocm == nil {
+				c.stats.NilMetrics++
+				nilIndices = append(nilIndices, i)
+				continue
+			}
+			if merged, found := b.merged[i]; found {
+				if merged == nil {
+					continue
+				}
+				ocm = merged
+			}
+			var start time.Time
+			if c.cfg.timingRecorder != nil {
+				start = c.cfg.now()
+			}
+			m, ok, metricErr = c.convertMetric(i, ocm)
+			if c.cfg.timingRecorder != nil {
+				c.cfg.timingRecorder(ocm.Descriptor.Name, c.cfg.now().Sub(start))
+			}
+		}
+		if ok {
+			mSize := EstimateSize([]metricdata.Metrics{m})
+			if len(converted) > 0 && size+mSize > maxBytes {
+				remaining = ocmetrics[n:]
+				c.budget = &budgetState{
+					remaining: remaining,
+					offset:    i,
+					merged:    b.merged,
+					next:      m,
+					nextErr:   metricErr,
+				}
+				break
+			}
+			size += mSize
+			if c.cfg.successHandler != nil {
+				c.cfg.successHandler(m)
+			}
+			converted = append(converted, m)
+		}
+		err = errors.Join(err, metricErr)
+	}
+	if c.cfg.reportNilMetrics && len(nilIndices) > 0 {
+		sort.Ints(nilIndices)
+		err = errors.Join(err, fmt.Errorf("%w: %d at indices %v", errNilMetrics, len(nilIndices), nilIndices))
+	}
+	if c.omittedErrCount > 0 {
+		err = errors.Join(err, fmt.Errorf("+%d more errors", c.omittedErrCount))
+	}
+	return converted, remaining, c.wrapErr(err)
+}
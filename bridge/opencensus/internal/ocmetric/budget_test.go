@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConvertMetricsBudgeted(t *testing.T) {
+	ocmetrics := histograms(5)
+	expected, err := ConvertMetrics(ocmetrics)
+	require.NoError(t, err)
+	// All histograms have names of the same length, and the same size.
+	size := EstimateSize(expected[:1])
+
+	for _, tc := range []struct {
+		desc     string
+		maxBytes int
+		batches  []int
+	}{
+		{
+			desc:     "budget for all metrics",
+			maxBytes: 5 * size,
+			batches:  []int{5},
+		},
+		{
+			desc:     "budget for two metrics",
+			maxBytes: 2*size + size/2,
+			batches:  []int{2, 2, 1},
+		},
+		{
+			desc:     "budget smaller than a metric",
+			maxBytes: size / 2,
+			batches:  []int{1, 1, 1, 1, 1},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var conversions int
+			c := NewConverter(WithTimingHistogram(func(string, time.Duration) {
+				conversions++
+			}))
+			remaining := ocmetrics
+			var output []metricdata.Metrics
+			for _, n := range tc.batches {
+				require.NotEmpty(t, remaining)
+				var converted []metricdata.Metrics
+				converted, remaining, err = c.ConvertMetricsBudgeted(remaining, tc.maxBytes)
+				require.NoError(t, err)
+				assert.Len(t, converted, n)
+				output = append(output, converted...)
+			}
+			assert.Empty(t, remaining)
+			assert.Equal(t, len(ocmetrics), conversions, "each metric must be converted once")
+			metricdatatest.AssertEqual(t,
+				metricdata.ScopeMetrics{Metrics: expected},
+				metricdata.ScopeMetrics{Metrics: output})
+		})
+	}
+
+	t.Run("continued conversion", func(t *testing.T) {
+		gauge := &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: ocmetrics[0].Descriptor.Name, Type: ocmetricdata.TypeGaugeInt64},
+		}
+		unnamed := &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Type: ocmetricdata.TypeGaugeInt64},
+		}
+		input := []*ocmetricdata.Metric{ocmetrics[0], ocmetrics[1], gauge, unnamed}
+		c := NewConverter(WithDefaultMetricName("unnamed-"), WithMaxErrors(0))
+		converted, remaining, err := c.ConvertMetricsBudgeted(input, size)
+		require.NoError(t, err)
+		assert.Len(t, converted, 1)
+		require.Equal(t, input[1:], remaining)
+
+		// The gauge has the name of the histogram of the first chunk.
+		converted, remaining, err = c.ConvertMetricsBudgeted(remaining, 2*size)
+		assert.EqualError(t, err, defaultErrorPrefix+": +1 more errors")
+		require.Len(t, converted, 2)
+		assert.Equal(t, ocmetrics[1].Descriptor.Name, converted[0].Name)
+		assert.Equal(t, "unnamed-3", converted[1].Name)
+		assert.Empty(t, remaining)
+		assert.Contains(t, c.DroppedMetrics(), gauge.Descriptor.Name)
+
+		// Other metrics start a new conversion.
+		converted, _, err = c.ConvertMetricsBudgeted([]*ocmetricdata.Metric{gauge}, size)
+		require.NoError(t, err)
+		assert.Len(t, converted, 1)
+	})
+
+	t.Run("dropped metrics", func(t *testing.T) {
+		input := []*ocmetricdata.Metric{
+			nil,
+			{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/summary-a", Type: ocmetricdata.TypeSummary}},
+			ocmetrics[0],
+			{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/summary-b", Type: ocmetricdata.TypeSummary}},
+			ocmetrics[1],
+		}
+		converted, remaining, err := ConvertMetricsBudgeted(input, size)
+		assert.ErrorIs(t, err, errAggregationType)
+		assert.Contains(t, err.Error(), "foo.com/summary-b")
+		assert.Len(t, converted, 1)
+		assert.Equal(t, input[4:], remaining)
+	})
+}
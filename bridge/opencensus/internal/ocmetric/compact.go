@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// SparseBucket is the count of a histogram bucket with a non-zero count.
+type SparseBucket struct {
+	// Index is the index of the bucket in the dense bucket counts.
+	Index int
+	// Count is the count of the bucket.
+	Count uint64
+}
+
+// SparseBucketCounts is a sparse representation of the bucket counts of a
+// histogram data point, for exporters to backends that support sparse
+// histograms.
+type SparseBucketCounts struct {
+	// Len is the number of buckets of the dense bucket counts.
+	Len int
+	// Buckets are the buckets with a non-zero count, in index order.
+	Buckets []SparseBucket
+}
+
+// CompactHistogram returns the sparse representation of the bucket counts of
+// p: the index and count of each bucket with a non-zero count. p is not
+// modified.
+func CompactHistogram[N int64 | float64](p metricdata.HistogramDataPoint[N]) SparseBucketCounts {
+	sparse := SparseBucketCounts{Len: len(p.BucketCounts)}
+	for i, count := range p.BucketCounts {
+		if count != 0 {
+			sparse.Buckets = append(sparse.Buckets, SparseBucket{Index: i, Count: count})
+		}
+	}
+	return sparse
+}
+
+// Dense returns the dense bucket counts represented by s.
+func (s SparseBucketCounts) Dense() []uint64 {
+	counts := make([]uint64, s.Len)
+	for _, b := range s.Buckets {
+		counts[b.Index] = b.Count
+	}
+	return counts
+}
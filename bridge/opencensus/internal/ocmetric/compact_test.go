@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCompactHistogram(t *testing.T) {
+	mostlyEmpty := make([]uint64, 100)
+	mostlyEmpty[3], mostlyEmpty[97] = 5, 1
+	for _, tc := range []struct {
+		desc     string
+		counts   []uint64
+		expected SparseBucketCounts
+	}{
+		{
+			desc:     "no buckets",
+			expected: SparseBucketCounts{},
+		},
+		{
+			desc:     "all empty",
+			counts:   []uint64{0, 0, 0, 0},
+			expected: SparseBucketCounts{Len: 4},
+		},
+		{
+			desc:   "mostly empty",
+			counts: mostlyEmpty,
+			expected: SparseBucketCounts{
+				Len:     100,
+				Buckets: []SparseBucket{{Index: 3, Count: 5}, {Index: 97, Count: 1}},
+			},
+		},
+		{
+			desc:   "overflow bucket",
+			counts: []uint64{0, 0, 0, 8},
+			expected: SparseBucketCounts{
+				Len:     4,
+				Buckets: []SparseBucket{{Index: 3, Count: 8}},
+			},
+		},
+		{
+			desc:   "dense",
+			counts: []uint64{1, 2, 3},
+			expected: SparseBucketCounts{
+				Len:     3,
+				Buckets: []SparseBucket{{Index: 0, Count: 1}, {Index: 1, Count: 2}, {Index: 2, Count: 3}},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var counts []uint64
+			if tc.counts != nil {
+				counts = append([]uint64{}, tc.counts...)
+			}
+			p := metricdata.HistogramDataPoint[float64]{BucketCounts: counts}
+			sparse := CompactHistogram(p)
+			assert.Equal(t, tc.expected, sparse)
+			assert.Equal(t, tc.counts, p.BucketCounts, "dense form is not modified")
+			if tc.counts != nil {
+				assert.Equal(t, tc.counts, sparse.Dense())
+			}
+
+			assert.Equal(t, sparse, CompactHistogram(metricdata.HistogramDataPoint[int64]{BucketCounts: counts}))
+		})
+	}
+}
@@ -0,0 +1,1660 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// config contains options for converting OpenCensus metrics.
+type config struct {
+	// nameEmptyMetrics determines if metrics with an empty name are named
+	// using defaultNamePrefix instead of being dropped.
+	nameEmptyMetrics  bool
+	defaultNamePrefix string
+	// latestGaugePointOnly determines if only the latest point of each gauge
+	// time series is converted.
+	latestGaugePointOnly bool
+	// maxAttributeCount is the maximum number of attributes of a data point.
+	// A negative value means there is no limit.
+	maxAttributeCount int
+	// attributeKeyMapper, if set, maps OpenCensus label keys to attribute
+	// keys.
+	attributeKeyMapper func(attribute.Key) attribute.Key
+	// maxHistogramBuckets is the maximum number of buckets of a histogram
+	// data point. A non-positive value means there is no limit.
+	maxHistogramBuckets int
+	// sortPointsByTime determines if the data points of each time series are
+	// sorted by time.
+	sortPointsByTime bool
+	// maxErrors is the maximum number of errors reported by a conversion. A
+	// negative value means there is no limit.
+	maxErrors int
+	// fallbackResource, if set, is the resource of metrics without an
+	// OpenCensus resource.
+	fallbackResource *resource.Resource
+	// attributeAllowList, if not nil, is the set of OpenCensus label keys
+	// that are converted to attributes.
+	attributeAllowList map[string]struct{}
+	// temporalitySelector, if set, selects the temporality of sums and
+	// histograms by metric name and OpenCensus type.
+	temporalitySelector func(string, ocmetricdata.Type) metricdata.Temporality
+	// rejectNegativeBounds determines if distributions with negative bounds
+	// are dropped.
+	rejectNegativeBounds bool
+	// maxDescriptionLength is the maximum length in bytes of a metric
+	// description. A non-positive value means there is no limit.
+	maxDescriptionLength int
+	// detectResets determines if resets of cumulative time series are
+	// reported.
+	detectResets bool
+	// aggregationOverride, if set, selects the OpenCensus type metrics are
+	// converted as by metric name and declared OpenCensus type.
+	aggregationOverride func(string, ocmetricdata.Type) ocmetricdata.Type
+	// gaugeNaNPolicy determines how NaN values of float64 gauges are
+	// converted.
+	gaugeNaNPolicy NaNPolicy
+	// metricNamePrefix is prepended to the names of converted metrics,
+	// including its separator.
+	metricNamePrefix string
+	// labelDescriptionsAsExemplarAttrs determines if label key descriptions
+	// are added to exemplar filtered attributes.
+	labelDescriptionsAsExemplarAttrs bool
+	// validateUTF8 determines if invalid UTF-8 in attribute values is
+	// replaced.
+	validateUTF8 bool
+	// detectNonMonotonic determines if sums whose values decrease are
+	// converted as non-monotonic.
+	detectNonMonotonic bool
+	// monotonicTimestamps determines if colliding times of the data points
+	// of a time series are made distinct.
+	monotonicTimestamps bool
+	// labelTypes are the attribute types the values of OpenCensus labels are
+	// converted to by label key. Labels without a type are converted to
+	// string attributes.
+	labelTypes map[string]attribute.Type
+	// sourceAttrs are added to the resources of converted metrics.
+	sourceAttrs []attribute.KeyValue
+	// histogramExtremaFromExemplars determines if the minimum and maximum of
+	// histogram data points are derived from their exemplars.
+	histogramExtremaFromExemplars bool
+	// exemplarResolver, if set, returns exemplars of histogram data points
+	// in addition to those of their OpenCensus buckets.
+	exemplarResolver func(string, attribute.Set) []metricdata.Exemplar[float64]
+	// emptyAttributeSetKey, if set, is the attribute of data points that
+	// would have no attributes.
+	emptyAttributeSetKey *attribute.KeyValue
+	// unitMapper, if set, maps the units of metrics by metric name.
+	unitMapper func(string, string) string
+	// labelValueMapper, if set, converts label values by metric name and
+	// label key. Values it returns false for are converted as configured by
+	// the other options.
+	labelValueMapper func(string, string, string) (attribute.Value, bool)
+	// labelSplitter, if set, converts labels to multiple attributes by
+	// metric name and label key. Labels it returns no attributes for are
+	// converted as configured by the other options.
+	labelSplitter func(string, string, string) []attribute.KeyValue
+	// boundsDecimals is the number of decimal places histogram bounds are
+	// rounded to. Negative values disable rounding.
+	boundsDecimals int
+	// tolerateExtraValues determines if time series with a different number
+	// of label values than label keys are converted.
+	tolerateExtraValues bool
+	// extraValueKeyPrefix is the prefix of the keys of extra label values.
+	extraValueKeyPrefix string
+	// maxExemplarsPerPoint is the maximum number of exemplars of a histogram
+	// data point. Non-positive values mean there is no limit.
+	maxExemplarsPerPoint int
+	// timingRecorder, if set, is called with the conversion duration of each
+	// metric.
+	timingRecorder func(string, time.Duration)
+	// now returns the current time, e.g. to measure conversion durations.
+	now func() time.Time
+	// sumOfSquaredDeviationKey, if set, is the attribute key of the sum of
+	// squared deviation of distributions.
+	sumOfSquaredDeviationKey string
+	// descriptionFallback, if set, returns the description of metrics without
+	// one by metric name.
+	descriptionFallback func(string) string
+	// semConvRegistry, if set, is the registry converted metric names and
+	// attribute keys are validated against.
+	semConvRegistry SemConvRegistry
+	// pointFilter, if set, selects the data points that are converted.
+	pointFilter func(string, attribute.Set, time.Time) bool
+	// attributeValueMapper, if set, maps the values of converted attributes.
+	attributeValueMapper func(attribute.Key, attribute.Value) attribute.Value
+	// counterResetSplit determines if decreases of sums within a conversion
+	// start a new time series epoch.
+	counterResetSplit bool
+	// sumDecimals is the number of decimal places histogram sums are rounded
+	// to. Negative values disable rounding.
+	sumDecimals int
+	// skipEmptyMetrics determines if metrics without data points are
+	// dropped.
+	skipEmptyMetrics bool
+	// instrumentNameValidation determines how names of converted metrics
+	// that are not valid instrument names are converted.
+	instrumentNameValidation InstrumentNameValidation
+	// keyValueBufferPool, if set, holds the buffers attributes are converted
+	// in.
+	keyValueBufferPool *sync.Pool
+	// resourceAttributeTypes are the attribute types the values of
+	// OpenCensus resource labels are converted to by label key.
+	resourceAttributeTypes map[string]attribute.Type
+	// incompatibleTypeHandling determines how metrics with the name of a
+	// metric of another type are converted.
+	incompatibleTypeHandling IncompatibleTypeHandling
+	// enrichmentOverride determines if enrichment attributes replace
+	// attributes with the same key.
+	enrichmentOverride bool
+	// unitOverrides are the units of converted metrics by metric name.
+	unitOverrides map[string]string
+	// floatGaugeToInt determines if, and how, float64 gauges are converted
+	// to int64 gauges.
+	floatGaugeToInt FloatToIntMode
+	// timestampResolution is the resolution the times of converted data
+	// points are truncated to. Non-positive values disable truncation.
+	timestampResolution time.Duration
+	// reportNilMetrics determines if skipped nil metrics are reported as an
+	// error.
+	reportNilMetrics bool
+	// unsupportedAggregationHandler, if set, converts metrics of OpenCensus
+	// types that are not supported.
+	unsupportedAggregationHandler func(*ocmetricdata.Metric) (metricdata.Aggregation, error)
+	// monotonicCorrection determines if decreasing values of sums within a
+	// conversion are raised to the previous value.
+	monotonicCorrection bool
+	// resourceGroupingKeys, if set, are the resource attribute keys metrics
+	// are grouped by.
+	resourceGroupingKeys map[attribute.Key]struct{}
+	// pointTimeFallback determines if zero times of data points are
+	// replaced.
+	pointTimeFallback bool
+	// scopePerMetric determines if each metric converted with
+	// ConvertResourceMetrics is included in a scope named after it.
+	scopePerMetric bool
+	// valueTypeMismatch determines how data points with a value of the wrong
+	// type are converted.
+	valueTypeMismatch ValueTypeMismatchHandling
+	// view, if set, transforms converted aggregations by metric name.
+	view func(string, metricdata.Aggregation) metricdata.Aggregation
+	// internStrings determines if the string values of converted attributes
+	// are interned.
+	internStrings bool
+	// sortByStartTime determines if the data points of each converted metric
+	// are sorted by start time.
+	sortByStartTime bool
+	// resetHandling determines how resets of cumulative sums are converted.
+	resetHandling ResetHandling
+	// metricScopedKeyMapper, if set, maps attribute keys by metric name.
+	metricScopedKeyMapper func(string, attribute.Key) attribute.Key
+	// validateBucketSum determines if the bucket counts of distributions are
+	// checked against their count, and dropInvalidBucketSum if the points
+	// that fail the check are dropped.
+	validateBucketSum    bool
+	dropInvalidBucketSum bool
+	// checkTimestamps determines if data points with a time outside of
+	// [minTimestamp, maxTimestamp] are dropped.
+	checkTimestamps bool
+	minTimestamp    time.Time
+	maxTimestamp    time.Time
+	// stableOrdering determines if metrics and data points are sorted once
+	// their order is detected to be nondeterministic.
+	stableOrdering bool
+	// attributeCacheSize is the number of converted attribute sets that are
+	// cached. A non-positive value disables the cache.
+	attributeCacheSize int
+	// errorPrefix is the message the errors of a conversion are wrapped
+	// with.
+	errorPrefix string
+	// successHandler, if set, is called with each converted metric.
+	successHandler func(metricdata.Metrics)
+	// globalResource, if set, is merged under the resource of each metric.
+	globalResource *resource.Resource
+	// validateExemplarBounds determines if exemplars with a value outside of
+	// the bounds of their bucket are dropped.
+	validateExemplarBounds bool
+	// maxSeriesAge, if positive, is the maximum age of converted data
+	// points.
+	maxSeriesAge time.Duration
+	// logger logs the warnings and dropped metrics of conversions.
+	logger logr.Logger
+	// attributeKeyValidation determines how attributes with invalid keys are
+	// converted.
+	attributeKeyValidation AttributeKeyValidation
+	// deduplicateDescriptors determines if metrics with identical
+	// descriptors are merged.
+	deduplicateDescriptors bool
+	// sourceLibrary, if set, returns the name of the library that produced
+	// a metric, by metric name.
+	sourceLibrary func(string) string
+	// emptyBucketHistogramHandling determines how distributions with a
+	// count but only empty buckets are converted.
+	emptyBucketHistogramHandling EmptyBucketHistogramHandling
+}
+
+// defaultErrorPrefix is the message the errors of a conversion are wrapped
+// with by default.
+const defaultErrorPrefix = "error converting from OpenCensus to OpenTelemetry"
+
+// newConfig returns a config configured with options.
+func newConfig(options []Option) config {
+	conf := config{
+		maxAttributeCount: -1,
+		maxErrors:         -1,
+		boundsDecimals:    -1,
+		sumDecimals:       -1,
+		now:               time.Now,
+		errorPrefix:       defaultErrorPrefix,
+		logger:            logr.Discard(),
+	}
+	for _, o := range options {
+		conf = o.apply(conf)
+	}
+	return conf
+}
+
+// Option applies a configuration option to the conversion of OpenCensus
+// metrics.
+type Option interface {
+	apply(config) config
+}
+
+// optionFunc applies a set of options to a config.
+type optionFunc func(config) config
+
+// apply returns a config with option(s) applied.
+func (o optionFunc) apply(conf config) config {
+	return o(conf)
+}
+
+// WithDefaultMetricName names metrics that have an empty name with prefix
+// followed by the index of the metric in the converted batch, instead of
+// dropping them.
+//
+// By default, metrics with an empty name are dropped and an error is
+// returned.
+func WithDefaultMetricName(prefix string) Option {
+	return optionFunc(func(conf config) config {
+		conf.nameEmptyMetrics = true
+		conf.defaultNamePrefix = prefix
+		return conf
+	})
+}
+
+// WithLatestGaugePointOnly converts only the point with the latest time of
+// each gauge time series. Other points of gauge time series are dropped and
+// counted in the CoalescedGaugePoints of the conversion Stats. Sums and
+// histograms are not affected.
+//
+// By default, all points of gauge time series are converted.
+func WithLatestGaugePointOnly() Option {
+	return optionFunc(func(conf config) config {
+		conf.latestGaugePointOnly = true
+		return conf
+	})
+}
+
+// WithMaxAttributeCount limits the number of attributes of converted data
+// points to n. Only the first n present OpenCensus labels, in the order of
+// the metric descriptor label keys, are kept and the remaining labels are
+// dropped with an error. Data points of time series that have the same
+// attributes once limited are merged as described for
+// WithAttributeAllowList. A negative n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxAttributeCount(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxAttributeCount = n
+		return conf
+	})
+}
+
+// WithAttributeKeyMapper maps the keys of converted attributes with mapper,
+// e.g. to align OpenCensus label keys with semantic conventions. If mapper
+// maps two label keys of a time series to the same attribute key, an error
+// is returned and the value of the last label is used. Data points of time
+// series that have the same attributes after mapping are merged as described
+// for WithAttributeAllowList.
+//
+// By default, label keys are used as attribute keys unchanged.
+func WithAttributeKeyMapper(mapper func(attribute.Key) attribute.Key) Option {
+	return optionFunc(func(conf config) config {
+		conf.attributeKeyMapper = mapper
+		return conf
+	})
+}
+
+// WithMaxHistogramBuckets limits the number of buckets of converted histogram
+// data points to n. Distributions with more than n buckets have adjacent
+// buckets merged, summing their counts and dropping the bounds between them,
+// and an error is returned noting the downsampling. The total count and sum
+// are preserved. A non-positive n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxHistogramBuckets(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxHistogramBuckets = n
+		return conf
+	})
+}
+
+// WithSortPointsByTime sorts the converted data points of each time series by
+// time, in ascending order. Points of OpenCensus time series should already
+// be ordered, but some producers emit them out of order.
+//
+// By default, data points are in the order of the OpenCensus points.
+func WithSortPointsByTime() Option {
+	return optionFunc(func(conf config) config {
+		conf.sortPointsByTime = true
+		return conf
+	})
+}
+
+// WithMaxErrors limits the number of errors reported by a conversion to n.
+// Further errors are not reported individually. Instead, a single error
+// with the number of omitted errors is reported. Errors that cause data to
+// be dropped still do so when they are omitted. A negative n means there is
+// no limit.
+//
+// By default, there is no limit.
+func WithMaxErrors(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxErrors = n
+		return conf
+	})
+}
+
+// WithFallbackResource sets the resource of metrics that do not have an
+// OpenCensus resource when converted with ConvertResourceMetrics, e.g.
+// resource.Default(). Metrics that have an OpenCensus resource are not
+// affected.
+//
+// By default, metrics without an OpenCensus resource have an empty resource.
+func WithFallbackResource(res *resource.Resource) Option {
+	return optionFunc(func(conf config) config {
+		conf.fallbackResource = res
+		return conf
+	})
+}
+
+// WithAttributeAllowList converts only the OpenCensus labels with one of keys
+// to attributes. All other labels are dropped.
+//
+// Dropping labels can make distinct OpenCensus time series have the same
+// attributes. The data points of these time series that also have the same
+// time are merged into a single data point:
+//
+//   - Sum values are added.
+//   - Histogram counts, sums, and bucket counts are added and exemplars are
+//     combined. Histogram points with different bounds cannot be merged and
+//     are kept separately with an error.
+//   - Gauges keep the value of the last of the data points.
+//
+// The merged sum and histogram data points have the earliest start time of
+// the merged data points.
+//
+// By default, all labels are converted.
+func WithAttributeAllowList(keys ...string) Option {
+	return optionFunc(func(conf config) config {
+		conf.attributeAllowList = make(map[string]struct{}, len(keys))
+		for _, k := range keys {
+			conf.attributeAllowList[k] = struct{}{}
+		}
+		return conf
+	})
+}
+
+// WithTemporalityByName selects the temporality of converted sums and
+// histograms with selector, which is called with the name and OpenCensus
+// type of each cumulative metric. If selector returns an invalid temporality,
+// cumulative temporality is used and an error is returned.
+//
+// Converting to delta temporality is stateful: the Converter retains the
+// last value of each time series to compute the change since the previous
+// conversion. The first conversion of a time series, and the first after it
+// is reset, reports the change since its start time. So does the first
+// conversion of a histogram time series after its bounds changed, and an
+// error is returned.
+//
+// By default, all sums and histograms have cumulative temporality.
+func WithTemporalityByName(selector func(name string, ocType ocmetricdata.Type) metricdata.Temporality) Option {
+	return optionFunc(func(conf config) config {
+		conf.temporalitySelector = selector
+		return conf
+	})
+}
+
+// WithReaderTemporality selects the temporality of converted sums and
+// histograms with selector, e.g. the temporality selector of the exporter
+// of the converted metrics, as the SDK does for instruments. Cumulative
+// OpenCensus sums are selected as counters, and cumulative distributions as
+// histograms. It replaces the selector set by WithTemporalityByName, and
+// converting to delta temporality is stateful as described there.
+//
+// By default, all sums and histograms have cumulative temporality.
+func WithReaderTemporality(selector metric.TemporalitySelector) Option {
+	return WithTemporalityByName(func(_ string, ocType ocmetricdata.Type) metricdata.Temporality {
+		switch ocType {
+		case ocmetricdata.TypeCumulativeDistribution:
+			return selector(metric.InstrumentKindHistogram)
+		default:
+			return selector(metric.InstrumentKindCounter)
+		}
+	})
+}
+
+// WithRejectNegativeBounds drops OpenCensus distributions that have negative
+// bucket bounds, and returns an error. This protects exporters and backends
+// that only support non-negative histogram bounds.
+//
+// By default, negative bounds are converted.
+func WithRejectNegativeBounds() Option {
+	return optionFunc(func(conf config) config {
+		conf.rejectNegativeBounds = true
+		return conf
+	})
+}
+
+// WithMaxDescriptionLength limits the length of converted metric
+// descriptions to n bytes. Longer descriptions are truncated on a rune
+// boundary and suffixed with an ellipsis within the limit, and an error is
+// returned. A non-positive n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxDescriptionLength(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxDescriptionLength = n
+		return conf
+	})
+}
+
+// WithDetectResets reports an error for each data point of a cumulative sum
+// or histogram that has a later start time than a previous data point with
+// the same attributes. The data points are converted unchanged.
+//
+// Different start times for the same attributes mean the cumulative time
+// series was reset, e.g. because the producer restarted. The data point with
+// the later start time begins a new cumulative time series: its value does
+// not include any value reported with an earlier start time, so consumers
+// must only compute rates between data points with the same start time.
+//
+// By default, resets are not reported.
+func WithDetectResets() Option {
+	return optionFunc(func(conf config) config {
+		conf.detectResets = true
+		return conf
+	})
+}
+
+// WithAggregationOverride converts each metric as the OpenCensus type
+// returned by override, which is called with the name and declared
+// OpenCensus type of the metric. This allows fixing metrics whose producer
+// declares the wrong type, e.g. converting a gauge that is actually
+// cumulative to a sum. The points of the metric still need to have values
+// of the returned type. If override returns a type that cannot be
+// converted, the declared type is used and an error is returned.
+//
+// By default, metrics are converted as their declared type.
+func WithAggregationOverride(override func(name string, ocType ocmetricdata.Type) ocmetricdata.Type) Option {
+	return optionFunc(func(conf config) config {
+		conf.aggregationOverride = override
+		return conf
+	})
+}
+
+// NaNPolicy determines how NaN values of data points are converted.
+type NaNPolicy int
+
+const (
+	// NaNKeep converts NaN values unchanged.
+	NaNKeep NaNPolicy = iota
+	// NaNZero converts NaN values to 0.
+	NaNZero
+	// NaNDrop drops data points with a NaN value and returns an error.
+	NaNDrop
+)
+
+// WithGaugeNaNPolicy converts NaN values of float64 gauges, e.g. produced by
+// a division by zero, according to policy. Some backends do not accept NaN
+// values. Int64 gauges, sums, and histograms are not affected.
+//
+// By default, NaN values are kept.
+func WithGaugeNaNPolicy(policy NaNPolicy) Option {
+	return optionFunc(func(conf config) config {
+		conf.gaugeNaNPolicy = policy
+		return conf
+	})
+}
+
+// metricNameSeparators are the separators a metric name prefix can end with.
+const metricNameSeparators = "./_"
+
+// WithMetricNamePrefix prepends prefix to the names of converted metrics,
+// e.g. to namespace the metrics of a producer. A "." separator is inserted
+// between prefix and the name, unless prefix already ends with ".", "/", or
+// "_". The metric names passed to other options, and returned by
+// DroppedMetrics, are not prefixed.
+//
+// By default, metric names are not prefixed.
+func WithMetricNamePrefix(prefix string) Option {
+	return optionFunc(func(conf config) config {
+		if prefix != "" && !strings.ContainsAny(prefix[len(prefix)-1:], metricNameSeparators) {
+			prefix += "."
+		}
+		conf.metricNamePrefix = prefix
+		return conf
+	})
+}
+
+// WithLabelDescriptionsAsExemplarAttrs adds the descriptions of the label
+// keys of each histogram as "label.<key>.description" filtered attributes of
+// the exemplars of its first data point that has exemplars. This makes label
+// descriptions discoverable in exemplar UIs. Label keys without a description
+// and histograms without exemplars are not affected.
+//
+// By default, label key descriptions are not converted.
+func WithLabelDescriptionsAsExemplarAttrs() Option {
+	return optionFunc(func(conf config) config {
+		conf.labelDescriptionsAsExemplarAttrs = true
+		return conf
+	})
+}
+
+// WithValidateUTF8 replaces invalid UTF-8 sequences in converted attribute
+// values with the Unicode replacement character, and returns an error for
+// each such value. Invalid UTF-8 is otherwise only rejected when the metrics
+// are exported, e.g. by OTLP protobuf marshaling.
+//
+// By default, attribute values are not validated.
+func WithValidateUTF8() Option {
+	return optionFunc(func(conf config) config {
+		conf.validateUTF8 = true
+		return conf
+	})
+}
+
+// WithDetectNonMonotonic converts cumulative sums whose values decrease as
+// non-monotonic sums, e.g. OpenCensus cumulative aggregations of gauge-like
+// measures that behave as up-down counters. A sum decreases when a data point
+// has a lower value than the previous data point of the same time series with
+// the same start time. An error is returned for the first decrease of each
+// sum.
+//
+// Detection is stateful: the Converter retains the last value of each sum
+// time series across conversions, and a sum that decreased once is converted
+// as non-monotonic by all later conversions, until Reset is called. Sums are
+// converted as monotonic until a decrease is seen.
+//
+// By default, all sums are converted as monotonic.
+func WithDetectNonMonotonic() Option {
+	return optionFunc(func(conf config) config {
+		conf.detectNonMonotonic = true
+		return conf
+	})
+}
+
+// WithMonotonicTimestamps makes the times of the converted data points of
+// each time series distinct. OpenCensus times often only have millisecond
+// precision, e.g. after a protobuf round-trip, so points recorded in the same
+// millisecond can have the same time. Each data point with the same time as
+// the previous data point of its time series is moved to a nanosecond after
+// it. Data points earlier than the previous data point are not moved; use
+// WithSortPointsByTime to order them first.
+//
+// By default, the times of OpenCensus points are converted unchanged.
+func WithMonotonicTimestamps() Option {
+	return optionFunc(func(conf config) config {
+		conf.monotonicTimestamps = true
+		return conf
+	})
+}
+
+// WithInt64Labels converts the values of the OpenCensus labels with one of
+// keys to int64 attributes. Values that are not base 10 integers, or are
+// outside of the int64 range, are converted to string attributes and an
+// error is returned, instead of being truncated.
+//
+// By default, all label values are converted to string attributes.
+func WithInt64Labels(keys ...string) Option {
+	return optionFunc(func(conf config) config {
+		return conf.withLabelTypes(attribute.INT64, keys...)
+	})
+}
+
+// withLabelTypes returns conf with the values of the labels with one of keys
+// converted to attributes of type t.
+func (conf config) withLabelTypes(t attribute.Type, keys ...string) config {
+	if conf.labelTypes == nil {
+		conf.labelTypes = make(map[string]attribute.Type, len(keys))
+	}
+	for _, k := range keys {
+		conf.labelTypes[k] = t
+	}
+	return conf
+}
+
+// WithSourceAttribute adds a key attribute with value to the resource of
+// metrics converted with ConvertResourceMetrics, e.g. to identify the
+// OpenCensus producer of metrics aggregated from multiple producers. The
+// attribute is added to the resource, including the fallback resource, so it
+// does not increase the number of time series. It replaces a resource
+// attribute with the same key.
+//
+// By default, no attributes are added to resources.
+func WithSourceAttribute(key, value string) Option {
+	return optionFunc(func(conf config) config {
+		conf.sourceAttrs = append(conf.sourceAttrs, attribute.String(key, value))
+		return conf
+	})
+}
+
+// WithHistogramExtremaFromExemplars sets the Min and Max of converted
+// histogram data points to the minimum and maximum values of their exemplars.
+// OpenCensus distributions do not record their minimum and maximum, so these
+// are approximations: the true minimum can be lower, and the true maximum
+// higher, than any exemplar value. The Min and Max of data points without
+// exemplars are unset, as are those of data points merged with a data point
+// without them.
+//
+// By default, the Min and Max of histogram data points are unset.
+func WithHistogramExtremaFromExemplars() Option {
+	return optionFunc(func(conf config) config {
+		conf.histogramExtremaFromExemplars = true
+		return conf
+	})
+}
+
+// WithExemplarResolver adds the exemplars returned by resolver to those of
+// each converted histogram data point. It is called with the name of the
+// metric, without any prefix, and the converted attributes of each data
+// point, and supports exemplars recorded outside of OpenCensus distributions,
+// e.g. in a store of sampled spans. The returned exemplars are sorted with
+// those of the OpenCensus buckets, by time, then value, and are included
+// when deriving Min and Max with WithHistogramExtremaFromExemplars.
+//
+// By default, histogram data points only have the exemplars of their
+// OpenCensus buckets.
+func WithExemplarResolver(resolver func(metricName string, attrs attribute.Set) []metricdata.Exemplar[float64]) Option {
+	return optionFunc(func(conf config) config {
+		conf.exemplarResolver = resolver
+		return conf
+	})
+}
+
+// WithView transforms the converted aggregation of each metric with view,
+// which is called with the name and converted aggregation of the metric,
+// like a view of the OpenTelemetry SDK. For example, view can reduce a
+// histogram to a sum of its count to lower the volume of exported data.
+// If view returns an aggregation of a type other than metricdata.Gauge,
+// metricdata.Sum, or metricdata.Histogram[float64], the converted aggregation
+// is used and an error is returned.
+//
+// By default, converted aggregations are not transformed.
+func WithView(view func(name string, agg metricdata.Aggregation) metricdata.Aggregation) Option {
+	return optionFunc(func(conf config) config {
+		conf.view = view
+		return conf
+	})
+}
+
+// WithStringInterner interns the string values of converted attributes, so
+// that equal values share the same memory instead of retaining the memory of
+// each OpenCensus label value. This reduces the memory of converted metrics
+// with many data points sharing few label values. For example, converted
+// metrics of 10000 data points with 10 distinct 64 byte label values retain
+// about 2.3 MB without interning and 1.7 MB with it, as their label values
+// shrink from 640 KB to 640 B (see BenchmarkStringInterner).
+//
+// The Converter retains every interned value for its lifetime, including
+// values that are no longer used. Interning also adds a lookup for each label
+// value, so it only helps workloads where label values repeat.
+//
+// By default, label values are not interned.
+func WithStringInterner() Option {
+	return optionFunc(func(conf config) config {
+		conf.internStrings = true
+		return conf
+	})
+}
+
+// WithSortByStartTime sorts the data points of each converted metric by start
+// time, then time, in ascending order, across all its time series. Some
+// backends ingest data points more efficiently in this order. Data points
+// with the same start time and time keep their order.
+//
+// By default, data points are in the order of the OpenCensus time series and
+// points.
+func WithSortByStartTime() Option {
+	return optionFunc(func(conf config) config {
+		conf.sortByStartTime = true
+		return conf
+	})
+}
+
+// ResetHandling determines how resets of cumulative sums are converted.
+type ResetHandling int
+
+const (
+	// ResetPassthrough converts the data points after a reset unchanged.
+	ResetPassthrough ResetHandling = iota
+	// ResetZero adds a data point with a zero value at the start time of the
+	// first data point after a reset, before that data point.
+	ResetZero
+	// ResetSkip drops the first data point after a reset.
+	ResetSkip
+)
+
+// WithResetHandling converts resets of cumulative sums according to
+// handling. A sum time series is reset, e.g. when its producer restarts,
+// when a data point has a later start time than the previous data points of
+// the time series. Its value then starts over, which shows as a drop.
+//
+// Reset handling is stateful: the Converter retains the latest start time of
+// each sum time series across conversions to detect resets between them,
+// until Reset is called. The first data point of a time series converted by
+// the Converter, or after Reset, is never handled as a reset.
+//
+// By default, resets are passed through unchanged.
+func WithResetHandling(handling ResetHandling) Option {
+	return optionFunc(func(conf config) config {
+		conf.resetHandling = handling
+		return conf
+	})
+}
+
+// WithBooleanLabels converts the values of the OpenCensus labels with one of
+// keys to bool attributes. The values "true" and "false", in any case, are
+// converted. Other values are converted to string attributes and an error is
+// returned.
+//
+// By default, all label values are converted to string attributes.
+func WithBooleanLabels(keys ...string) Option {
+	return optionFunc(func(conf config) config {
+		return conf.withLabelTypes(attribute.BOOL, keys...)
+	})
+}
+
+// WithMetricScopedKeyMapper maps the keys of converted attributes with
+// mapper, which is called with the name of the metric and the attribute key.
+// Unlike WithAttributeKeyMapper, this allows renaming keys of specific
+// metrics only, e.g. renaming "method" to "http.request.method" on HTTP
+// metrics when gradually adopting semantic conventions. If both are used,
+// mapper is called with the keys mapped by the WithAttributeKeyMapper mapper.
+// If mapper maps two label keys of a time series to the same attribute key,
+// an error is returned and the value of the last label is used. Colliding
+// data points are merged as with WithAttributeKeyMapper.
+//
+// By default, label keys are used as attribute keys unchanged.
+func WithMetricScopedKeyMapper(mapper func(metricName string, key attribute.Key) attribute.Key) Option {
+	return optionFunc(func(conf config) config {
+		conf.metricScopedKeyMapper = mapper
+		return conf
+	})
+}
+
+// WithValidateBucketSum returns an error for each OpenCensus distribution
+// with bucket counts that do not sum to its count. Such distributions are
+// malformed, and the converted histograms would skew percentiles calculated
+// from them. If drop is true, the data points of these distributions are
+// dropped, otherwise they are converted. Distributions without buckets are
+// not checked.
+//
+// By default, bucket counts are not checked.
+func WithValidateBucketSum(drop bool) Option {
+	return optionFunc(func(conf config) config {
+		conf.validateBucketSum = true
+		conf.dropInvalidBucketSum = drop
+		return conf
+	})
+}
+
+// WithTimestampSanity drops data points with a time before earliest or after
+// latest, and returns an error for each. This guards against corrupt times from
+// non-standard producers, e.g. hand-built metrics with epoch seconds used as
+// nanoseconds, which would otherwise be decades off.
+//
+// By default, data points are converted regardless of their time.
+func WithTimestampSanity(earliest, latest time.Time) Option {
+	return optionFunc(func(conf config) config {
+		conf.checkTimestamps = true
+		conf.minTimestamp = earliest
+		conf.maxTimestamp = latest
+		return conf
+	})
+}
+
+// WithStableOrdering sorts converted metrics by name, and their data points by
+// attributes, then time, once the order of the OpenCensus metrics appears to
+// be nondeterministic, e.g. because they are produced by iterating a map.
+// This makes the output of conversions of the same data identical, without
+// the cost of sorting when the order is already deterministic.
+//
+// The detection is heuristic and stateful: the order of the metric names of
+// each conversion is compared to that of the previous conversion of the
+// Converter. Once the same names appear in a different order, this and all
+// later conversions are sorted, until Reset is called. Conversions before a
+// reordering is seen are not sorted, and a nondeterministic order of only the
+// data points of a metric is not detected.
+//
+// By default, metrics and data points are converted in the OpenCensus order.
+func WithStableOrdering() Option {
+	return optionFunc(func(conf config) config {
+		conf.stableOrdering = true
+		return conf
+	})
+}
+
+// WithEmptyAttributeSetKey sets the attributes of converted data points that
+// would have no attributes, e.g. those of metrics without labels, to a single
+// key attribute with value, for backends that require data points to have at
+// least one attribute.
+//
+// By default, these data points have the empty attribute set.
+func WithEmptyAttributeSetKey(key, value string) Option {
+	return optionFunc(func(conf config) config {
+		kv := attribute.String(key, value)
+		conf.emptyAttributeSetKey = &kv
+		return conf
+	})
+}
+
+// WithUnitMapper maps the units of converted metrics with mapper, which is
+// called with the name of the metric, without any prefix, and its OpenCensus
+// unit, e.g. to replace dimensionless units with UCUM annotations.
+//
+// By default, the OpenCensus unit is used unchanged.
+func WithUnitMapper(mapper func(metricName, unit string) string) Option {
+	return optionFunc(func(conf config) config {
+		conf.unitMapper = mapper
+		return conf
+	})
+}
+
+// withLabelValueMapper converts label values with mapper, which is called
+// with the name of the metric and the key and value of each label, and
+// returns false if the value is not converted by it.
+func withLabelValueMapper(mapper func(metricName, key, value string) (attribute.Value, bool)) Option {
+	return optionFunc(func(conf config) config {
+		conf.labelValueMapper = mapper
+		return conf
+	})
+}
+
+// withLabelSplitter converts labels with splitter, which is called with the
+// name of the metric and the key and value of each label, and returns the
+// attributes the label is converted to, or none if the label is not converted
+// by it.
+func withLabelSplitter(splitter func(metricName, key, value string) []attribute.KeyValue) Option {
+	return optionFunc(func(conf config) config {
+		conf.labelSplitter = splitter
+		return conf
+	})
+}
+
+// WithBoundsRounding rounds the bounds of converted histograms to decimals
+// decimal places, e.g. to convert a bound of 0.9999999999 to 1 with decimals
+// 2. Bucket counts are not changed. Histogram data points with distinct
+// bounds that are equal after rounding are dropped, and an error is returned.
+// A negative decimals disables rounding.
+//
+// By default, bounds are not rounded.
+func WithBoundsRounding(decimals int) Option {
+	return optionFunc(func(conf config) config {
+		conf.boundsDecimals = decimals
+		return conf
+	})
+}
+
+// WithNumericLabelTypes converts the values of the OpenCensus labels with the
+// keys of types to attributes of the type of the key: attribute.INT64,
+// attribute.FLOAT64, or attribute.BOOL. Values that cannot be parsed as that
+// type are converted to string attributes and an error is returned, as with
+// WithInt64Labels and WithBooleanLabels. Labels with other types are
+// converted to string attributes. Types override those set by earlier
+// options for the same keys.
+//
+// By default, all label values are converted to string attributes.
+func WithNumericLabelTypes(types map[string]attribute.Type) Option {
+	return optionFunc(func(conf config) config {
+		for k, t := range types {
+			conf = conf.withLabelTypes(t, k)
+		}
+		return conf
+	})
+}
+
+// WithTolerateExtraValues converts time series with a different number of
+// label values than their metric has label keys, instead of dropping them
+// with an error, to recover the data of producers that append unlabeled
+// values. Extra values are converted to attributes with keys of keyPrefix
+// followed by the index of the value, e.g. "label_2" for the third value with
+// keyPrefix "label_", and keys without a value are converted like absent
+// values. An error is returned for each of these time series.
+//
+// By default, time series with a different number of label values than label
+// keys are dropped, and an error is returned.
+func WithTolerateExtraValues(keyPrefix string) Option {
+	return optionFunc(func(conf config) config {
+		conf.tolerateExtraValues = true
+		conf.extraValueKeyPrefix = keyPrefix
+		return conf
+	})
+}
+
+// WithMaxExemplarsPerPoint limits the number of exemplars of converted
+// histogram data points to n, e.g. to stay within the limits of a backend.
+// Exemplars with a trace context are retained over those without one, then
+// exemplars with higher values over those with lower values, and an error is
+// returned noting the dropped exemplars. Retained exemplars are sorted by
+// time, then value, like all exemplars. A non-positive n means there is no limit.
+//
+// By default, there is no limit.
+func WithMaxExemplarsPerPoint(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxExemplarsPerPoint = n
+		return conf
+	})
+}
+
+// WithTimingHistogram calls recorder with the name of each OpenCensus metric
+// and the duration of its conversion, after it is converted, e.g. to record
+// the durations in a histogram when profiling the conversion of large
+// batches. Nothing is retained by the Converter. recorder is called
+// synchronously and adds its own duration to that of the conversion.
+//
+// By default, conversion durations are not measured.
+func WithTimingHistogram(recorder func(metricName string, d time.Duration)) Option {
+	return optionFunc(func(conf config) config {
+		conf.timingRecorder = recorder
+		return conf
+	})
+}
+
+// WithClock sets the function the Converter calls for the current time, e.g.
+// to measure conversion durations or fall back to the current time. A nil
+// now is ignored.
+//
+// By default, time.Now is used.
+func WithClock(now func() time.Time) Option {
+	return optionFunc(func(conf config) config {
+		if now != nil {
+			conf.now = now
+		}
+		return conf
+	})
+}
+
+// WithPreserveSumOfSquaredDeviation adds the sum of squared deviation of
+// OpenCensus distributions, which OpenTelemetry histograms do not have, to
+// the attributes of converted histogram data points as a float64 attribute
+// with key, e.g. to compute the variance of the distributions. It is not
+// added if it is zero. This is not a semantic convention, and since the value
+// changes between data points, each data point of a time series has distinct
+// attributes: it should not be combined with delta temporality or reset
+// detection, which track time series by attributes.
+//
+// By default, the sum of squared deviation is dropped.
+func WithPreserveSumOfSquaredDeviation(key string) Option {
+	return optionFunc(func(conf config) config {
+		conf.sumOfSquaredDeviationKey = key
+		return conf
+	})
+}
+
+// WithDescriptionFallback sets the description of converted metrics whose
+// OpenCensus description is empty to the one returned by fallback, which is
+// called with the name of the metric, without any prefix, e.g. to look up
+// descriptions in a catalog. Non-empty descriptions are not changed. Fallback
+// descriptions are limited by WithMaxDescriptionLength.
+//
+// By default, empty descriptions are kept empty.
+func WithDescriptionFallback(fallback func(name string) string) Option {
+	return optionFunc(func(conf config) config {
+		conf.descriptionFallback = fallback
+		return conf
+	})
+}
+
+// WithSemConvValidation validates the names and attribute keys of converted
+// metrics against registry, e.g. to audit which bridged metrics do not follow
+// semantic conventions. An error is returned for each metric name, and each
+// attribute key of a metric, that is not valid in registry. Metrics are
+// converted regardless. Names are validated including any prefix set with
+// WithMetricNamePrefix, and keys after mapping.
+//
+// By default, names and keys are not validated.
+func WithSemConvValidation(registry SemConvRegistry) Option {
+	return optionFunc(func(conf config) config {
+		conf.semConvRegistry = registry
+		return conf
+	})
+}
+
+// WithPointFilter converts only the data points for which filter returns
+// true, e.g. to drop data points older than a retention window. filter is
+// called with the name of the metric, without any prefix, and the attributes
+// and time of each data point of sums, gauges, and histograms. The number of
+// excluded data points is reported by Stats.
+//
+// By default, all data points are converted.
+func WithPointFilter(filter func(metricName string, attrs attribute.Set, t time.Time) bool) Option {
+	return optionFunc(func(conf config) config {
+		conf.pointFilter = filter
+		return conf
+	})
+}
+
+// WithAttributeValueMapper maps the values of converted attributes with
+// mapper, e.g. to lowercase enumerated values or trim whitespace. mapper is
+// called with the key, after any key mapping, and the value, after it is
+// converted to the type configured for its label, of each attribute, and may
+// return a value of a different type.
+//
+// By default, attribute values are not mapped.
+func WithAttributeValueMapper(mapper func(key attribute.Key, v attribute.Value) attribute.Value) Option {
+	return optionFunc(func(conf config) config {
+		conf.attributeValueMapper = mapper
+		return conf
+	})
+}
+
+// WithCounterResetSplit splits cumulative sum time series where their value
+// decreases between data points of the same conversion, which happens when a
+// counter is reset without a new start time. The data point the value
+// decreased at, and the later data points of the time series, have their
+// start time set to the time of that data point, starting a new counter
+// epoch so that rates are computed correctly. Unlike WithDetectResets and
+// WithResetHandling, this only detects resets within a conversion.
+//
+// By default, the start times of sum data points are not changed.
+func WithCounterResetSplit() Option {
+	return optionFunc(func(conf config) config {
+		conf.counterResetSplit = true
+		return conf
+	})
+}
+
+// WithHistogramSumRounding rounds the sums of converted histogram data points
+// to decimals decimal places, e.g. to display a sum of 99.99999999997 as 100
+// with decimals 2. Counts and bucket counts are not changed. A negative
+// decimals disables rounding.
+//
+// By default, sums are not rounded.
+func WithHistogramSumRounding(decimals int) Option {
+	return optionFunc(func(conf config) config {
+		conf.sumDecimals = decimals
+		return conf
+	})
+}
+
+// WithSkipEmptyMetrics drops metrics without any data points, i.e. with no
+// time series or only time series without points, instead of converting them
+// to metrics with no data points, which some exporters reject. The number of
+// dropped metrics is reported by Stats.
+//
+// By default, metrics without data points are converted.
+func WithSkipEmptyMetrics() Option {
+	return optionFunc(func(conf config) config {
+		conf.skipEmptyMetrics = true
+		return conf
+	})
+}
+
+// InstrumentNameValidation determines how metrics whose names are not valid
+// OpenTelemetry instrument names are converted.
+type InstrumentNameValidation int
+
+const (
+	// InstrumentNameOff converts metrics with invalid names unchanged.
+	InstrumentNameOff InstrumentNameValidation = iota
+	// InstrumentNameStrict drops metrics with invalid names.
+	InstrumentNameStrict
+	// InstrumentNameSanitize rewrites invalid names to valid ones.
+	InstrumentNameSanitize
+)
+
+// WithInstrumentNameValidation converts metrics whose names, including the
+// metric name prefix, are not valid OpenTelemetry instrument names according
+// to validation, so that they are accepted by SDKs and exporters that enforce
+// the naming rules. A valid instrument name starts with a letter, contains at
+// most 255 characters, and only contains alphanumeric characters, '_', '.',
+// '-', and '/'.
+//
+// With InstrumentNameStrict, metrics with invalid names are dropped and an
+// error is returned. With InstrumentNameSanitize, invalid characters are
+// replaced with '_', "oc_" is prepended to names that do not start with a
+// letter, and names are truncated to 255 characters. If sanitizing makes the
+// names of two metrics of the same conversion equal, the later metric is
+// dropped and an error is returned.
+//
+// By default, names are not validated.
+func WithInstrumentNameValidation(validation InstrumentNameValidation) Option {
+	return optionFunc(func(conf config) config {
+		conf.instrumentNameValidation = validation
+		return conf
+	})
+}
+
+// WithKeyValueBufferPool converts the attributes of each time series in
+// buffers drawn from pool, instead of allocating a new buffer for each, to
+// reduce allocations of high-throughput conversions. A buffer is returned to
+// pool once the attribute set is built from it, as attribute.NewSet copies
+// the attributes, so buffers are never retained by converted metrics. The
+// pool may be shared by Converters used concurrently.
+//
+// pool holds *[]attribute.KeyValue values, and its New function, if set,
+// must return one. A pool that is empty, or returns any other value,
+// results in a new buffer.
+//
+// By default, a new buffer is allocated for the attributes of each time
+// series.
+func WithKeyValueBufferPool(pool *sync.Pool) Option {
+	return optionFunc(func(conf config) config {
+		conf.keyValueBufferPool = pool
+		return conf
+	})
+}
+
+// WithResourceAttributeTypes converts the values of the OpenCensus resource
+// labels with the keys of types to resource attributes of the type of the
+// key: attribute.INT64, attribute.FLOAT64, or attribute.BOOL, e.g.
+// "host.cpu.count" to an int64 attribute, as OpenTelemetry resources carry
+// typed attributes. Values that cannot be parsed as that type, and labels
+// with other types, are converted to string attributes. Resources are
+// converted by ConvertResourceMetrics.
+//
+// By default, all resource labels are converted to string attributes.
+func WithResourceAttributeTypes(types map[string]attribute.Type) Option {
+	return optionFunc(func(conf config) config {
+		if conf.resourceAttributeTypes == nil {
+			conf.resourceAttributeTypes = make(map[string]attribute.Type, len(types))
+		}
+		for k, t := range types {
+			conf.resourceAttributeTypes[k] = t
+		}
+		return conf
+	})
+}
+
+// IncompatibleTypeHandling determines how metrics with the name of a metric
+// of an incompatible type are converted.
+type IncompatibleTypeHandling int
+
+const (
+	// IncompatibleTypeError drops the metrics with the name of an earlier
+	// metric of another type and returns an error.
+	IncompatibleTypeError IncompatibleTypeHandling = iota
+	// IncompatibleTypeFirst drops the metrics with the name of an earlier
+	// metric of another type, keeping the type first seen.
+	IncompatibleTypeFirst
+	// IncompatibleTypeRename appends the kind of aggregation of the metrics
+	// with the name of an earlier metric of another type to their name,
+	// e.g. "_histogram". The type of their values is appended as well if the
+	// earlier metric has the same kind of aggregation, e.g. "_sum_float64".
+	IncompatibleTypeRename
+)
+
+// WithIncompatibleTypeHandling converts metrics of a conversion that have
+// the same name, including the metric name prefix, but are converted to
+// aggregations of different types, e.g. a gauge and a histogram, according
+// to handling. OpenTelemetry SDKs and exporters reject such conflicting
+// metrics.
+//
+// By default, metrics with the name of an earlier metric of another type are
+// dropped and an error is returned.
+func WithIncompatibleTypeHandling(handling IncompatibleTypeHandling) Option {
+	return optionFunc(func(conf config) config {
+		conf.incompatibleTypeHandling = handling
+		return conf
+	})
+}
+
+// WithEnrichmentOverride gives the enrichment attributes of
+// ConvertMetricsWithContext precedence over the attributes converted from
+// the labels of data points with the same key, e.g. to enforce the tenant of
+// the converting context.
+//
+// By default, the attributes converted from labels take precedence.
+func WithEnrichmentOverride() Option {
+	return optionFunc(func(conf config) config {
+		conf.enrichmentOverride = true
+		return conf
+	})
+}
+
+// WithUnitOverride replaces the units of the converted metrics named in
+// overrides, without any prefix, with the unit they are mapped to, e.g. to
+// correct wrong or missing units without changing their producers. Overrides
+// take precedence over the unit mapper, and the units of other metrics are
+// not changed. Overrides of multiple calls are combined.
+//
+// By default, units are converted unchanged.
+func WithUnitOverride(overrides map[string]string) Option {
+	return optionFunc(func(conf config) config {
+		if conf.unitOverrides == nil {
+			conf.unitOverrides = make(map[string]string, len(overrides))
+		}
+		for name, unit := range overrides {
+			conf.unitOverrides[name] = unit
+		}
+		return conf
+	})
+}
+
+// FloatToIntMode determines how float64 values are converted to int64.
+type FloatToIntMode int
+
+const (
+	// FloatToIntNone does not convert float64 values.
+	FloatToIntNone FloatToIntMode = iota
+	// FloatToIntRound rounds values to the nearest integer, and half away
+	// from zero.
+	FloatToIntRound
+	// FloatToIntFloor rounds values down, towards negative infinity.
+	FloatToIntFloor
+	// FloatToIntTruncate rounds values towards zero.
+	FloatToIntTruncate
+)
+
+// WithFloatGaugeToInt converts OpenCensus float64 gauges to int64 gauges,
+// for backends that only accept integer gauges, rounding their values
+// according to mode. This changes the aggregation of the converted metrics
+// from metricdata.Gauge[float64] to metricdata.Gauge[int64]. An error is
+// returned noting the number of values changed by rounding. Data points
+// with a NaN value, or a value outside of the int64 range, are dropped and
+// an error is returned.
+//
+// By default, float64 gauges are converted to metricdata.Gauge[float64].
+func WithFloatGaugeToInt(mode FloatToIntMode) Option {
+	return optionFunc(func(conf config) config {
+		conf.floatGaugeToInt = mode
+		return conf
+	})
+}
+
+// WithTimestampTruncation truncates the start times and times of converted
+// data points to a multiple of d, e.g. time.Second for backends that only
+// store second-resolution timestamps, where sub-second differences would
+// create spurious distinct points. Truncation rounds times down, so it never
+// reorders the points of a time series. Of the points of a time series that
+// are truncated to the same time, only the later is kept, as it holds the
+// current value of the series. Point filters and timestamp checks apply to the times
+// before truncation, and WithMonotonicTimestamps makes the truncated times
+// distinct instead. Exemplar times are not truncated. A non-positive d
+// disables truncation.
+//
+// By default, times are converted with full precision.
+func WithTimestampTruncation(d time.Duration) Option {
+	return optionFunc(func(conf config) config {
+		conf.timestampResolution = d
+		return conf
+	})
+}
+
+// WithReportNilMetrics returns an error noting the number and indices of
+// the nil metrics skipped by a conversion, which are otherwise only counted
+// by Stats, to surface producers that return nil metrics. The converted
+// metrics are not affected.
+//
+// By default, nil metrics are skipped silently.
+func WithReportNilMetrics() Option {
+	return optionFunc(func(conf config) config {
+		conf.reportNilMetrics = true
+		return conf
+	})
+}
+
+// WithUnsupportedAggregationHandler converts the metrics of OpenCensus types
+// that are not supported, e.g. summaries, with handler instead of dropping
+// them with an error. handler is called with the OpenCensus metric and
+// returns its converted aggregation, which is then processed like those of
+// supported types, e.g. by the view. If handler returns an error, or no
+// aggregation, the metric is dropped and the error is returned.
+// UnsupportedMetrics still reports these metrics.
+//
+// By default, metrics of unsupported types are dropped with an error.
+func WithUnsupportedAggregationHandler(handler func(*ocmetricdata.Metric) (metricdata.Aggregation, error)) Option {
+	return optionFunc(func(conf config) config {
+		conf.unsupportedAggregationHandler = handler
+		return conf
+	})
+}
+
+// WithMonotonicCorrection makes the values of cumulative sums non-decreasing
+// within a conversion, for backends that strictly validate the monotonicity
+// of counters. Each data point with a lower value than the previous data
+// point of its time series with the same start time is given the value of
+// that data point, e.g. values 5, 3, and 8 are converted as 5, 5, and 8, and
+// an error is returned noting the number of raised values. Data points with
+// a new start time, e.g. after WithCounterResetSplit, are not raised.
+//
+// By default, the values of sums are converted unchanged.
+func WithMonotonicCorrection() Option {
+	return optionFunc(func(conf config) config {
+		conf.monotonicCorrection = true
+		return conf
+	})
+}
+
+// WithResourceGroupingKeys groups the metrics converted with
+// ConvertResourceMetrics by the resource attributes with one of keys, e.g.
+// only "service.name", instead of by their whole resource, to control the
+// number of distinct resources emitted. Metrics whose resources have the
+// same values for keys, or both lack them, are grouped together under the
+// resource of the first of them.
+//
+// This loses information: the resource attributes of the other metrics of a
+// group that are not grouping keys are discarded, so their metrics are
+// attributed to the other resource attributes of the first metric. Keys of
+// multiple calls are combined.
+//
+// By default, metrics are grouped by resource equality.
+func WithResourceGroupingKeys(keys ...string) Option {
+	return optionFunc(func(conf config) config {
+		if conf.resourceGroupingKeys == nil {
+			conf.resourceGroupingKeys = make(map[attribute.Key]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			conf.resourceGroupingKeys[attribute.Key(k)] = struct{}{}
+		}
+		return conf
+	})
+}
+
+// WithPointTimeFallback replaces the zero times of OpenCensus points, to
+// recover usable times from producers that only set the start time of time
+// series. The time of a point is, in order of precedence:
+//
+//  1. the time of the point, if it is not zero
+//  2. the start time of its time series, if it is not zero
+//  3. the current time
+//
+// The replaced time is used by the timestamp checks and point filters.
+//
+// By default, zero times are converted unchanged.
+func WithPointTimeFallback() Option {
+	return optionFunc(func(conf config) config {
+		conf.pointTimeFallback = true
+		return conf
+	})
+}
+
+// WithScopePerMetric includes each metric converted with
+// ConvertResourceMetrics in its own scope, named after the metric, for
+// backends that key dashboards on the instrumentation scope. The scopes have
+// the version and schema URL of the scope passed to ConvertResourceMetrics.
+// Metrics with the same name and resource share a scope.
+//
+// This creates as many scopes as there are metrics, which increases the size
+// of exports and the cardinality of the scope for backends that index it.
+//
+// By default, all metrics are included in the scope passed to
+// ConvertResourceMetrics.
+func WithScopePerMetric() Option {
+	return optionFunc(func(conf config) config {
+		conf.scopePerMetric = true
+		return conf
+	})
+}
+
+// ValueTypeMismatchHandling determines how OpenCensus points with a value of
+// another type than that of their metric are converted.
+type ValueTypeMismatchHandling int
+
+const (
+	// ValueTypeMismatchError drops the metric and returns an error.
+	ValueTypeMismatchError ValueTypeMismatchHandling = iota
+	// ValueTypeMismatchDrop drops the point, converts the rest of the
+	// metric, and returns an error.
+	ValueTypeMismatchDrop
+	// ValueTypeMismatchCoerce converts int64 values of float64 metrics to
+	// float64, and float64 values of int64 metrics to int64. Other points
+	// are dropped like with ValueTypeMismatchDrop.
+	ValueTypeMismatchCoerce
+)
+
+// WithValueTypeMismatchHandling converts OpenCensus points with a value of
+// another type than that of their metric, e.g. a float64 point of an int64
+// gauge, according to handling. With ValueTypeMismatchCoerce, float64 values
+// are rounded according to the mode of WithFloatGaugeToInt, or to the
+// nearest integer without it, and an error is returned for values that are
+// changed by the conversion. Values that cannot be converted, e.g. NaN, are
+// dropped. Distributions, and the points of distribution metrics, are never
+// converted.
+//
+// By default, metrics with points of another value type are dropped and an
+// error is returned.
+func WithValueTypeMismatchHandling(handling ValueTypeMismatchHandling) Option {
+	return optionFunc(func(conf config) config {
+		conf.valueTypeMismatch = handling
+		return conf
+	})
+}
+
+// WithAttributeCache caches the attribute sets converted from the labels of
+// OpenCensus time series, keyed by metric name and label values, so that
+// converting the same time series again does not build its attribute set
+// again. Up to 1024 attribute sets are cached, after which the least
+// recently used ones are evicted; use WithAttributeCacheSize to change this
+// limit. The cache is kept across conversions by the same Converter, and
+// discarded by Reset. Attribute sets whose conversion returned an error are
+// not cached.
+//
+// By default, attribute sets are not cached.
+func WithAttributeCache() Option {
+	return WithAttributeCacheSize(defaultAttributeCacheSize)
+}
+
+// WithAttributeCacheSize caches converted attribute sets like
+// WithAttributeCache, keeping at most n attribute sets. A non-positive n
+// disables the cache.
+//
+// By default, attribute sets are not cached.
+func WithAttributeCacheSize(n int) Option {
+	return optionFunc(func(conf config) config {
+		conf.attributeCacheSize = n
+		return conf
+	})
+}
+
+// WithErrorPrefix wraps the error returned by a conversion with prefix, e.g.
+// to attribute the errors of one of several Converters in logs. The returned
+// error reads "<prefix>: <errors>".
+//
+// By default, the prefix is "error converting from OpenCensus to
+// OpenTelemetry".
+func WithErrorPrefix(prefix string) Option {
+	return optionFunc(func(conf config) config {
+		conf.errorPrefix = prefix
+		return conf
+	})
+}
+
+// WithSuccessHandler calls handler with each converted metric, as soon as it
+// is converted, e.g. to stream the converted metrics into a pipeline or
+// index them incrementally. handler is not called for dropped metrics, and
+// the metrics returned by the conversion are unchanged. handler is called
+// synchronously, in the order the metrics are converted.
+//
+// By default, no handler is called.
+func WithSuccessHandler(handler func(m metricdata.Metrics)) Option {
+	return optionFunc(func(conf config) config {
+		conf.successHandler = handler
+		return conf
+	})
+}
+
+// WithGlobalResource merges res under the resource of each metric converted
+// with ConvertResourceMetrics, like the process-global resource of
+// OpenCensus: the attributes of the resource of a metric, or of the fallback
+// resource if it has none, take precedence over those of res with the same
+// key. The schema URL of the resource of the metric is kept.
+//
+// By default, no global resource is merged.
+func WithGlobalResource(res *resource.Resource) Option {
+	return optionFunc(func(conf config) config {
+		conf.globalResource = res
+		return conf
+	})
+}
+
+// WithValidateExemplarBounds drops the exemplars of OpenCensus distribution
+// buckets whose value is outside of the [lower, upper) bounds of their
+// bucket, which indicates a producer bug, and returns an error for each.
+// Exemplars returned by the resolver of WithExemplarResolver are not
+// attached to a bucket, so they are not checked.
+//
+// By default, exemplars are converted without checking their bucket bounds.
+func WithValidateExemplarBounds() Option {
+	return optionFunc(func(conf config) config {
+		conf.validateExemplarBounds = true
+		return conf
+	})
+}
+
+// WithMaxSeriesAge drops data points with a time more than d before the time
+// of the conversion, e.g. the points of stale time series that long-lived producers
+// never retire. The dropped points are counted in Stats.AgedOutPoints. A
+// non-positive d keeps all points.
+//
+// By default, data points are converted regardless of their age.
+func WithMaxSeriesAge(d time.Duration) Option {
+	return optionFunc(func(conf config) config {
+		conf.maxSeriesAge = d
+		return conf
+	})
+}
+
+// WithLogger logs the issues of conversions with logger as they happen, in
+// addition to returning them: each dropped metric is logged at the info
+// level, l.V(4), and each warning at the debug level, l.V(8), as the
+// OpenTelemetry SDK does. The logged errors are not limited by
+// WithMaxErrors.
+//
+// By default, nothing is logged.
+func WithLogger(l logr.Logger) Option {
+	return optionFunc(func(conf config) config {
+		conf.logger = l
+		return conf
+	})
+}
+
+// AttributeKeyValidation determines how attributes whose keys are not valid
+// are converted.
+type AttributeKeyValidation int
+
+const (
+	// AttributeKeyWarn converts attributes with invalid keys unchanged, and
+	// returns an error.
+	AttributeKeyWarn AttributeKeyValidation = iota
+	// AttributeKeyDrop drops attributes with invalid keys, and returns an
+	// error.
+	AttributeKeyDrop
+	// AttributeKeySanitize rewrites invalid keys to valid ones, and returns
+	// an error. Attributes with an empty key are dropped.
+	AttributeKeySanitize
+)
+
+// WithValidateAttributeKeys converts the attributes of data points whose
+// keys, after mapping, are not valid according to validation, to enforce
+// attribute hygiene at the bridge. A valid key is not empty and only
+// contains alphanumeric characters, '_', '.', and '-', as in the dotted
+// namespaces of the semantic conventions. With AttributeKeySanitize, invalid
+// characters are replaced with '_'. An error is returned for each invalid
+// key.
+//
+// By default, attributes with invalid keys are converted unchanged, and an
+// error is returned.
+func WithValidateAttributeKeys(validation AttributeKeyValidation) Option {
+	return optionFunc(func(conf config) config {
+		conf.attributeKeyValidation = validation
+		return conf
+	})
+}
+
+// WithDeduplicateDescriptors merges OpenCensus metrics with identical
+// descriptors, e.g. the same metric registered by two producers, into a
+// single metric with the time series of all of them, converted in place of
+// the first of them. Descriptors are identical if their name, description,
+// unit, type, and label keys, including the descriptions of the label keys,
+// are equal. Metrics with different resources are not merged. Metrics whose
+// descriptors differ in any way are converted separately.
+//
+// By default, each OpenCensus metric is converted to a separate metric.
+func WithDeduplicateDescriptors() Option {
+	return optionFunc(func(conf config) config {
+		conf.deduplicateDescriptors = true
+		return conf
+	})
+}
+
+// WithSourceLibrary includes each metric converted with
+// ConvertResourceMetrics in the scope of the OpenCensus library that
+// produced it, e.g. "ocgrpc" for "grpc.io/client/roundtrip_latency", as
+// returned by library for the OpenCensus name of the metric. The scopes have
+// the version and schema URL of the scope passed to ConvertResourceMetrics.
+// Metrics for which library returns an empty name are included in the scope
+// passed to ConvertResourceMetrics, or in their own scope with
+// WithScopePerMetric.
+//
+// By default, all metrics are included in the scope passed to
+// ConvertResourceMetrics.
+func WithSourceLibrary(library func(metricName string) string) Option {
+	return optionFunc(func(conf config) config {
+		conf.sourceLibrary = library
+		return conf
+	})
+}
+
+// EmptyBucketHistogramHandling determines how OpenCensus distributions with a
+// positive count but only empty buckets are converted.
+type EmptyBucketHistogramHandling int
+
+const (
+	// EmptyBucketHistogramError drops the metric and returns an error.
+	EmptyBucketHistogramError EmptyBucketHistogramHandling = iota
+	// EmptyBucketHistogramDrop drops the data point, and converts the rest
+	// of the metric.
+	EmptyBucketHistogramDrop
+	// EmptyBucketHistogramPlaceInOverflow converts the data point with its
+	// count in the overflow bucket, i.e. the last bucket, whose upper bound
+	// is +Inf.
+	EmptyBucketHistogramPlaceInOverflow
+)
+
+// WithEmptyBucketHistogramHandling converts OpenCensus distributions with a
+// positive count but only empty buckets according to handling. Such
+// distributions are inconsistent, as their count is in no bucket, which
+// indicates a producer bug. Distributions without buckets are not affected.
+//
+// By default, metrics with such distributions are dropped and an error is
+// returned.
+func WithEmptyBucketHistogramHandling(handling EmptyBucketHistogramHandling) Option {
+	return optionFunc(func(conf config) config {
+		conf.emptyBucketHistogramHandling = handling
+		return conf
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Change is the kind of difference between two converted metrics.
+type Change int
+
+const (
+	// MetricAdded is a metric only present in the second batch.
+	MetricAdded Change = iota
+	// MetricRemoved is a metric only present in the first batch.
+	MetricRemoved
+	// AggregationChanged is a metric with a different aggregation type.
+	AggregationChanged
+	// UnitChanged is a metric with a different unit.
+	UnitChanged
+	// DescriptionChanged is a metric with a different description.
+	DescriptionChanged
+	// AttributesChanged is a metric whose data points have different
+	// attribute sets.
+	AttributesChanged
+)
+
+// MetricDiff is a structural difference between two converted metrics with
+// the same name.
+type MetricDiff struct {
+	// Name is the name of the metric.
+	Name string
+	// Change is the kind of difference.
+	Change Change
+	// Old and New are the aggregation types, units, or descriptions of the
+	// metric in the first and second batch, for the corresponding changes.
+	Old, New string
+	// AddedAttributes and RemovedAttributes are the attribute sets of the
+	// data points only present in the second and first batch, for
+	// AttributesChanged.
+	AddedAttributes, RemovedAttributes []attribute.Set
+}
+
+// String returns a human-readable description of d.
+func (d MetricDiff) String() string {
+	switch d.Change {
+	case MetricAdded:
+		return fmt.Sprintf("%s: metric added", d.Name)
+	case MetricRemoved:
+		return fmt.Sprintf("%s: metric removed", d.Name)
+	case AggregationChanged:
+		return fmt.Sprintf("%s: aggregation changed from %s to %s", d.Name, d.Old, d.New)
+	case UnitChanged:
+		return fmt.Sprintf("%s: unit changed from %q to %q", d.Name, d.Old, d.New)
+	case DescriptionChanged:
+		return fmt.Sprintf("%s: description changed from %q to %q", d.Name, d.Old, d.New)
+	case AttributesChanged:
+		return fmt.Sprintf("%s: attribute sets added [%s], removed [%s]", d.Name, encodeSets(d.AddedAttributes), encodeSets(d.RemovedAttributes))
+	}
+	return fmt.Sprintf("%s: unknown change %d", d.Name, d.Change)
+}
+
+// encodeSets returns the attribute sets encoded and separated by "; ".
+func encodeSets(sets []attribute.Set) string {
+	encoded := make([]string, len(sets))
+	for i, s := range sets {
+		encoded[i] = "{" + s.Encoded(attribute.DefaultEncoder()) + "}"
+	}
+	return strings.Join(encoded, "; ")
+}
+
+// DiffMetrics returns the structural differences between the converted
+// metrics a and b, e.g. to validate that upgrading an OpenCensus producer did
+// not change the shape of its metrics. Metrics are matched by name. Added and
+// removed metrics, and changed aggregation types, units, descriptions, and
+// data point attribute sets are reported. Data point values are not compared.
+//
+// Differences are in the order of a, followed by the metrics only in b in
+// their order. If a batch has metrics with the same name, only the first is
+// compared.
+func DiffMetrics(a, b []metricdata.Metrics) []MetricDiff {
+	bByName := make(map[string]metricdata.Metrics, len(b))
+	for _, m := range b {
+		if _, ok := bByName[m.Name]; !ok {
+			bByName[m.Name] = m
+		}
+	}
+	var diffs []MetricDiff
+	seen := make(map[string]struct{}, len(a))
+	for _, old := range a {
+		if _, ok := seen[old.Name]; ok {
+			continue
+		}
+		seen[old.Name] = struct{}{}
+		m, ok := bByName[old.Name]
+		if !ok {
+			diffs = append(diffs, MetricDiff{Name: old.Name, Change: MetricRemoved})
+			continue
+		}
+		diffs = append(diffs, diffMetric(old, m)...)
+	}
+	for _, m := range b {
+		if _, ok := seen[m.Name]; ok {
+			continue
+		}
+		seen[m.Name] = struct{}{}
+		diffs = append(diffs, MetricDiff{Name: m.Name, Change: MetricAdded})
+	}
+	return diffs
+}
+
+// diffMetric returns the structural differences between a and b, metrics
+// with the same name.
+func diffMetric(a, b metricdata.Metrics) []MetricDiff {
+	var diffs []MetricDiff
+	if oldType, newType := fmt.Sprintf("%T", a.Data), fmt.Sprintf("%T", b.Data); oldType != newType {
+		diffs = append(diffs, MetricDiff{Name: a.Name, Change: AggregationChanged, Old: oldType, New: newType})
+	}
+	if a.Unit != b.Unit {
+		diffs = append(diffs, MetricDiff{Name: a.Name, Change: UnitChanged, Old: a.Unit, New: b.Unit})
+	}
+	if a.Description != b.Description {
+		diffs = append(diffs, MetricDiff{Name: a.Name, Change: DescriptionChanged, Old: a.Description, New: b.Description})
+	}
+	oldSets, newSets := attributeSets(a.Data), attributeSets(b.Data)
+	added, removed := missingSets(newSets, oldSets), missingSets(oldSets, newSets)
+	if len(added) > 0 || len(removed) > 0 {
+		diffs = append(diffs, MetricDiff{Name: a.Name, Change: AttributesChanged, AddedAttributes: added, RemovedAttributes: removed})
+	}
+	return diffs
+}
+
+// missingSets returns the attribute sets of sets that are not in other.
+func missingSets(sets, other []attribute.Set) []attribute.Set {
+	index := make(map[attribute.Distinct]struct{}, len(other))
+	for _, s := range other {
+		index[s.Equivalent()] = struct{}{}
+	}
+	var missing []attribute.Set
+	for _, s := range sets {
+		if _, ok := index[s.Equivalent()]; !ok {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// attributeSets returns the distinct attribute sets of the data points of
+// agg, in order of first appearance.
+func attributeSets(agg metricdata.Aggregation) []attribute.Set {
+	var sets []attribute.Set
+	seen := make(map[attribute.Distinct]struct{})
+	eachAttributeSet(agg, func(s attribute.Set) {
+		if _, ok := seen[s.Equivalent()]; !ok {
+			seen[s.Equivalent()] = struct{}{}
+			sets = append(sets, s)
+		}
+	})
+	return sets
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDiffMetrics(t *testing.T) {
+	setA := attribute.NewSet(attribute.String("a", "1"))
+	setB := attribute.NewSet(attribute.String("a", "2"))
+	gauge := func(value int64, sets ...attribute.Set) metricdata.Gauge[int64] {
+		points := make([]metricdata.DataPoint[int64], len(sets))
+		for i, s := range sets {
+			points[i] = metricdata.DataPoint[int64]{Attributes: s, Value: value}
+		}
+		return metricdata.Gauge[int64]{DataPoints: points}
+	}
+	for _, tc := range []struct {
+		desc     string
+		a, b     []metricdata.Metrics
+		expected []string
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "values are not compared",
+			a:    []metricdata.Metrics{{Name: "foo.com/gauge-a", Data: gauge(1, setA)}},
+			b:    []metricdata.Metrics{{Name: "foo.com/gauge-a", Data: gauge(2, setA, setA)}},
+		},
+		{
+			desc: "metrics added and removed",
+			a: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Data: gauge(1)},
+				{Name: "foo.com/gauge-b", Data: gauge(1)},
+			},
+			b: []metricdata.Metrics{
+				{Name: "foo.com/gauge-c", Data: gauge(1)},
+				{Name: "foo.com/gauge-a", Data: gauge(1)},
+			},
+			expected: []string{
+				"foo.com/gauge-b: metric removed",
+				"foo.com/gauge-c: metric added",
+			},
+		},
+		{
+			desc: "metadata changed",
+			a: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Description: "old", Unit: "ms", Data: gauge(1)},
+			},
+			b: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Description: "new", Unit: "s", Data: metricdata.Sum[int64]{}},
+			},
+			expected: []string{
+				"foo.com/gauge-a: aggregation changed from metricdata.Gauge[int64] to metricdata.Sum[int64]",
+				`foo.com/gauge-a: unit changed from "ms" to "s"`,
+				`foo.com/gauge-a: description changed from "old" to "new"`,
+			},
+		},
+		{
+			desc: "attribute sets changed",
+			a:    []metricdata.Metrics{{Name: "foo.com/gauge-a", Data: gauge(1, setA, *attribute.EmptySet())}},
+			b:    []metricdata.Metrics{{Name: "foo.com/gauge-a", Data: gauge(1, setB, *attribute.EmptySet())}},
+			expected: []string{
+				"foo.com/gauge-a: attribute sets added [{a=2}], removed [{a=1}]",
+			},
+		},
+		{
+			desc: "duplicate names",
+			a: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Unit: "ms", Data: gauge(1)},
+				{Name: "foo.com/gauge-a", Unit: "s", Data: gauge(1)},
+			},
+			b: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Unit: "ms", Data: gauge(1)},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var diffs []string
+			for _, d := range DiffMetrics(tc.a, tc.b) {
+				diffs = append(diffs, d.String())
+			}
+			assert.Equal(t, tc.expected, diffs)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errIncompatibleDuplicate = errors.New("metric has the name of a metric of an incompatible type")
+
+// aggregationKind describes the type of a converted aggregation.
+type aggregationKind struct {
+	// kind is the kind of aggregation, e.g. "Sum".
+	kind string
+	// number is the type of the values of the aggregation, e.g. "int64".
+	number string
+}
+
+func (k aggregationKind) String() string {
+	return k.kind + "[" + k.number + "]"
+}
+
+// kindOf returns the kind of agg.
+func kindOf(agg metricdata.Aggregation) aggregationKind {
+	switch agg.(type) {
+	case metricdata.Gauge[int64]:
+		return aggregationKind{kind: "Gauge", number: "int64"}
+	case metricdata.Gauge[float64]:
+		return aggregationKind{kind: "Gauge", number: "float64"}
+	case metricdata.Sum[int64]:
+		return aggregationKind{kind: "Sum", number: "int64"}
+	case metricdata.Sum[float64]:
+		return aggregationKind{kind: "Sum", number: "float64"}
+	case metricdata.Histogram[int64]:
+		return aggregationKind{kind: "Histogram", number: "int64"}
+	case metricdata.Histogram[float64]:
+		return aggregationKind{kind: "Histogram", number: "float64"}
+	}
+	return aggregationKind{kind: fmt.Sprintf("%T", agg)}
+}
+
+// checkMetricType returns the name the current metric, of full name name and
+// aggregation agg, is converted with, according to the incompatible type
+// handling. An error is returned if the metric is dropped because a metric
+// of the conversion with the same name has an aggregation of another type.
+func (c *Converter) checkMetricType(name string, agg metricdata.Aggregation) (string, error) {
+	kind := kindOf(agg)
+	prev, ok := c.metricKinds[name]
+	if !ok || prev == kind {
+		c.metricKinds[name] = kind
+		return name, nil
+	}
+	if c.cfg.incompatibleTypeHandling != IncompatibleTypeRename {
+		return "", fmt.Errorf("%w: %q converted as %s, previously %s", errIncompatibleDuplicate, name, kind, prev)
+	}
+	// The number type only distinguishes aggregations of the same kind.
+	suffix := "_" + strings.ToLower(kind.kind)
+	if prev.kind == kind.kind {
+		suffix += "_" + kind.number
+	}
+	c.metricKinds[name+suffix] = kind
+	return name + suffix, nil
+}
+
+// deduplicateDescriptors returns the metrics of ocmetrics merged with the
+// metrics of identical descriptors and resources that follow them, by index.
+// The merged metric of a descriptor has the time series of all its metrics,
+// in order, and replaces the first of them. The others are mapped to nil, as
+// they are merged. Metrics without duplicates are not included. ocmetrics is
+// not modified.
+func (c *Converter) deduplicateDescriptors(ocmetrics []*ocmetricdata.Metric) map[int]*ocmetricdata.Metric {
+	if !c.cfg.deduplicateDescriptors {
+		return nil
+	}
+	first := make(map[string]int)
+	merged := make(map[int]*ocmetricdata.Metric)
+	for i, ocm := range ocmetrics {
+		if ocm == nil {
+			continue
+		}
+		key := descriptorKey(ocm.Descriptor, ocm.Resource)
+		j, ok := first[key]
+		if !ok {
+			first[key] = i
+			continue
+		}
+		m, ok := merged[j]
+		if !ok {
+			m = &ocmetricdata.Metric{
+				Descriptor: ocmetrics[j].Descriptor,
+				Resource:   ocmetrics[j].Resource,
+				TimeSeries: append([]*ocmetricdata.TimeSeries(nil), ocmetrics[j].TimeSeries...),
+			}
+			merged[j] = m
+		}
+		m.TimeSeries = append(m.TimeSeries, ocm.TimeSeries...)
+		merged[i] = nil
+	}
+	return merged
+}
+
+// descriptorKey returns a key that identifies the descriptor desc of a
+// metric with resource res. Strings are prefixed with their length so that
+// distinct descriptors have distinct keys.
+func descriptorKey(desc ocmetricdata.Descriptor, res *ocresource.Resource) string {
+	var b strings.Builder
+	write := func(s string) {
+		b.WriteString(strconv.Itoa(len(s)))
+		b.WriteByte(':')
+		b.WriteString(s)
+	}
+	write(desc.Name)
+	write(desc.Description)
+	write(string(desc.Unit))
+	write(strconv.Itoa(int(desc.Type)))
+	write(strconv.Itoa(len(desc.LabelKeys)))
+	for _, k := range desc.LabelKeys {
+		write(k.Key)
+		write(k.Description)
+	}
+	if res == nil {
+		b.WriteByte('-')
+		return b.String()
+	}
+	b.WriteByte('+')
+	write(res.Type)
+	keys := make([]string, 0, len(res.Labels))
+	for k := range res.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		write(k)
+		write(res.Labels[k])
+	}
+	return b.String()
+}
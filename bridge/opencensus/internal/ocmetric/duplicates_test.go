@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestConverterIncompatibleTypeHandling(t *testing.T) {
+	metric := func(name string, ocType ocmetricdata.Type) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: name, Type: ocType},
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		metric("foo.com/a", ocmetricdata.TypeGaugeInt64),
+		metric("foo.com/a", ocmetricdata.TypeCumulativeDistribution),
+		metric("foo.com/a", ocmetricdata.TypeGaugeInt64),
+		metric("foo.com/a", ocmetricdata.TypeGaugeFloat64),
+		metric("foo.com/b", ocmetricdata.TypeCumulativeInt64),
+	}
+	for _, tc := range []struct {
+		desc     string
+		opts     []Option
+		expected []string
+		errCount int
+	}{
+		{
+			desc:     "default",
+			expected: []string{"foo.com/a", "foo.com/a", "foo.com/b"},
+			errCount: 2,
+		},
+		{
+			desc:     "error",
+			opts:     []Option{WithIncompatibleTypeHandling(IncompatibleTypeError)},
+			expected: []string{"foo.com/a", "foo.com/a", "foo.com/b"},
+			errCount: 2,
+		},
+		{
+			desc:     "first",
+			opts:     []Option{WithIncompatibleTypeHandling(IncompatibleTypeFirst)},
+			expected: []string{"foo.com/a", "foo.com/a", "foo.com/b"},
+		},
+		{
+			desc:     "rename",
+			opts:     []Option{WithIncompatibleTypeHandling(IncompatibleTypeRename)},
+			expected: []string{"foo.com/a", "foo.com/a_histogram", "foo.com/a", "foo.com/a_gauge_float64", "foo.com/b"},
+		},
+		{
+			desc:     "prefixed names",
+			opts:     []Option{WithMetricNamePrefix("oc."), WithIncompatibleTypeHandling(IncompatibleTypeRename)},
+			expected: []string{"oc.foo.com/a", "oc.foo.com/a_histogram", "oc.foo.com/a", "oc.foo.com/a_gauge_float64", "oc.foo.com/b"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.opts...)
+			if tc.errCount > 0 {
+				assert.ErrorIs(t, err, errIncompatibleDuplicate)
+				assert.Equal(t, tc.errCount, strings.Count(err.Error(), errIncompatibleDuplicate.Error()))
+			} else {
+				assert.NoError(t, err)
+			}
+			var names []string
+			for _, m := range output {
+				names = append(names, m.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestConverterIncompatibleTypeHandlingPerConversion(t *testing.T) {
+	c := NewConverter()
+	gauge := []*ocmetricdata.Metric{{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/a", Type: ocmetricdata.TypeGaugeInt64}}}
+	sum := []*ocmetricdata.Metric{{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/a", Type: ocmetricdata.TypeCumulativeInt64}}}
+
+	_, err := c.ConvertMetrics(gauge)
+	assert.NoError(t, err)
+	// The types of metrics of previous conversions are not retained.
+	output, err := c.ConvertMetrics(sum)
+	assert.NoError(t, err)
+	assert.IsType(t, metricdata.Sum[int64]{}, output[0].Data)
+}
+
+func TestConverterDeduplicateDescriptors(t *testing.T) {
+	now := time.Now()
+	descriptor := ocmetricdata.Descriptor{
+		Name:        "foo.com/gauge-a",
+		Description: "a gauge",
+		Unit:        ocmetricdata.UnitBytes,
+		Type:        ocmetricdata.TypeGaugeInt64,
+		LabelKeys:   []ocmetricdata.LabelKey{{Key: "producer"}},
+	}
+	metric := func(desc ocmetricdata.Descriptor, producer string, res *ocresource.Resource) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: desc,
+			Resource:   res,
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: producer, Present: true}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+				},
+			},
+		}
+	}
+	with := func(f func(*ocmetricdata.Descriptor)) ocmetricdata.Descriptor {
+		desc := descriptor
+		desc.LabelKeys = append([]ocmetricdata.LabelKey(nil), descriptor.LabelKeys...)
+		f(&desc)
+		return desc
+	}
+	type converted struct {
+		name      string
+		producers []string
+	}
+	for _, tc := range []struct {
+		desc     string
+		input    []*ocmetricdata.Metric
+		expected []converted
+	}{
+		{
+			desc: "identical descriptors",
+			input: []*ocmetricdata.Metric{
+				metric(descriptor, "a", nil),
+				metric(with(func(d *ocmetricdata.Descriptor) { d.Name = "foo.com/gauge-b" }), "b", nil),
+				nil,
+				metric(descriptor, "c", nil),
+				metric(descriptor, "d", nil),
+			},
+			expected: []converted{
+				{name: "foo.com/gauge-a", producers: []string{"a", "c", "d"}},
+				{name: "foo.com/gauge-b", producers: []string{"b"}},
+			},
+		},
+		{
+			desc: "different descriptions",
+			input: []*ocmetricdata.Metric{
+				metric(descriptor, "a", nil),
+				metric(with(func(d *ocmetricdata.Descriptor) { d.Description = "a gauge." }), "b", nil),
+			},
+			expected: []converted{
+				{name: "foo.com/gauge-a", producers: []string{"a"}},
+				{name: "foo.com/gauge-a", producers: []string{"b"}},
+			},
+		},
+		{
+			desc: "different units",
+			input: []*ocmetricdata.Metric{
+				metric(descriptor, "a", nil),
+				metric(with(func(d *ocmetricdata.Descriptor) { d.Unit = ocmetricdata.UnitDimensionless }), "b", nil),
+			},
+			expected: []converted{
+				{name: "foo.com/gauge-a", producers: []string{"a"}},
+				{name: "foo.com/gauge-a", producers: []string{"b"}},
+			},
+		},
+		{
+			desc: "different label key descriptions",
+			input: []*ocmetricdata.Metric{
+				metric(descriptor, "a", nil),
+				metric(with(func(d *ocmetricdata.Descriptor) { d.LabelKeys[0].Description = "producer" }), "b", nil),
+			},
+			expected: []converted{
+				{name: "foo.com/gauge-a", producers: []string{"a"}},
+				{name: "foo.com/gauge-a", producers: []string{"b"}},
+			},
+		},
+		{
+			desc: "different resources",
+			input: []*ocmetricdata.Metric{
+				metric(descriptor, "a", &ocresource.Resource{Type: "host", Labels: map[string]string{"a": "1"}}),
+				metric(descriptor, "b", &ocresource.Resource{Type: "host", Labels: map[string]string{"a": "2"}}),
+				metric(descriptor, "c", &ocresource.Resource{Type: "host", Labels: map[string]string{"a": "1"}}),
+			},
+			expected: []converted{
+				{name: "foo.com/gauge-a", producers: []string{"a", "c"}},
+				{name: "foo.com/gauge-a", producers: []string{"b"}},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			series := make([]int, len(tc.input))
+			var metrics int
+			for i, ocm := range tc.input {
+				if ocm != nil {
+					series[i] = len(ocm.TimeSeries)
+					metrics++
+				}
+			}
+
+			output, err := ConvertMetrics(tc.input, WithDeduplicateDescriptors())
+			require.NoError(t, err)
+			var got []converted
+			for _, m := range output {
+				c := converted{name: m.Name}
+				for _, p := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+					v, _ := p.Attributes.Value("producer")
+					c.producers = append(c.producers, v.AsString())
+				}
+				got = append(got, c)
+			}
+			assert.Equal(t, tc.expected, got)
+			for i, ocm := range tc.input {
+				if ocm != nil {
+					assert.Len(t, ocm.TimeSeries, series[i], "input is not modified")
+				}
+			}
+
+			output, err = ConvertMetrics(tc.input)
+			require.NoError(t, err)
+			assert.Len(t, output, metrics, "not merged by default")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	grpcMetricPrefix = "grpc.io/"
+
+	rpcServiceKey    = attribute.Key("rpc.service")
+	rpcMethodKey     = attribute.Key("rpc.method")
+	rpcStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+)
+
+// grpcStatusCodes are the gRPC status codes by the names the OpenCensus gRPC
+// plugin records them with.
+var grpcStatusCodes = map[string]int64{
+	"OK":                  0,
+	"CANCELLED":           1,
+	"UNKNOWN":             2,
+	"INVALID_ARGUMENT":    3,
+	"DEADLINE_EXCEEDED":   4,
+	"NOT_FOUND":           5,
+	"ALREADY_EXISTS":      6,
+	"PERMISSION_DENIED":   7,
+	"RESOURCE_EXHAUSTED":  8,
+	"FAILED_PRECONDITION": 9,
+	"ABORTED":             10,
+	"OUT_OF_RANGE":        11,
+	"UNIMPLEMENTED":       12,
+	"INTERNAL":            13,
+	"UNAVAILABLE":         14,
+	"DATA_LOSS":           15,
+	"UNAUTHENTICATED":     16,
+}
+
+// ConvertGRPCMetrics converts metric data from OpenCensus to OpenTelemetry
+// like ConvertMetrics, with conversions for the metrics of the OpenCensus
+// gRPC plugin, whose names start with "grpc.io/":
+//
+//   - The grpc_client_method and grpc_server_method labels, of value
+//     "package.Service/Method", are converted to the rpc.service and
+//     rpc.method attributes, split on the last '/'.
+//   - The grpc_client_status and grpc_server_status labels are converted to
+//     the rpc.grpc.status_code attribute, with the numeric status code as
+//     value. Unknown status names are kept as strings.
+//   - The dimensionless units of RPC and message counts are replaced with
+//     the "{call}" and "{message}" UCUM annotations.
+//
+// Other metrics are converted as configured by opts. The gRPC conversions
+// replace the metric scoped key mapper and unit mapper of opts.
+func ConvertGRPCMetrics(ocmetrics []*ocmetricdata.Metric, opts ...Option) ([]metricdata.Metrics, error) {
+	// The full slice expression keeps append from modifying the backing
+	// array of the caller's opts.
+	return ConvertMetrics(ocmetrics, append(opts[:len(opts):len(opts)],
+		WithMetricScopedKeyMapper(grpcKey),
+		WithUnitMapper(grpcUnit),
+		withLabelValueMapper(grpcLabelValue),
+		withLabelSplitter(grpcMethod),
+	)...)
+}
+
+// grpcKey maps the label keys of gRPC metrics to semantic convention
+// attribute keys.
+func grpcKey(metricName string, key attribute.Key) attribute.Key {
+	if !strings.HasPrefix(metricName, grpcMetricPrefix) {
+		return key
+	}
+	switch key {
+	case "grpc_client_status", "grpc_server_status":
+		return rpcStatusCodeKey
+	}
+	return key
+}
+
+// grpcMethod splits the values of the method labels of gRPC metrics, of the
+// form "package.Service/Method", into the service and method attributes.
+// Values without a service are converted to the method attribute only.
+func grpcMethod(metricName, key, value string) []attribute.KeyValue {
+	if !strings.HasPrefix(metricName, grpcMetricPrefix) || key != "grpc_client_method" && key != "grpc_server_method" {
+		return nil
+	}
+	i := strings.LastIndexByte(value, '/')
+	if i < 0 {
+		return []attribute.KeyValue{rpcMethodKey.String(value)}
+	}
+	method := rpcMethodKey.String(value[i+1:])
+	if service := strings.TrimPrefix(value[:i], "/"); service != "" {
+		return []attribute.KeyValue{rpcServiceKey.String(service), method}
+	}
+	return []attribute.KeyValue{method}
+}
+
+// grpcUnit maps the dimensionless units of gRPC count metrics to UCUM
+// annotations.
+func grpcUnit(metricName, unit string) string {
+	if !strings.HasPrefix(metricName, grpcMetricPrefix) || unit != string(ocmetricdata.UnitDimensionless) {
+		return unit
+	}
+	switch {
+	case strings.HasSuffix(metricName, "_rpcs"):
+		return "{call}"
+	case strings.HasSuffix(metricName, "_messages_per_rpc"):
+		return "{message}"
+	}
+	return unit
+}
+
+// grpcLabelValue converts the values of the status labels of gRPC metrics to
+// status codes.
+func grpcLabelValue(metricName, key, value string) (attribute.Value, bool) {
+	if !strings.HasPrefix(metricName, grpcMetricPrefix) || key != "grpc_client_status" && key != "grpc_server_status" {
+		return attribute.Value{}, false
+	}
+	code, ok := grpcStatusCodes[value]
+	if !ok {
+		return attribute.Value{}, false
+	}
+	return attribute.Int64Value(code), true
+}
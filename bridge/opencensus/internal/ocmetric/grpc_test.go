@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConvertGRPCMetrics(t *testing.T) {
+	now := time.Now()
+	sum := func(name string, unit ocmetricdata.Unit, keys []string, values ...string) *ocmetricdata.Metric {
+		labelKeys := make([]ocmetricdata.LabelKey, len(keys))
+		for i, k := range keys {
+			labelKeys[i] = ocmetricdata.LabelKey{Key: k}
+		}
+		labelValues := make([]ocmetricdata.LabelValue, len(values))
+		for i, v := range values {
+			labelValues[i] = ocmetricdata.LabelValue{Value: v, Present: true}
+		}
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Unit:      unit,
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: labelKeys,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				LabelValues: labelValues,
+				Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+				StartTime:   now,
+			}},
+		}
+	}
+	expectedSum := func(name, unit string, attrs ...attribute.KeyValue) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name: name,
+			Unit: unit,
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: attribute.NewSet(attrs...),
+					StartTime:  now,
+					Time:       now,
+					Value:      1,
+				}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		desc     string
+		input    *ocmetricdata.Metric
+		expected metricdata.Metrics
+	}{
+		{
+			desc:  "client RPCs",
+			input: sum("grpc.io/client/completed_rpcs", ocmetricdata.UnitDimensionless, []string{"grpc_client_method", "grpc_client_status"}, "helloworld.Greeter/SayHello", "NOT_FOUND"),
+			expected: expectedSum("grpc.io/client/completed_rpcs", "{call}",
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+				attribute.Int64("rpc.grpc.status_code", 5),
+			),
+		},
+		{
+			desc:  "server messages",
+			input: sum("grpc.io/server/received_messages_per_rpc", ocmetricdata.UnitDimensionless, []string{"grpc_server_method"}, "helloworld.Greeter/SayHello"),
+			expected: expectedSum("grpc.io/server/received_messages_per_rpc", "{message}",
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+			),
+		},
+		{
+			desc:  "server latency",
+			input: sum("grpc.io/server/server_latency", ocmetricdata.UnitMilliseconds, []string{"grpc_server_method", "grpc_server_status"}, "helloworld.Greeter/SayHello", "OK"),
+			expected: expectedSum("grpc.io/server/server_latency", "ms",
+				attribute.String("rpc.service", "helloworld.Greeter"),
+				attribute.String("rpc.method", "SayHello"),
+				attribute.Int64("rpc.grpc.status_code", 0),
+			),
+		},
+		{
+			desc:  "method without service",
+			input: sum("grpc.io/client/completed_rpcs", ocmetricdata.UnitDimensionless, []string{"grpc_client_method"}, "/SayHello"),
+			expected: expectedSum("grpc.io/client/completed_rpcs", "{call}",
+				attribute.String("rpc.method", "SayHello"),
+			),
+		},
+		{
+			desc:  "full method name",
+			input: sum("grpc.io/server/server_latency", ocmetricdata.UnitMilliseconds, []string{"grpc_server_method"}, "/google.pubsub.v1.Publisher/Publish"),
+			expected: expectedSum("grpc.io/server/server_latency", "ms",
+				attribute.String("rpc.service", "google.pubsub.v1.Publisher"),
+				attribute.String("rpc.method", "Publish"),
+			),
+		},
+		{
+			desc:  "unknown status",
+			input: sum("grpc.io/client/completed_rpcs", ocmetricdata.UnitDimensionless, []string{"grpc_client_status"}, "TEAPOT"),
+			expected: expectedSum("grpc.io/client/completed_rpcs", "{call}",
+				attribute.String("rpc.grpc.status_code", "TEAPOT"),
+			),
+		},
+		{
+			desc:  "other metric",
+			input: sum("foo.com/completed_rpcs", ocmetricdata.UnitDimensionless, []string{"grpc_client_method", "grpc_client_status"}, "helloworld.Greeter/SayHello", "OK"),
+			expected: expectedSum("foo.com/completed_rpcs", "1",
+				attribute.String("grpc_client_method", "helloworld.Greeter/SayHello"),
+				attribute.String("grpc_client_status", "OK"),
+			),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertGRPCMetrics([]*ocmetricdata.Metric{tc.input})
+			require.NoError(t, err)
+			require.Len(t, output, 1)
+			metricdatatest.AssertEqual(t, tc.expected, output[0])
+		})
+	}
+}
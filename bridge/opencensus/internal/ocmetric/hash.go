@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// HashMetric returns a 64-bit FNV-1a hash of the structure and values of m,
+// e.g. to skip exporting metrics that did not change since their last
+// export. Equal metrics have equal hashes, and metrics that differ in what is
+// hashed have different hashes with high probability. The hash does not
+// depend on the process, so it can be compared across restarts.
+//
+// The hash covers, in order:
+//
+//   - the name and unit of m
+//   - the kind of aggregation of m, e.g. "Sum[int64]"
+//   - the temporality of sums and histograms, and if sums are monotonic
+//   - for each data point, in order: its attributes, its value for gauges
+//     and sums, and its count, sum, bounds, bucket counts, minimum, and
+//     maximum for histograms
+//
+// The description of m, the start times and times of data points, and
+// exemplars are not hashed. Use HashMetricWithTimestamps to hash the start
+// times and times of data points.
+func HashMetric(m metricdata.Metrics) uint64 {
+	return hashMetric(m, false)
+}
+
+// HashMetricWithTimestamps returns a hash of m like HashMetric that also
+// covers the start time and time of each data point, hashed after its
+// attributes.
+func HashMetricWithTimestamps(m metricdata.Metrics) uint64 {
+	return hashMetric(m, true)
+}
+
+func hashMetric(m metricdata.Metrics, timestamps bool) uint64 {
+	h := metricHasher{Hash64: fnv.New64a(), timestamps: timestamps}
+	h.string(m.Name)
+	h.string(m.Unit)
+	h.string(kindOf(m.Data).String())
+	switch a := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		hashDataPoints(h, a.DataPoints)
+	case metricdata.Gauge[float64]:
+		hashDataPoints(h, a.DataPoints)
+	case metricdata.Sum[int64]:
+		h.uint64(uint64(a.Temporality))
+		h.bool(a.IsMonotonic)
+		hashDataPoints(h, a.DataPoints)
+	case metricdata.Sum[float64]:
+		h.uint64(uint64(a.Temporality))
+		h.bool(a.IsMonotonic)
+		hashDataPoints(h, a.DataPoints)
+	case metricdata.Histogram[int64]:
+		h.uint64(uint64(a.Temporality))
+		hashHistogramPoints(h, a.DataPoints)
+	case metricdata.Histogram[float64]:
+		h.uint64(uint64(a.Temporality))
+		hashHistogramPoints(h, a.DataPoints)
+	}
+	return h.Sum64()
+}
+
+// metricHasher writes the parts of a metric to a hash. Variable length parts
+// are prefixed with their length so that distinct sequences of parts are
+// written as distinct bytes.
+type metricHasher struct {
+	hash.Hash64
+	timestamps bool
+}
+
+func (h metricHasher) uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	_, _ = h.Write(b[:])
+}
+
+func (h metricHasher) float64(v float64) {
+	h.uint64(math.Float64bits(v))
+}
+
+func (h metricHasher) bool(v bool) {
+	if v {
+		h.uint64(1)
+	} else {
+		h.uint64(0)
+	}
+}
+
+func (h metricHasher) string(s string) {
+	h.uint64(uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+// point writes the attributes of a data point, and its start time and time
+// if timestamps are hashed.
+func (h metricHasher) point(attrs attribute.Set, start, t time.Time) {
+	h.uint64(uint64(attrs.Len()))
+	for iter := attrs.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		h.string(string(kv.Key))
+		h.uint64(uint64(kv.Value.Type()))
+		h.string(kv.Value.Emit())
+	}
+	if h.timestamps {
+		h.uint64(uint64(start.UnixNano()))
+		h.uint64(uint64(t.UnixNano()))
+	}
+}
+
+// hashNumber writes v exactly, as int64 values beyond 2^53 are not exactly
+// represented as float64.
+func hashNumber[N int64 | float64](h metricHasher, v N) {
+	switch v := any(v).(type) {
+	case int64:
+		h.uint64(uint64(v))
+	case float64:
+		h.float64(v)
+	}
+}
+
+func hashDataPoints[N int64 | float64](h metricHasher, points []metricdata.DataPoint[N]) {
+	h.uint64(uint64(len(points)))
+	for _, p := range points {
+		h.point(p.Attributes, p.StartTime, p.Time)
+		hashNumber(h, p.Value)
+	}
+}
+
+func hashHistogramPoints[N int64 | float64](h metricHasher, points []metricdata.HistogramDataPoint[N]) {
+	h.uint64(uint64(len(points)))
+	for _, p := range points {
+		h.point(p.Attributes, p.StartTime, p.Time)
+		h.uint64(p.Count)
+		hashNumber(h, p.Sum)
+		h.uint64(uint64(len(p.Bounds)))
+		for _, b := range p.Bounds {
+			h.float64(b)
+		}
+		h.uint64(uint64(len(p.BucketCounts)))
+		for _, n := range p.BucketCounts {
+			h.uint64(n)
+		}
+		for _, e := range []metricdata.Extrema[N]{p.Min, p.Max} {
+			v, defined := e.Value()
+			h.bool(defined)
+			hashNumber(h, v)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHashMetric(t *testing.T) {
+	now := time.Now()
+	attrs := attribute.NewSet(attribute.String("a", "hello"), attribute.Int("b", 1))
+	sum := func(value int64) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name:        "foo.com/sum-a",
+			Description: "a sum",
+			Unit:        "By",
+			Data: metricdata.Sum[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{Attributes: attrs, StartTime: now, Time: now, Value: value},
+				},
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+			},
+		}
+	}
+	histogram := func(f func(*metricdata.HistogramDataPoint[float64])) metricdata.Metrics {
+		p := metricdata.HistogramDataPoint[float64]{
+			Attributes:   attrs,
+			StartTime:    now,
+			Time:         now,
+			Count:        2,
+			Sum:          3,
+			Bounds:       []float64{1, 2},
+			BucketCounts: []uint64{1, 1, 0},
+			Min:          metricdata.NewExtrema(0.5),
+			Max:          metricdata.NewExtrema(2.5),
+		}
+		if f != nil {
+			f(&p)
+		}
+		return metricdata.Metrics{
+			Name: "foo.com/histogram-a",
+			Data: metricdata.Histogram[float64]{
+				DataPoints:  []metricdata.HistogramDataPoint[float64]{p},
+				Temporality: metricdata.CumulativeTemporality,
+			},
+		}
+	}
+	later := func(m metricdata.Metrics) metricdata.Metrics {
+		a := m.Data.(metricdata.Sum[int64])
+		points := append([]metricdata.DataPoint[int64](nil), a.DataPoints...)
+		points[0].Time = now.Add(time.Minute)
+		a.DataPoints = points
+		m.Data = a
+		return m
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		for _, tc := range []struct {
+			desc string
+			a, b metricdata.Metrics
+		}{
+			{desc: "identical", a: sum(1), b: sum(1)},
+			{desc: "same attributes in another order", a: sum(1), b: func() metricdata.Metrics {
+				m := sum(1)
+				a := m.Data.(metricdata.Sum[int64])
+				a.DataPoints[0].Attributes = attribute.NewSet(attribute.Int("b", 1), attribute.String("a", "hello"))
+				return m
+			}()},
+			{desc: "other description", a: sum(1), b: func() metricdata.Metrics {
+				m := sum(1)
+				m.Description = "another description"
+				return m
+			}()},
+			{desc: "other time", a: sum(1), b: later(sum(1))},
+			{desc: "identical histograms", a: histogram(nil), b: histogram(nil)},
+			{desc: "other exemplars", a: histogram(nil), b: histogram(func(p *metricdata.HistogramDataPoint[float64]) {
+				p.Exemplars = []metricdata.Exemplar[float64]{{Value: 1}}
+			})},
+		} {
+			t.Run(tc.desc, func(t *testing.T) {
+				assert.Equal(t, HashMetric(tc.a), HashMetric(tc.b))
+			})
+		}
+	})
+
+	t.Run("different", func(t *testing.T) {
+		renamed := sum(1)
+		renamed.Name = "foo.com/sum-b"
+		unit := sum(1)
+		unit.Unit = "s"
+		attrs := sum(1)
+		attrs.Data.(metricdata.Sum[int64]).DataPoints[0].Attributes = attribute.NewSet(attribute.String("a", "hello"), attribute.String("b", "1"))
+		nonMonotonic := sum(1)
+		s := nonMonotonic.Data.(metricdata.Sum[int64])
+		s.IsMonotonic = false
+		nonMonotonic.Data = s
+		gauge := sum(1)
+		gauge.Data = metricdata.Gauge[int64]{DataPoints: s.DataPoints}
+
+		metrics := []metricdata.Metrics{
+			sum(1),
+			sum(2),
+			sum(1 << 60),
+			sum(1<<60 + 1),
+			renamed,
+			unit,
+			attrs,
+			nonMonotonic,
+			gauge,
+			histogram(nil),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.Count = 3 }),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.Sum = 4 }),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.Bounds = []float64{1, 3} }),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.BucketCounts = []uint64{0, 1, 1} }),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.Min = metricdata.Extrema[float64]{} }),
+			histogram(func(p *metricdata.HistogramDataPoint[float64]) { p.Max = metricdata.NewExtrema(3.0) }),
+		}
+		hashes := make(map[uint64]int)
+		for i, m := range metrics {
+			h := HashMetric(m)
+			if j, ok := hashes[h]; ok {
+				t.Errorf("metrics %d and %d have the same hash", j, i)
+			}
+			hashes[h] = i
+		}
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		assert.Equal(t, HashMetricWithTimestamps(sum(1)), HashMetricWithTimestamps(sum(1)))
+		assert.NotEqual(t, HashMetricWithTimestamps(sum(1)), HashMetricWithTimestamps(later(sum(1))))
+		assert.NotEqual(t, HashMetric(sum(1)), HashMetricWithTimestamps(sum(1)))
+	})
+}
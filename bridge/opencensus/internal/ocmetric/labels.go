@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var errInvalidLabelValue = errors.New("label value cannot be converted, using string")
+
+// convertLabelValue converts value, the value of the OpenCensus label with
+// key, to an attribute value of the type configured for key. If value cannot
+// be converted to that type, a warning is recorded and it is converted to a
+// string.
+func (c *Converter) convertLabelValue(key, value string) attribute.Value {
+	if c.cfg.labelValueMapper != nil {
+		if v, ok := c.cfg.labelValueMapper(c.metricName, key, value); ok {
+			return v
+		}
+	}
+	switch t := c.cfg.labelTypes[key]; t {
+	case attribute.INT64, attribute.FLOAT64, attribute.BOOL:
+		v, err := parseValue(t, value)
+		if err == nil {
+			return v
+		}
+		c.warn(fmt.Errorf("%w: label %q: %w", errInvalidLabelValue, key, err))
+	}
+	if c.interner != nil {
+		value = c.interner.intern(value)
+	}
+	return attribute.StringValue(value)
+}
+
+// parseValue parses value as an attribute value of type t, which is one of
+// attribute.INT64, attribute.FLOAT64, or attribute.BOOL. Booleans are parsed
+// case-insensitively.
+func parseValue(t attribute.Type, value string) (attribute.Value, error) {
+	switch t {
+	case attribute.INT64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return attribute.Value{}, err
+		}
+		return attribute.Int64Value(n), nil
+	case attribute.FLOAT64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return attribute.Value{}, err
+		}
+		return attribute.Float64Value(f), nil
+	case attribute.BOOL:
+		switch {
+		case strings.EqualFold(value, "true"):
+			return attribute.BoolValue(true), nil
+		case strings.EqualFold(value, "false"):
+			return attribute.BoolValue(false), nil
+		}
+		return attribute.Value{}, fmt.Errorf("%q is not a boolean", value)
+	}
+	return attribute.Value{}, fmt.Errorf("unsupported type %s", t)
+}
+
+// interner deduplicates strings so that equal strings share the same backing
+// memory.
+type interner struct {
+	strings sync.Map
+}
+
+// intern returns the interned string equal to s.
+func (i *interner) intern(s string) string {
+	if v, ok := i.strings.Load(s); ok {
+		return v.(string)
+	}
+	// Clone s so the interned string does not retain the memory s may be a
+	// part of.
+	v, _ := i.strings.LoadOrStore(s, strings.Clone(s))
+	return v.(string)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// repeatedLabelValues returns a gauge with n time series whose label values
+// are one of distinct values, each with its own memory.
+func repeatedLabelValues(n, distinct int) []*ocmetricdata.Metric {
+	now := time.Now()
+	ts := make([]*ocmetricdata.TimeSeries, n)
+	for i := range ts {
+		value := fmt.Sprintf("%064d", i%distinct)
+		ts[i] = &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, int64(i))},
+		}
+	}
+	return []*ocmetricdata.Metric{{
+		Descriptor: ocmetricdata.Descriptor{
+			Name:      "foo.com/gauge-a",
+			Type:      ocmetricdata.TypeGaugeInt64,
+			LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+		},
+		TimeSeries: ts,
+	}}
+}
+
+// labelValuePointers returns the distinct backing memory of the label values
+// of the data points of the converted gauge.
+func labelValuePointers(t *testing.T, output []metricdata.Metrics) map[*byte]struct{} {
+	require.Len(t, output, 1)
+	pointers := make(map[*byte]struct{})
+	for _, p := range output[0].Data.(metricdata.Gauge[int64]).DataPoints {
+		v, ok := p.Attributes.Value("a")
+		require.True(t, ok)
+		pointers[unsafe.StringData(v.AsString())] = struct{}{}
+	}
+	return pointers
+}
+
+func TestConverterStringInterner(t *testing.T) {
+	input := repeatedLabelValues(100, 10)
+
+	output, err := ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Len(t, labelValuePointers(t, output), 100)
+
+	c := NewConverter(WithStringInterner())
+	output, err = c.ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Len(t, labelValuePointers(t, output), 10)
+	first := labelValuePointers(t, output)
+
+	// Values are interned across conversions.
+	output, err = c.ConvertMetrics(repeatedLabelValues(100, 10))
+	require.NoError(t, err)
+	assert.Equal(t, first, labelValuePointers(t, output))
+
+	assert.Equal(t, "a", c.interner.intern(strings.Clone("a")))
+}
+
+func BenchmarkStringInterner(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "Disabled"},
+		{name: "Enabled", opts: []Option{WithStringInterner()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var retained int64
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				input := repeatedLabelValues(10000, 10)
+				c := NewConverter(bc.opts...)
+				b.StartTimer()
+				output, _ := c.ConvertMetrics(input)
+				b.StopTimer()
+				// Only the converted metrics are retained, not the OpenCensus
+				// label values.
+				input = nil
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				retained += int64(after.HeapAlloc) - int64(before.HeapAlloc)
+				runtime.KeepAlive(input)
+				runtime.KeepAlive(output)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// MetricManifestEntry describes the shape of a converted metric, without its
+// values and timestamps.
+type MetricManifestEntry struct {
+	// Name is the name of the metric.
+	Name string
+	// Unit is the unit of the metric.
+	Unit string
+	// Aggregation is the kind of aggregation of the metric, e.g. "Sum[int64]".
+	// It is empty for unknown aggregations.
+	Aggregation string
+	// Temporality is the temporality of sums and histograms. It is undefined
+	// for gauges.
+	Temporality metricdata.Temporality
+	// AttributeKeys are the sorted, distinct attribute keys of the data
+	// points of the metric.
+	AttributeKeys []string
+}
+
+// Manifest returns the manifest of metrics: an entry for each metric, sorted
+// by name, then aggregation. The manifest only depends on the shape of the
+// metrics, so it can be compared to a golden manifest to detect changes of
+// the conversion of a fixed input.
+func Manifest(metrics []metricdata.Metrics) []MetricManifestEntry {
+	entries := make([]MetricManifestEntry, 0, len(metrics))
+	for _, m := range metrics {
+		entry := MetricManifestEntry{
+			Name:          m.Name,
+			Unit:          m.Unit,
+			AttributeKeys: []string{},
+		}
+		switch a := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			entry.Aggregation = "Gauge[int64]"
+		case metricdata.Gauge[float64]:
+			entry.Aggregation = "Gauge[float64]"
+		case metricdata.Sum[int64]:
+			entry.Aggregation, entry.Temporality = "Sum[int64]", a.Temporality
+		case metricdata.Sum[float64]:
+			entry.Aggregation, entry.Temporality = "Sum[float64]", a.Temporality
+		case metricdata.Histogram[int64]:
+			entry.Aggregation, entry.Temporality = "Histogram[int64]", a.Temporality
+		case metricdata.Histogram[float64]:
+			entry.Aggregation, entry.Temporality = "Histogram[float64]", a.Temporality
+		}
+		keys := make(map[attribute.Key]struct{})
+		eachAttributeSet(m.Data, func(s attribute.Set) {
+			for iter := s.Iter(); iter.Next(); {
+				keys[iter.Attribute().Key] = struct{}{}
+			}
+		})
+		for k := range keys {
+			entry.AttributeKeys = append(entry.AttributeKeys, string(k))
+		}
+		sort.Strings(entry.AttributeKeys)
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Aggregation < entries[j].Aggregation
+	})
+	return entries
+}
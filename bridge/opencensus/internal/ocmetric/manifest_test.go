@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestManifest(t *testing.T) {
+	input := func(now time.Time, value int64) []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{
+			{
+				Descriptor: ocmetricdata.Descriptor{
+					Name:      "foo.com/sum-a",
+					Unit:      ocmetricdata.UnitBytes,
+					Type:      ocmetricdata.TypeCumulativeInt64,
+					LabelKeys: []ocmetricdata.LabelKey{{Key: "b"}, {Key: "a"}},
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{
+					{
+						LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {}},
+						Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, value)},
+					}, {
+						LabelValues: []ocmetricdata.LabelValue{{}, {Value: "2", Present: true}},
+						Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, value+1)},
+					},
+				},
+			}, {
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/histogram-a",
+					Unit: ocmetricdata.UnitMilliseconds,
+					Type: ocmetricdata.TypeCumulativeDistribution,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							Count:         value,
+							BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+							Buckets:       []ocmetricdata.Bucket{{Count: value}, {}},
+						}),
+					},
+				}},
+			}, {
+				Descriptor: ocmetricdata.Descriptor{
+					Name:      "foo.com/gauge-a",
+					Unit:      ocmetricdata.UnitDimensionless,
+					Type:      ocmetricdata.TypeGaugeFloat64,
+					LabelKeys: []ocmetricdata.LabelKey{{Key: "c"}},
+				},
+			},
+		}
+	}
+	expected := []MetricManifestEntry{
+		{
+			Name:          "foo.com/gauge-a",
+			Unit:          "1",
+			Aggregation:   "Gauge[float64]",
+			AttributeKeys: []string{},
+		},
+		{
+			Name:          "foo.com/histogram-a",
+			Unit:          "ms",
+			Aggregation:   "Histogram[float64]",
+			Temporality:   metricdata.CumulativeTemporality,
+			AttributeKeys: []string{},
+		},
+		{
+			Name:          "foo.com/sum-a",
+			Unit:          "By",
+			Aggregation:   "Sum[int64]",
+			Temporality:   metricdata.CumulativeTemporality,
+			AttributeKeys: []string{"a", "b"},
+		},
+	}
+
+	now := time.Now()
+	output, err := ConvertMetrics(input(now, 1))
+	require.NoError(t, err)
+	assert.Equal(t, expected, Manifest(output))
+
+	// The manifest does not depend on values and timestamps.
+	output, err = ConvertMetrics(input(now.Add(time.Hour), 10))
+	require.NoError(t, err)
+	assert.Equal(t, expected, Manifest(output))
+
+	// It does depend on the conversion.
+	output, err = ConvertMetrics(input(now, 1), WithTemporalityByName(func(string, ocmetricdata.Type) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}))
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, Manifest(output))
+
+	assert.Empty(t, Manifest(nil))
+}
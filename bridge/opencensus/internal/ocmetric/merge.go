@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errMismatchedBounds = errors.New("colliding histogram data points have different bounds")
+
+// pointKey identifies data points that collide. Data points collide if they
+// have the same attributes and time, which happens when time series only
+// differ by attributes that were dropped during conversion.
+type pointKey struct {
+	attrs attribute.Distinct
+	time  int64
+}
+
+// mergesCollisions returns true if the configured conversion can make the
+// attributes of distinct OpenCensus time series collide, or the times of the
+// data points of a time series collide when they are truncated.
+func (c *Converter) mergesCollisions() bool {
+	return c.cfg.attributeAllowList != nil || c.cfg.attributeKeyMapper != nil || c.cfg.metricScopedKeyMapper != nil ||
+		c.cfg.maxAttributeCount >= 0 || c.cfg.timestampResolution > 0 ||
+		c.cfg.attributeKeyValidation == AttributeKeyDrop || c.cfg.attributeKeyValidation == AttributeKeySanitize
+}
+
+// latestPerTime removes the data points of a time series that have the same
+// time as a later data point of the series, which happens when their times
+// are truncated, in place. The later data point holds the current value of
+// the series, gauge or cumulative, so it is kept instead of merging them.
+// The timeOf function returns the time of a data point.
+func latestPerTime[P any](series []P, timeOf func(P) time.Time) []P {
+	index := make(map[int64]int, len(series))
+	latest := series[:0]
+	for _, p := range series {
+		t := timeOf(p).UnixNano()
+		if i, ok := index[t]; ok {
+			latest[i] = p
+			continue
+		}
+		index[t] = len(latest)
+		latest = append(latest, p)
+	}
+	return latest
+}
+
+// mergeCollidingPoints merges colliding data points in place. key returns
+// the pointKey of a data point, and merge merges a data point into the
+// earlier one it collides with. If merge returns an error, the data points
+// cannot be merged and both are kept. The errors of merge are joined and
+// returned.
+func mergeCollidingPoints[P any](points []P, key func(P) pointKey, merge func(m *P, p P) error) ([]P, error) {
+	index := make(map[pointKey]int, len(points))
+	merged := points[:0]
+	var err error
+	for _, p := range points {
+		k := key(p)
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(merged)
+			merged = append(merged, p)
+			continue
+		}
+		if mergeErr := merge(&merged[i], p); mergeErr != nil {
+			err = errors.Join(err, mergeErr)
+			merged = append(merged, p)
+		}
+	}
+	return merged, err
+}
+
+// dataPointKey returns the pointKey of a sum or gauge data point.
+func dataPointKey[N int64 | float64](p metricdata.DataPoint[N]) pointKey {
+	return pointKey{attrs: p.Attributes.Equivalent(), time: p.Time.UnixNano()}
+}
+
+// histogramPointKey returns the pointKey of a histogram data point.
+func histogramPointKey(p metricdata.HistogramDataPoint[float64]) pointKey {
+	return pointKey{attrs: p.Attributes.Equivalent(), time: p.Time.UnixNano()}
+}
+
+// mergeSumPoint merges sum data points by adding their values. The merged
+// point has the earliest start time of the points.
+func mergeSumPoint[N int64 | float64](m *metricdata.DataPoint[N], p metricdata.DataPoint[N]) error {
+	m.Value += p.Value
+	if p.StartTime.Before(m.StartTime) {
+		m.StartTime = p.StartTime
+	}
+	return nil
+}
+
+// mergeGaugePoint merges gauge data points by keeping the last one.
+func mergeGaugePoint[N int64 | float64](m *metricdata.DataPoint[N], p metricdata.DataPoint[N]) error {
+	*m = p
+	return nil
+}
+
+// mergeHistogramPoint merges histogram data points by adding their counts,
+// sums, and bucket counts, and combining their exemplars. The merged point
+// has the earliest start time of the points. Data points with different
+// bounds cannot be merged.
+func mergeHistogramPoint(m *metricdata.HistogramDataPoint[float64], p metricdata.HistogramDataPoint[float64]) error {
+	if !equalBounds(m.Bounds, p.Bounds) || len(m.BucketCounts) != len(p.BucketCounts) {
+		return fmt.Errorf("%w: %v and %v", errMismatchedBounds, m.Bounds, p.Bounds)
+	}
+	// Copy the bucket counts so that the converted point is not modified.
+	bucketCounts := make([]uint64, len(m.BucketCounts))
+	for j := range bucketCounts {
+		bucketCounts[j] = m.BucketCounts[j] + p.BucketCounts[j]
+	}
+	m.BucketCounts = bucketCounts
+	m.Count += p.Count
+	m.Sum += p.Sum
+	if p.StartTime.Before(m.StartTime) {
+		m.StartTime = p.StartTime
+	}
+	m.Min = mergeExtrema(m.Min, p.Min, math.Min)
+	m.Max = mergeExtrema(m.Max, p.Max, math.Max)
+	if len(p.Exemplars) > 0 {
+		m.Exemplars = append(m.Exemplars[:len(m.Exemplars):len(m.Exemplars)], p.Exemplars...)
+	}
+	return nil
+}
+
+// mergeExtrema returns the extremum of a and b selected by f. It is undefined
+// if either a or b is undefined.
+func mergeExtrema(a, b metricdata.Extrema[float64], f func(x, y float64) float64) metricdata.Extrema[float64] {
+	x, okA := a.Value()
+	y, okB := b.Value()
+	if !okA || !okB {
+		return metricdata.Extrema[float64]{}
+	}
+	return metricdata.NewExtrema(f(x, y))
+}
+
+// equalBounds returns true if a and b are the same bounds.
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
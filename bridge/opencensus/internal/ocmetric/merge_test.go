@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMergeCollidingPoints(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+	later := now.Add(time.Minute)
+	a := attribute.NewSet(attribute.String("a", "1"))
+	b := attribute.NewSet(attribute.String("a", "2"))
+	for _, tc := range []struct {
+		desc     string
+		merge    func() (metricdata.Aggregation, error)
+		expected metricdata.Aggregation
+		wantErr  error
+	}{
+		{
+			desc: "int64 sum",
+			merge: func() (metricdata.Aggregation, error) {
+				points, err := mergeCollidingPoints([]metricdata.DataPoint[int64]{
+					{Attributes: a, StartTime: now, Time: later, Value: 1},
+					{Attributes: b, StartTime: now, Time: later, Value: 2},
+					{Attributes: a, StartTime: earlier, Time: later, Value: 3},
+					{Attributes: a, StartTime: now, Time: now, Value: 4},
+				}, dataPointKey[int64], mergeSumPoint[int64])
+				return metricdata.Sum[int64]{DataPoints: points}, err
+			},
+			expected: metricdata.Sum[int64]{DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: a, StartTime: earlier, Time: later, Value: 4},
+				{Attributes: b, StartTime: now, Time: later, Value: 2},
+				{Attributes: a, StartTime: now, Time: now, Value: 4},
+			}},
+		},
+		{
+			desc: "float64 gauge",
+			merge: func() (metricdata.Aggregation, error) {
+				points, err := mergeCollidingPoints([]metricdata.DataPoint[float64]{
+					{Attributes: a, Time: now, Value: 1.5},
+					{Attributes: b, Time: now, Value: 2.5},
+					{Attributes: a, Time: now, Value: 3.5},
+				}, dataPointKey[float64], mergeGaugePoint[float64])
+				return metricdata.Gauge[float64]{DataPoints: points}, err
+			},
+			expected: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{
+				{Attributes: a, Time: now, Value: 3.5},
+				{Attributes: b, Time: now, Value: 2.5},
+			}},
+		},
+		{
+			desc: "histogram",
+			merge: func() (metricdata.Aggregation, error) {
+				points, err := mergeCollidingPoints([]metricdata.HistogramDataPoint[float64]{
+					{
+						Attributes: a, StartTime: now, Time: later,
+						Count: 2, Sum: 3, Bounds: []float64{1}, BucketCounts: []uint64{1, 1},
+						Min: metricdata.NewExtrema(0.5), Max: metricdata.NewExtrema(2.5),
+						Exemplars: []metricdata.Exemplar[float64]{{Value: 0.5}},
+					},
+					{
+						Attributes: a, StartTime: earlier, Time: later,
+						Count: 1, Sum: 5, Bounds: []float64{1}, BucketCounts: []uint64{0, 1},
+						Min: metricdata.NewExtrema(5.0), Max: metricdata.NewExtrema(5.0),
+						Exemplars: []metricdata.Exemplar[float64]{{Value: 5}},
+					},
+				}, histogramPointKey, mergeHistogramPoint)
+				return metricdata.Histogram[float64]{DataPoints: points}, err
+			},
+			expected: metricdata.Histogram[float64]{DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{
+					Attributes: a, StartTime: earlier, Time: later,
+					Count: 3, Sum: 8, Bounds: []float64{1}, BucketCounts: []uint64{1, 2},
+					Min: metricdata.NewExtrema(0.5), Max: metricdata.NewExtrema(5.0),
+					Exemplars: []metricdata.Exemplar[float64]{{Value: 0.5}, {Value: 5}},
+				},
+			}},
+		},
+		{
+			desc: "histogram with different bounds",
+			merge: func() (metricdata.Aggregation, error) {
+				points, err := mergeCollidingPoints([]metricdata.HistogramDataPoint[float64]{
+					{Attributes: a, Time: now, Count: 1, Bounds: []float64{1}, BucketCounts: []uint64{1, 0}},
+					{Attributes: a, Time: now, Count: 1, Bounds: []float64{2}, BucketCounts: []uint64{1, 0}},
+					{Attributes: a, Time: now, Count: 1, Bounds: []float64{3}, BucketCounts: []uint64{1, 0}},
+				}, histogramPointKey, mergeHistogramPoint)
+				return metricdata.Histogram[float64]{DataPoints: points}, err
+			},
+			expected: metricdata.Histogram[float64]{DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{Attributes: a, Time: now, Count: 1, Bounds: []float64{1}, BucketCounts: []uint64{1, 0}},
+				{Attributes: a, Time: now, Count: 1, Bounds: []float64{2}, BucketCounts: []uint64{1, 0}},
+				{Attributes: a, Time: now, Count: 1, Bounds: []float64{3}, BucketCounts: []uint64{1, 0}},
+			}},
+			wantErr: errMismatchedBounds,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := tc.merge()
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, 2, len(err.(interface{ Unwrap() []error }).Unwrap()))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func TestConverterMergesCollisions(t *testing.T) {
+	start := time.Unix(1000, 0)
+	now := start.Add(time.Minute)
+	sum := func(keys []string, values ...[]string) *ocmetricdata.Metric {
+		labelKeys := make([]ocmetricdata.LabelKey, len(keys))
+		for i, k := range keys {
+			labelKeys[i] = ocmetricdata.LabelKey{Key: k}
+		}
+		series := make([]*ocmetricdata.TimeSeries, len(values))
+		for i, vs := range values {
+			labelValues := make([]ocmetricdata.LabelValue, len(vs))
+			for j, v := range vs {
+				labelValues[j] = ocmetricdata.NewLabelValue(v)
+			}
+			series[i] = &ocmetricdata.TimeSeries{
+				LabelValues: labelValues,
+				Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+				StartTime:   start,
+			}
+		}
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/sum-a", Type: ocmetricdata.TypeCumulativeInt64, LabelKeys: labelKeys},
+			TimeSeries: series,
+		}
+	}
+	for _, tc := range []struct {
+		desc     string
+		opts     []Option
+		input    *ocmetricdata.Metric
+		expected []metricdata.DataPoint[int64]
+	}{
+		{
+			desc:  "max attribute count",
+			opts:  []Option{WithMaxAttributeCount(1)},
+			input: sum([]string{"a", "b"}, []string{"1", "x"}, []string{"1", "y"}),
+			expected: []metricdata.DataPoint[int64]{
+				{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 2},
+			},
+		},
+		{
+			desc:  "attribute key drop",
+			opts:  []Option{WithValidateAttributeKeys(AttributeKeyDrop)},
+			input: sum([]string{"a", "b c"}, []string{"1", "x"}, []string{"1", "y"}),
+			expected: []metricdata.DataPoint[int64]{
+				{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 2},
+			},
+		},
+		{
+			desc:  "attribute key sanitize",
+			opts:  []Option{WithValidateAttributeKeys(AttributeKeySanitize)},
+			input: sum([]string{"a", ""}, []string{"1", "x"}, []string{"1", "y"}),
+			expected: []metricdata.DataPoint[int64]{
+				{Attributes: attribute.NewSet(attribute.String("a", "1")), StartTime: start, Time: now, Value: 2},
+			},
+		},
+		{
+			desc: "timestamp truncation",
+			opts: []Option{WithTimestampTruncation(time.Second)},
+			input: &ocmetricdata.Metric{
+				Descriptor: ocmetricdata.Descriptor{Name: "foo.com/sum-a", Type: ocmetricdata.TypeCumulativeInt64},
+				TimeSeries: []*ocmetricdata.TimeSeries{{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(now.Add(100*time.Millisecond), 1),
+						ocmetricdata.NewInt64Point(now.Add(900*time.Millisecond), 3),
+						ocmetricdata.NewInt64Point(now.Add(1100*time.Millisecond), 4),
+					},
+					StartTime: start,
+				}},
+			},
+			expected: []metricdata.DataPoint[int64]{
+				{Attributes: *attribute.EmptySet(), StartTime: start, Time: now, Value: 3},
+				{Attributes: *attribute.EmptySet(), StartTime: start, Time: now.Add(time.Second), Value: 4},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, _ := ConvertMetrics([]*ocmetricdata.Metric{tc.input}, tc.opts...)
+			require.Len(t, output, 1)
+			sum, ok := output[0].Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, sum.DataPoints)
+		})
+	}
+}
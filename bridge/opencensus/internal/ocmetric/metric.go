@@ -15,10 +15,20 @@
 package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	ocmetricdata "go.opencensus.io/metric/metricdata"
+	octrace "go.opencensus.io/trace"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -30,132 +40,1193 @@ var (
 	errNegativeDistributionCount    = errors.New("distribution count is negative")
 	errNegativeBucketCount          = errors.New("distribution bucket count is negative")
 	errMismatchedAttributeKeyValues = errors.New("mismatched number of attribute keys and values")
+	errEmptyMetricName              = errors.New("metric name is empty")
+	errInvalidExemplarSpanContext   = errors.New("span context exemplar attachment does not contain an OpenCensus SpanContext")
+	errInvalidExemplarSampleRate    = errors.New("sample rate exemplar attachment is not a number")
+	errInfiniteBound                = errors.New("distribution bounds include +Inf")
+	errTooManyAttributes            = errors.New("too many attributes")
+	errAttributeKeyCollision        = errors.New("attribute key collision")
+	errHistogramDownsampled         = errors.New("histogram buckets merged to fit bucket limit")
+	errNegativeBounds               = errors.New("distribution bounds are negative")
+	errDescriptionTruncated         = errors.New("metric description truncated")
+	errInvalidAggregationOverride   = errors.New("unsupported aggregation override type")
+	errNaNValue                     = errors.New("data point value is NaN")
+	errInvalidUTF8                  = errors.New("attribute value is not valid UTF-8")
+	errInvalidViewAggregation       = errors.New("unsupported view aggregation type")
+	errBucketSumMismatch            = errors.New("distribution bucket counts do not sum to the count")
+	errInconsistentHistogram        = errors.New("distribution has a count but no bucket counts")
+	errImplausibleTimestamp         = errors.New("data point time is implausible")
+	errBoundsCollisionAfterRounding = errors.New("distribution bounds collide after rounding")
+	errExemplarsTrimmed             = errors.New("exemplars exceed the exemplar limit")
+	errExemplarOutOfBounds          = errors.New("exemplar value outside of its bucket bounds")
+	errGaugePrecisionLoss           = errors.New("float64 gauge values rounded to int64")
+	errGaugeValueOutOfRange         = errors.New("float64 gauge value cannot be converted to int64")
+	errNilMetrics                   = errors.New("nil metrics skipped")
+	errValueCoerced                 = errors.New("data point value converted to the value type of the metric with loss of precision")
+
+	// errOmitted is returned in place of errors omitted because the maximum
+	// number of errors of a conversion was reached.
+	errOmitted = errors.New("error omitted")
+)
+
+const (
+	// attachmentKeySampleRate is the OpenCensus exemplar attachment key
+	// holding the rate at which the exemplar was sampled.
+	attachmentKeySampleRate = "SampleRate"
+
+	// SampleRateKey is the key of the exemplar filtered attribute the
+	// OpenCensus sample rate attachment is converted to. OpenTelemetry
+	// exemplars have no weight, so the sample rate is retained as a float64
+	// attribute for downstream rate estimation.
+	SampleRateKey = attribute.Key("exemplar.sample_rate")
 )
 
+// Converter converts OpenCensus metrics to OpenTelemetry. It retains
+// statistics about the last conversion it performed.
+//
+// A Converter is not safe for concurrent use.
+type Converter struct {
+	cfg   config
+	stats Stats
+	// warnings are the issues found converting the current metric that did
+	// not prevent its conversion.
+	warnings error
+	// errCount and omittedErrCount are the number of errors joined and
+	// omitted during the current conversion.
+	errCount        int
+	omittedErrCount int
+	// metricName is the name of the metric being converted.
+	metricName string
+	// metricErr is the first error that prevents the conversion of the
+	// current metric.
+	metricErr error
+	// dropped holds the first error of each metric dropped by the last
+	// conversion.
+	dropped map[string]error
+	// cumulative holds the last cumulative data point of the time series
+	// converted to delta temporality.
+	cumulative map[seriesKey]any
+	// sumValues holds the last cumulative data point of the sum time series
+	// checked for monotonicity.
+	sumValues map[seriesKey]any
+	// nonMonotonic holds the names of the sums whose values decreased.
+	nonMonotonic map[string]struct{}
+	// startTimes holds the latest start time of the sum time series whose
+	// resets are handled.
+	startTimes map[seriesKey]time.Time
+	// lastNames are the names of the metrics of the last conversion, and
+	// unstableOrder is true once their order changed, with stable ordering.
+	lastNames     []string
+	unstableOrder bool
+	// interner, if set, interns the string values of converted attributes.
+	interner *interner
+	// instrumentNames holds the original name of each sanitized metric name
+	// of the current conversion.
+	instrumentNames map[string]string
+	// metricKinds holds the kind of aggregation of each metric name of the
+	// current conversion.
+	metricKinds map[string]aggregationKind
+	// enrichment are the attributes added to the attributes of each
+	// converted data point.
+	enrichment []attribute.KeyValue
+	// attrCache, if set, caches converted attribute sets across
+	// conversions.
+	attrCache *attributeCache
+	// budget is the state of the budgeted conversion that can be continued
+	// with its remaining metrics, if any.
+	budget *budgetState
+}
+
+// Stats are statistics about a conversion of OpenCensus metrics.
+type Stats struct {
+	// CoalescedGaugePoints is the number of gauge points dropped because a
+	// later point of the same time series was kept.
+	CoalescedGaugePoints int
+	// AttributeSets is the number of distinct attribute sets of the data
+	// points of each converted metric, by metric name. A sudden increase
+	// indicates a cardinality explosion.
+	AttributeSets map[string]int
+	// FilteredPoints is the number of data points excluded by the point
+	// filter.
+	FilteredPoints int
+	// EmptyMetrics is the number of metrics without data points dropped.
+	EmptyMetrics int
+	// NilMetrics is the number of nil metrics skipped.
+	NilMetrics int
+	// AgedOutPoints is the number of data points dropped because they are
+	// older than the maximum series age.
+	AgedOutPoints int
+}
+
+// NewConverter returns a Converter configured with opts.
+func NewConverter(opts ...Option) *Converter {
+	return newConverter(newConfig(opts))
+}
+
+// newConverter returns a Converter configured with cfg.
+func newConverter(cfg config) *Converter {
+	c := &Converter{
+		cfg:             cfg,
+		stats:           Stats{AttributeSets: make(map[string]int)},
+		dropped:         make(map[string]error),
+		cumulative:      make(map[seriesKey]any),
+		sumValues:       make(map[seriesKey]any),
+		nonMonotonic:    make(map[string]struct{}),
+		startTimes:      make(map[seriesKey]time.Time),
+		instrumentNames: make(map[string]string),
+		metricKinds:     make(map[string]aggregationKind),
+	}
+	if c.cfg.internStrings {
+		c.interner = &interner{}
+	}
+	if c.cfg.attributeCacheSize > 0 {
+		c.attrCache = newAttributeCache(c.cfg.attributeCacheSize)
+	}
+	return c
+}
+
+// Reset discards the state the Converter retains from previous conversions
+// to convert sums and histograms to delta temporality, to detect
+// non-monotonic sums, to handle resets of sums, and to detect
+// nondeterministic ordering. Conversions after Reset behave like those of a
+// new Converter.
+func (c *Converter) Reset() {
+	c.lastNames, c.unstableOrder = nil, false
+	c.cumulative = make(map[seriesKey]any)
+	c.sumValues = make(map[seriesKey]any)
+	c.nonMonotonic = make(map[string]struct{})
+	c.startTimes = make(map[seriesKey]time.Time)
+	c.budget = nil
+	if c.attrCache != nil {
+		c.attrCache = newAttributeCache(c.cfg.attributeCacheSize)
+	}
+}
+
+// Stats returns the statistics of the last conversion.
+func (c *Converter) Stats() Stats {
+	return c.stats
+}
+
+// DroppedMetrics returns the names of the metrics dropped by the last
+// conversion, mapped to the first error that caused each to be dropped.
+// Metrics dropped because of an empty name are mapped from the empty name.
+func (c *Converter) DroppedMetrics() map[string]error {
+	return c.dropped
+}
+
+// ConvertMetrics converts metric data from OpenCensus to OpenTelemetry.
+func ConvertMetrics(ocmetrics []*ocmetricdata.Metric, opts ...Option) ([]metricdata.Metrics, error) {
+	return NewConverter(opts...).ConvertMetrics(ocmetrics)
+}
+
+// ConvertMetricsWithContext converts metric data from OpenCensus to
+// OpenTelemetry like ConvertMetrics, adding the attributes returned by
+// enrich to the attributes of each converted data point, e.g. a tenant or
+// region from the baggage of ctx. enrich is called once with ctx, and
+// invalid attributes it returns are ignored. By default, the attributes
+// converted from the labels of a data point take precedence over enrichment
+// attributes with the same key, see WithEnrichmentOverride.
+func ConvertMetricsWithContext(ctx context.Context, ocmetrics []*ocmetricdata.Metric, enrich func(ctx context.Context) []attribute.KeyValue, opts ...Option) ([]metricdata.Metrics, error) {
+	return NewConverter(opts...).ConvertMetricsWithContext(ctx, ocmetrics, enrich)
+}
+
+// ConvertMetricsWithContext converts metric data from OpenCensus to
+// OpenTelemetry like ConvertMetrics, adding the attributes returned by
+// enrich to the attributes of each converted data point, as described for
+// the ConvertMetricsWithContext function. The enrichment attributes only
+// apply to this conversion.
+func (c *Converter) ConvertMetricsWithContext(ctx context.Context, ocmetrics []*ocmetricdata.Metric, enrich func(ctx context.Context) []attribute.KeyValue) ([]metricdata.Metrics, error) {
+	c.enrichment = nil
+	if enrich != nil {
+		for _, kv := range enrich(ctx) {
+			if kv.Valid() {
+				c.enrichment = append(c.enrichment, kv)
+			}
+		}
+	}
+	defer func() { c.enrichment = nil }()
+	return c.ConvertMetrics(ocmetrics)
+}
+
 // ConvertMetrics converts metric data from OpenCensus to OpenTelemetry.
-func ConvertMetrics(ocmetrics []*ocmetricdata.Metric) ([]metricdata.Metrics, error) {
+func (c *Converter) ConvertMetrics(ocmetrics []*ocmetricdata.Metric) ([]metricdata.Metrics, error) {
 	otelMetrics := make([]metricdata.Metrics, 0, len(ocmetrics))
+	err := c.convert(ocmetrics, func(_ *ocmetricdata.Metric, m metricdata.Metrics) {
+		otelMetrics = append(otelMetrics, m)
+	})
+	return otelMetrics, err
+}
+
+// ConvertMetricsGrouped converts metric data from OpenCensus to OpenTelemetry.
+// The converted metrics are grouped by the concrete type of their
+// aggregation, in the order of ocmetrics. The keys of the returned map are
+// the reflect.Type of:
+//
+//   - metricdata.Gauge[int64] and metricdata.Gauge[float64]
+//   - metricdata.Sum[int64] and metricdata.Sum[float64]
+//   - metricdata.Histogram[float64]
+//
+// Only the types of converted metrics are included.
+func (c *Converter) ConvertMetricsGrouped(ocmetrics []*ocmetricdata.Metric) (map[reflect.Type][]metricdata.Metrics, error) {
+	grouped := make(map[reflect.Type][]metricdata.Metrics)
+	err := c.convert(ocmetrics, func(_ *ocmetricdata.Metric, m metricdata.Metrics) {
+		t := reflect.TypeOf(m.Data)
+		grouped[t] = append(grouped[t], m)
+	})
+	return grouped, err
+}
+
+// ConvertMetricsByTemporality converts metric data from OpenCensus to
+// OpenTelemetry, partitioned by the temporality of the converted sums and
+// histograms, e.g. to export them with different exporters. Gauges have no
+// temporality: they are included in cumulative, as cumulative exporters
+// export the latest value of gauges. Metrics are in the order of ocmetrics
+// within each partition.
+func (c *Converter) ConvertMetricsByTemporality(ocmetrics []*ocmetricdata.Metric) (cumulative, delta []metricdata.Metrics, err error) {
+	err = c.convert(ocmetrics, func(_ *ocmetricdata.Metric, m metricdata.Metrics) {
+		if temporalityOf(m.Data) == metricdata.DeltaTemporality {
+			delta = append(delta, m)
+			return
+		}
+		cumulative = append(cumulative, m)
+	})
+	return cumulative, delta, err
+}
+
+// temporalityOf returns the temporality of agg, or an undefined temporality
+// if agg has none.
+func temporalityOf(agg metricdata.Aggregation) metricdata.Temporality {
+	switch a := agg.(type) {
+	case metricdata.Sum[int64]:
+		return a.Temporality
+	case metricdata.Sum[float64]:
+		return a.Temporality
+	case metricdata.Histogram[int64]:
+		return a.Temporality
+	case metricdata.Histogram[float64]:
+		return a.Temporality
+	}
+	return metricdata.Temporality(0)
+}
+
+// convert converts metric data from OpenCensus to OpenTelemetry. The emit
+// function is called with each converted metric and the OpenCensus metric it
+// was converted from.
+func (c *Converter) convert(ocmetrics []*ocmetricdata.Metric, emit func(*ocmetricdata.Metric, metricdata.Metrics)) error {
+	c.beginConversion()
+	c.instrumentNames = make(map[string]string)
+	c.metricKinds = make(map[string]aggregationKind)
+	order := c.metricOrder(ocmetrics)
+	merged := c.deduplicateDescriptors(ocmetrics)
 	var err error
-	for _, ocm := range ocmetrics {
+	var nilIndices []int
+	for n := range ocmetrics {
+		i := n
+		if order != nil {
+			i = order[n]
+		}
+		ocm := ocmetrics[i]
 		if ocm == nil {
+			c.stats.NilMetrics++
+			nilIndices = append(nilIndices, i)
 			continue
 		}
-		agg, aggregationErr := convertAggregation(ocm)
-		if aggregationErr != nil {
-			err = errors.Join(err, fmt.Errorf("error converting metric %v: %w", ocm.Descriptor.Name, aggregationErr))
-			continue
+		if m, ok := merged[i]; ok {
+			if m == nil {
+				continue
+			}
+			ocm = m
 		}
-		otelMetrics = append(otelMetrics, metricdata.Metrics{
-			Name:        ocm.Descriptor.Name,
-			Description: ocm.Descriptor.Description,
-			Unit:        string(ocm.Descriptor.Unit),
-			Data:        agg,
-		})
+		var start time.Time
+		if c.cfg.timingRecorder != nil {
+			start = c.cfg.now()
+		}
+		m, ok, metricErr := c.convertMetric(i, ocm)
+		if c.cfg.timingRecorder != nil {
+			c.cfg.timingRecorder(ocm.Descriptor.Name, c.cfg.now().Sub(start))
+		}
+		err = errors.Join(err, metricErr)
+		if ok {
+			if c.cfg.successHandler != nil {
+				c.cfg.successHandler(m)
+			}
+			emit(ocm, m)
+		}
+	}
+	if c.cfg.reportNilMetrics && len(nilIndices) > 0 {
+		sort.Ints(nilIndices)
+		err = errors.Join(err, fmt.Errorf("%w: %d at indices %v", errNilMetrics, len(nilIndices), nilIndices))
+	}
+	if c.omittedErrCount > 0 {
+		err = errors.Join(err, fmt.Errorf("+%d more errors", c.omittedErrCount))
 	}
-	if err != nil {
-		return otelMetrics, fmt.Errorf("error converting from OpenCensus to OpenTelemetry: %w", err)
+	return c.wrapErr(err)
+}
+
+// beginConversion discards the statistics, errors, and dropped metrics of
+// the previous conversion.
+func (c *Converter) beginConversion() {
+	c.stats = Stats{AttributeSets: make(map[string]int)}
+	c.errCount, c.omittedErrCount = 0, 0
+	c.dropped = make(map[string]error)
+}
+
+// wrapErr wraps err, the error of a conversion, with the configured error
+// prefix. It returns nil if err is nil.
+func (c *Converter) wrapErr(err error) error {
+	if err == nil {
+		return nil
 	}
-	return otelMetrics, nil
+	return fmt.Errorf("%s: %w", c.cfg.errorPrefix, err)
+}
+
+// convertMetric converts ocm, the metric at index i of the converted batch,
+// from OpenCensus to OpenTelemetry. It returns false if the metric is
+// dropped, and the errors of the conversion that are not omitted.
+func (c *Converter) convertMetric(i int, ocm *ocmetricdata.Metric) (metricdata.Metrics, bool, error) {
+	name := ocm.Descriptor.Name
+	if name == "" {
+		if !c.cfg.nameEmptyMetrics {
+			nameErr := fmt.Errorf("%w: metric at index %d", errEmptyMetricName, i)
+			c.drop(name, nameErr)
+			if nameErr = c.joinErr(nil, nameErr); errors.Is(nameErr, errOmitted) {
+				return metricdata.Metrics{}, false, nil
+			}
+			return metricdata.Metrics{}, false, nameErr
+		}
+		name = c.cfg.defaultNamePrefix + strconv.Itoa(i)
+	}
+	if c.cfg.skipEmptyMetrics && !hasPoints(ocm) {
+		c.stats.EmptyMetrics++
+		return metricdata.Metrics{}, false, nil
+	}
+	c.metricName = name
+	c.metricErr = nil
+	fullName, ok, err := c.checkName(name)
+	if !ok {
+		return metricdata.Metrics{}, false, err
+	}
+	agg, aggregationErr := c.convertAggregation(ocm)
+	if aggregationErr == nil && c.cfg.view != nil {
+		agg = c.applyView(agg)
+	}
+	if aggregationErr == nil && c.unstableOrder {
+		sortByAttributes(agg)
+	}
+	if aggregationErr == nil && c.cfg.sortByStartTime {
+		sortByStartTime(agg)
+	}
+	if aggregationErr == nil && c.cfg.semConvRegistry != nil {
+		c.validateSemConv(fullName, agg)
+	}
+	description := c.convertDescription(ocm.Descriptor.Description)
+	if c.warnings != nil && !errors.Is(c.warnings, errOmitted) {
+		err = fmt.Errorf("warning converting metric %v: %w", name, c.warnings)
+	}
+	c.warnings = nil
+	if aggregationErr != nil {
+		if c.metricErr != nil {
+			c.drop(name, c.metricErr)
+		} else {
+			c.drop(name, aggregationErr)
+		}
+		if !errors.Is(aggregationErr, errOmitted) {
+			err = errors.Join(err, fmt.Errorf("error converting metric %v: %w", name, aggregationErr))
+		}
+		return metricdata.Metrics{}, false, err
+	}
+	fullName, ok, err = c.checkType(name, fullName, agg, err)
+	if !ok {
+		return metricdata.Metrics{}, false, err
+	}
+	c.stats.AttributeSets[name] = countAttributeSets(agg)
+	return metricdata.Metrics{
+		Name:        fullName,
+		Description: description,
+		Unit:        c.convertUnit(string(ocm.Descriptor.Unit)),
+		Data:        agg,
+	}, true, err
+}
+
+// checkName returns the full name of the current metric, of name name,
+// validated according to the instrument name validation. It returns false,
+// and the error to report if any, if the metric is dropped.
+func (c *Converter) checkName(name string) (string, bool, error) {
+	fullName, nameErr := c.instrumentName(c.cfg.metricNamePrefix + name)
+	if nameErr == nil {
+		return fullName, true, nil
+	}
+	c.drop(name, nameErr)
+	if nameErr = c.joinErr(nil, nameErr); errors.Is(nameErr, errOmitted) {
+		return "", false, nil
+	}
+	return "", false, fmt.Errorf("error converting metric %v: %w", name, nameErr)
+}
+
+// checkType returns the name the current metric, of name name, full name
+// fullName, and aggregation agg, is converted with, according to the
+// incompatible type handling, and err, the errors of its conversion, joined
+// with any type error. It returns false if the metric is dropped.
+func (c *Converter) checkType(name, fullName string, agg metricdata.Aggregation, err error) (string, bool, error) {
+	fullName, typeErr := c.checkMetricType(fullName, agg)
+	if typeErr == nil {
+		return fullName, true, err
+	}
+	c.drop(name, typeErr)
+	if c.cfg.incompatibleTypeHandling == IncompatibleTypeFirst {
+		return "", false, err
+	}
+	if typeErr = c.joinErr(nil, typeErr); !errors.Is(typeErr, errOmitted) {
+		err = errors.Join(err, fmt.Errorf("error converting metric %v: %w", name, typeErr))
+	}
+	return "", false, err
+}
+
+// hasPoints returns true if any time series of ocm has a data point.
+func hasPoints(ocm *ocmetricdata.Metric) bool {
+	for _, ts := range ocm.TimeSeries {
+		if ts != nil && len(ts.Points) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// convertUnit returns the unit of the current metric, replaced by its unit
+// override or mapped by the unit mapper.
+func (c *Converter) convertUnit(unit string) string {
+	if override, ok := c.cfg.unitOverrides[c.metricName]; ok {
+		return override
+	}
+	if c.cfg.unitMapper != nil {
+		return c.cfg.unitMapper(c.metricName, unit)
+	}
+	return unit
+}
+
+// convertDescription returns the description of the current metric, or the
+// fallback description if it is empty, truncated to the maximum description
+// length.
+func (c *Converter) convertDescription(description string) string {
+	if description == "" && c.cfg.descriptionFallback != nil {
+		description = c.cfg.descriptionFallback(c.metricName)
+	}
+	limit := c.cfg.maxDescriptionLength
+	if limit <= 0 || len(description) <= limit {
+		return description
+	}
+	c.warn(fmt.Errorf("%w: %d bytes truncated to %d", errDescriptionTruncated, len(description), limit))
+	const ellipsis = "..."
+	if limit <= len(ellipsis) {
+		return truncateRunes(description, limit)
+	}
+	return truncateRunes(description, limit-len(ellipsis)) + ellipsis
+}
+
+// truncateRunes truncates s to at most n bytes without splitting a
+// multi-byte rune.
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// drop records that the metric with name was dropped because of err, unless
+// a metric with the same name was already dropped.
+func (c *Converter) drop(name string, err error) {
+	c.cfg.logger.V(4).Info("OpenCensus metric dropped", "metric", name, "error", err)
+	if _, ok := c.dropped[name]; !ok {
+		c.dropped[name] = err
+	}
+}
+
+// warn records an issue that does not prevent the conversion of the current
+// metric.
+func (c *Converter) warn(err error) {
+	c.cfg.logger.V(8).Info("warning converting OpenCensus metric", "metric", c.metricName, "error", err)
+	c.warnings = c.appendErr(c.warnings, err)
+}
+
+// joinErr joins err, which prevents the conversion of the current metric, to
+// errs.
+func (c *Converter) joinErr(errs, err error) error {
+	if c.metricErr == nil {
+		c.metricErr = err
+	}
+	return c.appendErr(errs, err)
+}
+
+// appendErr joins err to errs. Once the maximum number of errors of the
+// conversion is reached, err is counted instead of joined, and errOmitted is
+// returned if errs is nil so that a failure is still reported to the caller.
+func (c *Converter) appendErr(errs, err error) error {
+	if c.cfg.maxErrors >= 0 && c.errCount >= c.cfg.maxErrors {
+		c.omittedErrCount++
+		if errs == nil {
+			return errOmitted
+		}
+		return errs
+	}
+	c.errCount++
+	return errors.Join(errs, err)
 }
 
 // convertAggregation produces an aggregation based on the OpenCensus Metric.
-func convertAggregation(metric *ocmetricdata.Metric) (metricdata.Aggregation, error) {
+func (c *Converter) convertAggregation(metric *ocmetricdata.Metric) (metricdata.Aggregation, error) {
 	labelKeys := metric.Descriptor.LabelKeys
-	switch metric.Descriptor.Type {
+	switch ocType := c.aggregationType(metric.Descriptor.Type); ocType {
 	case ocmetricdata.TypeGaugeInt64:
-		return convertGauge[int64](labelKeys, metric.TimeSeries)
+		return convertGauge[int64](c, labelKeys, metric.TimeSeries)
 	case ocmetricdata.TypeGaugeFloat64:
-		return convertGauge[float64](labelKeys, metric.TimeSeries)
+		gauge, err := convertGauge[float64](c, labelKeys, metric.TimeSeries)
+		if err != nil || c.cfg.floatGaugeToInt == FloatToIntNone {
+			return gauge, err
+		}
+		return c.gaugeToInt(gauge), nil
 	case ocmetricdata.TypeCumulativeInt64:
-		return convertSum[int64](labelKeys, metric.TimeSeries)
+		return convertSum[int64](c, labelKeys, metric.TimeSeries, c.temporality(ocType))
 	case ocmetricdata.TypeCumulativeFloat64:
-		return convertSum[float64](labelKeys, metric.TimeSeries)
+		return convertSum[float64](c, labelKeys, metric.TimeSeries, c.temporality(ocType))
 	case ocmetricdata.TypeCumulativeDistribution:
-		return convertHistogram(labelKeys, metric.TimeSeries)
+		return c.convertHistogram(labelKeys, metric.TimeSeries, c.temporality(ocType))
 		// TODO: Support summaries, once it is in the OTel data types.
+	default:
+		if c.cfg.unsupportedAggregationHandler != nil {
+			agg, err := c.cfg.unsupportedAggregationHandler(metric)
+			if err == nil && agg == nil {
+				err = fmt.Errorf("%w: %q, handler returned no aggregation", errAggregationType, ocType)
+			}
+			return agg, err
+		}
+		return nil, fmt.Errorf("%w: %q", errAggregationType, ocType)
 	}
-	return nil, fmt.Errorf("%w: %q", errAggregationType, metric.Descriptor.Type)
+}
+
+// aggregationType returns the OpenCensus type the current metric, declared
+// with type ocType, is converted as.
+func (c *Converter) aggregationType(ocType ocmetricdata.Type) ocmetricdata.Type {
+	if c.cfg.aggregationOverride == nil {
+		return ocType
+	}
+	t := c.cfg.aggregationOverride(c.metricName, ocType)
+	if !supportedType(t) {
+		c.warn(fmt.Errorf("%w: %q, using %q", errInvalidAggregationOverride, t, ocType))
+		return ocType
+	}
+	return t
+}
+
+// supportedType returns true if metrics of OpenCensus type ocType can be
+// converted. It must match the types handled by convertAggregation.
+func supportedType(ocType ocmetricdata.Type) bool {
+	switch ocType {
+	case ocmetricdata.TypeGaugeInt64, ocmetricdata.TypeGaugeFloat64,
+		ocmetricdata.TypeCumulativeInt64, ocmetricdata.TypeCumulativeFloat64,
+		ocmetricdata.TypeCumulativeDistribution:
+		return true
+	}
+	return false
+}
+
+// UnsupportedMetrics returns the names of the metrics of ocmetrics with an
+// OpenCensus type that cannot be converted, e.g. summaries, in order. The
+// metrics are not converted, so this is a cheap check of which metrics a
+// conversion would drop because of their type.
+func UnsupportedMetrics(ocmetrics []*ocmetricdata.Metric) []string {
+	var names []string
+	for _, ocm := range ocmetrics {
+		if ocm != nil && !supportedType(ocm.Descriptor.Type) {
+			names = append(names, ocm.Descriptor.Name)
+		}
+	}
+	return names
+}
+
+// applyView returns the aggregation of the current metric, agg, transformed by
+// the view.
+func (c *Converter) applyView(agg metricdata.Aggregation) metricdata.Aggregation {
+	switch viewAgg := c.cfg.view(c.metricName, agg); viewAgg.(type) {
+	case metricdata.Gauge[int64], metricdata.Gauge[float64],
+		metricdata.Sum[int64], metricdata.Sum[float64],
+		metricdata.Histogram[float64]:
+		return viewAgg
+	default:
+		c.warn(fmt.Errorf("%w: %T, using %T", errInvalidViewAggregation, viewAgg, agg))
+		return agg
+	}
+}
+
+// sortByStartTime sorts the data points of agg by start time, then time.
+func sortByStartTime(agg metricdata.Aggregation) {
+	switch a := agg.(type) {
+	case metricdata.Gauge[int64]:
+		sortPoints(a.DataPoints, func(p metricdata.DataPoint[int64]) (time.Time, time.Time) { return p.StartTime, p.Time })
+	case metricdata.Gauge[float64]:
+		sortPoints(a.DataPoints, func(p metricdata.DataPoint[float64]) (time.Time, time.Time) { return p.StartTime, p.Time })
+	case metricdata.Sum[int64]:
+		sortPoints(a.DataPoints, func(p metricdata.DataPoint[int64]) (time.Time, time.Time) { return p.StartTime, p.Time })
+	case metricdata.Sum[float64]:
+		sortPoints(a.DataPoints, func(p metricdata.DataPoint[float64]) (time.Time, time.Time) { return p.StartTime, p.Time })
+	case metricdata.Histogram[float64]:
+		sortPoints(a.DataPoints, func(p metricdata.HistogramDataPoint[float64]) (time.Time, time.Time) { return p.StartTime, p.Time })
+	}
+}
+
+// sortPoints stably sorts points by the start time, then time, returned by
+// times.
+func sortPoints[P any](points []P, times func(P) (time.Time, time.Time)) {
+	sort.SliceStable(points, func(i, j int) bool {
+		startI, timeI := times(points[i])
+		startJ, timeJ := times(points[j])
+		if !startI.Equal(startJ) {
+			return startI.Before(startJ)
+		}
+		return timeI.Before(timeJ)
+	})
 }
 
 // convertGauge converts an OpenCensus gauge to an OpenTelemetry gauge aggregation.
-func convertGauge[N int64 | float64](labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) (metricdata.Gauge[N], error) {
-	points, err := convertNumberDataPoints[N](labelKeys, ts)
+func convertGauge[N int64 | float64](c *Converter, labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) (metricdata.Gauge[N], error) {
+	if c.cfg.latestGaugePointOnly {
+		ts = c.latestPoints(ts)
+	}
+	points, err := convertNumberDataPoints[N](c, labelKeys, ts, c.cfg.gaugeNaNPolicy)
+	if c.mergesCollisions() {
+		points, _ = mergeCollidingPoints(points, dataPointKey[N], mergeGaugePoint[N])
+	}
 	return metricdata.Gauge[N]{DataPoints: points}, err
 }
 
-// convertSum converts an OpenCensus cumulative to an OpenTelemetry sum aggregation.
-func convertSum[N int64 | float64](labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) (metricdata.Sum[N], error) {
-	points, err := convertNumberDataPoints[N](labelKeys, ts)
-	// OpenCensus sums are always Cumulative
-	return metricdata.Sum[N]{DataPoints: points, Temporality: metricdata.CumulativeTemporality, IsMonotonic: true}, err
+// gaugeToInt converts the float64 gauge of the current metric to an int64
+// gauge, rounding values according to the float to int mode. Warnings are
+// recorded for values that are changed by rounding, and for points that are
+// dropped because their value is NaN or outside of the int64 range.
+func (c *Converter) gaugeToInt(gauge metricdata.Gauge[float64]) metricdata.Gauge[int64] {
+	points := make([]metricdata.DataPoint[int64], 0, len(gauge.DataPoints))
+	var inexact, dropped int
+	for _, p := range gauge.DataPoints {
+		v, ok := floatToInt(c.cfg.floatGaugeToInt, p.Value)
+		if !ok {
+			dropped++
+			continue
+		}
+		if float64(v) != p.Value {
+			inexact++
+		}
+		points = append(points, metricdata.DataPoint[int64]{
+			Attributes: p.Attributes,
+			StartTime:  p.StartTime,
+			Time:       p.Time,
+			Value:      v,
+		})
+	}
+	if inexact > 0 {
+		c.warn(fmt.Errorf("%w: %d of %d values", errGaugePrecisionLoss, inexact, len(gauge.DataPoints)))
+	}
+	if dropped > 0 {
+		c.warn(fmt.Errorf("%w: %d points dropped", errGaugeValueOutOfRange, dropped))
+	}
+	return metricdata.Gauge[int64]{DataPoints: points}
+}
+
+// floatToInt returns v rounded to an int64 according to mode, which rounds
+// to the nearest integer if it is FloatToIntNone. It returns false if v is
+// NaN or the rounded value is outside of the int64 range.
+func floatToInt(mode FloatToIntMode, v float64) (int64, bool) {
+	switch mode {
+	case FloatToIntFloor:
+		v = math.Floor(v)
+	case FloatToIntTruncate:
+		v = math.Trunc(v)
+	default:
+		v = math.Round(v)
+	}
+	// -2^63 is exactly representable, but 2^63 is beyond math.MaxInt64.
+	if math.IsNaN(v) || v < math.MinInt64 || v >= -math.MinInt64 {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+// latestPoints returns the time series with all but their latest point
+// removed.
+func (c *Converter) latestPoints(ts []*ocmetricdata.TimeSeries) []*ocmetricdata.TimeSeries {
+	latest := make([]*ocmetricdata.TimeSeries, len(ts))
+	for i, t := range ts {
+		if len(t.Points) <= 1 {
+			latest[i] = t
+			continue
+		}
+		last := t.Points[0]
+		for _, p := range t.Points[1:] {
+			if p.Time.After(last.Time) {
+				last = p
+			}
+		}
+		c.stats.CoalescedGaugePoints += len(t.Points) - 1
+		latest[i] = &ocmetricdata.TimeSeries{
+			LabelValues: t.LabelValues,
+			Points:      []ocmetricdata.Point{last},
+			StartTime:   t.StartTime,
+		}
+	}
+	return latest
 }
 
-// convertNumberDataPoints converts OpenCensus TimeSeries to OpenTelemetry DataPoints.
-func convertNumberDataPoints[N int64 | float64](labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) ([]metricdata.DataPoint[N], error) {
+// convertSum converts an OpenCensus cumulative to an OpenTelemetry sum
+// aggregation with the given temporality.
+func convertSum[N int64 | float64](c *Converter, labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries, temporality metricdata.Temporality) (metricdata.Sum[N], error) {
+	points, err := convertNumberDataPoints[N](c, labelKeys, ts, NaNKeep)
+	if c.mergesCollisions() {
+		points, _ = mergeCollidingPoints(points, dataPointKey[N], mergeSumPoint[N])
+	}
+	if c.cfg.counterResetSplit {
+		splitResets(points)
+	}
+	if c.cfg.detectResets {
+		c.detectResets(len(points), func(i int) (attribute.Set, time.Time) {
+			return points[i].Attributes, points[i].StartTime
+		})
+	}
+	if c.cfg.resetHandling != ResetPassthrough {
+		points = handleResets(c, points)
+	}
+	if c.cfg.monotonicCorrection {
+		if n := correctDecreases(points); n > 0 {
+			c.warn(fmt.Errorf("%w: %d of %d points", errSumCorrected, n, len(points)))
+		}
+	}
+	// The monotonicity is detected on the cumulative values.
+	isMonotonic := true
+	if c.cfg.detectNonMonotonic {
+		isMonotonic = monotonic(c, points)
+	}
+	// OpenCensus sums are always Cumulative, so deltas are computed from the
+	// previous conversion.
+	if temporality == metricdata.DeltaTemporality {
+		points = deltaSumPoints(c, points)
+	}
+	return metricdata.Sum[N]{DataPoints: points, Temporality: temporality, IsMonotonic: isMonotonic}, err
+}
+
+// convertNumberDataPoints converts OpenCensus TimeSeries to OpenTelemetry
+// DataPoints. NaN float64 values are handled according to nan.
+func convertNumberDataPoints[N int64 | float64](c *Converter, labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries, nan NaNPolicy) ([]metricdata.DataPoint[N], error) {
 	var points []metricdata.DataPoint[N]
 	var err error
 	for _, t := range ts {
-		attrs, attrsErr := convertAttrs(labelKeys, t.LabelValues)
+		attrs, attrsErr := c.convertAttrs(labelKeys, t.LabelValues)
 		if attrsErr != nil {
-			err = errors.Join(err, attrsErr)
+			err = c.joinErr(err, attrsErr)
 			continue
 		}
+		start := len(points)
 		for _, p := range t.Points {
 			v, ok := p.Value.(N)
 			if !ok {
-				err = errors.Join(err, fmt.Errorf("%w: %q", errMismatchedValueTypes, p.Value))
+				var mismatchErr error
+				if v, ok, mismatchErr = coerceValue[N](c, p.Value); !ok {
+					if mismatchErr != nil {
+						err = c.joinErr(err, mismatchErr)
+					}
+					continue
+				}
+			}
+			p.Time = c.pointTime(p.Time, t.StartTime)
+			if !c.plausibleTime(p.Time) || !c.fresh(p.Time) || !c.includePoint(attrs, p.Time) {
 				continue
 			}
+			if f, isFloat := any(v).(float64); isFloat && math.IsNaN(f) {
+				switch nan {
+				case NaNZero:
+					v = 0
+				case NaNDrop:
+					c.warn(fmt.Errorf("%w: at %v", errNaNValue, p.Time))
+					continue
+				}
+			}
 			points = append(points, metricdata.DataPoint[N]{
 				Attributes: attrs,
-				StartTime:  t.StartTime,
-				Time:       p.Time,
+				StartTime:  c.truncateTime(t.StartTime),
+				Time:       c.truncateTime(p.Time),
 				Value:      v,
 			})
 		}
+		series := points[start:]
+		if c.cfg.sortPointsByTime {
+			sort.SliceStable(series, func(i, j int) bool {
+				return series[i].Time.Before(series[j].Time)
+			})
+		}
+		if c.cfg.monotonicTimestamps {
+			distinctTimes(len(series), func(i int) *time.Time { return &series[i].Time })
+		}
+		if c.cfg.timestampResolution > 0 {
+			points = points[:start+len(latestPerTime(series, func(p metricdata.DataPoint[N]) time.Time { return p.Time }))]
+		}
 	}
 	return points, err
 }
 
+// pointTime returns t, the time of a data point of a time series with start
+// time start. With point time fallback, a zero t is replaced with start, or
+// the current time if start is zero as well.
+func (c *Converter) pointTime(t, start time.Time) time.Time {
+	if !c.cfg.pointTimeFallback || !t.IsZero() {
+		return t
+	}
+	if !start.IsZero() {
+		return start
+	}
+	return c.cfg.now()
+}
+
+// coerceValue returns value, the value of a data point of the current metric
+// that is not of type N, according to the value type mismatch handling. It
+// returns false if the data point is dropped, and the error that drops the
+// metric if it is. Coerced float64 values are rounded like those of
+// WithFloatGaugeToInt.
+func coerceValue[N int64 | float64](c *Converter, value any) (N, bool, error) {
+	mismatchErr := fmt.Errorf("%w: %v", errMismatchedValueTypes, value)
+	switch c.cfg.valueTypeMismatch {
+	case ValueTypeMismatchError:
+		return 0, false, mismatchErr
+	case ValueTypeMismatchCoerce:
+		switch v := value.(type) {
+		case int64:
+			if int64(N(v)) != v {
+				c.warn(fmt.Errorf("%w: %d converted to %v", errValueCoerced, v, N(v)))
+			}
+			return N(v), true, nil
+		case float64:
+			if n, ok := floatToInt(c.cfg.floatGaugeToInt, v); ok {
+				if float64(n) != v {
+					c.warn(fmt.Errorf("%w: %v converted to %d", errValueCoerced, v, n))
+				}
+				return N(n), true, nil
+			}
+		}
+	}
+	c.warn(fmt.Errorf("%w, point dropped", mismatchErr))
+	return 0, false, nil
+}
+
+// includePoint returns false, and counts the data point as filtered, if the
+// point filter excludes the data point of the current metric with attrs and
+// time t.
+func (c *Converter) includePoint(attrs attribute.Set, t time.Time) bool {
+	if c.cfg.pointFilter == nil || c.cfg.pointFilter(c.metricName, attrs, t) {
+		return true
+	}
+	c.stats.FilteredPoints++
+	return false
+}
+
+// plausibleTime returns false, and records a warning, if t is outside of the
+// plausible time window.
+func (c *Converter) plausibleTime(t time.Time) bool {
+	if !c.cfg.checkTimestamps || (!t.Before(c.cfg.minTimestamp) && !t.After(c.cfg.maxTimestamp)) {
+		return true
+	}
+	c.warn(fmt.Errorf("%w: %v not within [%v, %v]", errImplausibleTimestamp, t, c.cfg.minTimestamp, c.cfg.maxTimestamp))
+	return false
+}
+
+// fresh returns false, and counts the data point as aged out, if t is older
+// than the maximum series age.
+func (c *Converter) fresh(t time.Time) bool {
+	if c.cfg.maxSeriesAge <= 0 || !t.Before(c.cfg.now().Add(-c.cfg.maxSeriesAge)) {
+		return true
+	}
+	c.stats.AgedOutPoints++
+	return false
+}
+
+// truncateTime returns t rounded down to a multiple of the timestamp
+// resolution. As rounding down never moves a time past a later one, the
+// order of times is retained, although distinct times can become equal.
+func (c *Converter) truncateTime(t time.Time) time.Time {
+	if c.cfg.timestampResolution <= 0 {
+		return t
+	}
+	return t.Truncate(c.cfg.timestampResolution)
+}
+
+// distinctTimes makes the times of the n data points of a time series
+// distinct by moving each time that is the same as, or between, the original
+// and the adjusted time of the previous data point to a nanosecond after the
+// adjusted time. The timeOf function returns the time of the i-th data point.
+func distinctTimes(n int, timeOf func(i int) *time.Time) {
+	if n == 0 {
+		return
+	}
+	prevOriginal, prev := *timeOf(0), *timeOf(0)
+	for i := 1; i < n; i++ {
+		t := timeOf(i)
+		original := *t
+		if !t.Before(prevOriginal) && !t.After(prev) {
+			*t = prev.Add(time.Nanosecond)
+		}
+		prevOriginal, prev = original, *t
+	}
+}
+
 // convertHistogram converts OpenCensus Distribution timeseries to an
 // OpenTelemetry Histogram aggregation.
-func convertHistogram(labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries) (metricdata.Histogram[float64], error) {
+func (c *Converter) convertHistogram(labelKeys []ocmetricdata.LabelKey, ts []*ocmetricdata.TimeSeries, temporality metricdata.Temporality) (metricdata.Histogram[float64], error) {
 	points := make([]metricdata.HistogramDataPoint[float64], 0, len(ts))
 	var err error
 	for _, t := range ts {
-		attrs, attrsErr := convertAttrs(labelKeys, t.LabelValues)
+		attrs, attrsErr := c.convertAttrs(labelKeys, t.LabelValues)
 		if attrsErr != nil {
-			err = errors.Join(err, attrsErr)
+			err = c.joinErr(err, attrsErr)
 			continue
 		}
+		start := len(points)
 		for _, p := range t.Points {
 			dist, ok := p.Value.(*ocmetricdata.Distribution)
 			if !ok {
-				err = errors.Join(err, fmt.Errorf("%w: %d", errMismatchedValueTypes, p.Value))
+				mismatchErr := fmt.Errorf("%w: %d", errMismatchedValueTypes, p.Value)
+				if c.cfg.valueTypeMismatch == ValueTypeMismatchError {
+					err = c.joinErr(err, mismatchErr)
+				} else {
+					c.warn(fmt.Errorf("%w, point dropped", mismatchErr))
+				}
+				continue
+			}
+			p.Time = c.pointTime(p.Time, t.StartTime)
+			if !c.plausibleTime(p.Time) || !c.fresh(p.Time) || !c.includePoint(attrs, p.Time) {
 				continue
 			}
 			bucketCounts, bucketErr := convertBucketCounts(dist.Buckets)
 			if bucketErr != nil {
-				err = errors.Join(err, bucketErr)
+				err = c.joinErr(err, bucketErr)
 				continue
 			}
 			if dist.Count < 0 {
-				err = errors.Join(err, fmt.Errorf("%w: %d", errNegativeDistributionCount, dist.Count))
+				err = c.joinErr(err, fmt.Errorf("%w: %d", errNegativeDistributionCount, dist.Count))
+				continue
+			}
+			if dist.Count > 0 && len(bucketCounts) > 0 && sumCounts(bucketCounts) == 0 {
+				switch c.cfg.emptyBucketHistogramHandling {
+				case EmptyBucketHistogramDrop:
+					continue
+				case EmptyBucketHistogramPlaceInOverflow:
+					bucketCounts[len(bucketCounts)-1] = uint64(dist.Count)
+				default:
+					err = c.joinErr(err, fmt.Errorf("%w: count %d, all buckets empty", errInconsistentHistogram, dist.Count))
+					continue
+				}
+			}
+			if c.cfg.validateBucketSum && len(bucketCounts) > 0 {
+				if total := sumCounts(bucketCounts); total != uint64(dist.Count) {
+					c.warn(fmt.Errorf("%w: buckets %d, distribution %d", errBucketSumMismatch, total, dist.Count))
+					if c.cfg.dropInvalidBucketSum {
+						continue
+					}
+				}
+			}
+			var bounds []float64
+			if dist.BucketOptions != nil {
+				bounds = dist.BucketOptions.Bounds
+			}
+			if c.cfg.rejectNegativeBounds && len(bounds) > 0 && bounds[0] < 0 {
+				err = c.joinErr(err, fmt.Errorf("%w: %v", errNegativeBounds, bounds))
 				continue
 			}
-			// TODO: handle exemplars
-			points = append(points, metricdata.HistogramDataPoint[float64]{
-				Attributes:   attrs,
-				StartTime:    t.StartTime,
-				Time:         p.Time,
+			bounds, bucketCounts = c.convertBounds(bounds, bucketCounts)
+			if c.cfg.boundsDecimals >= 0 {
+				var roundErr error
+				if bounds, roundErr = roundBounds(bounds, c.cfg.boundsDecimals); roundErr != nil {
+					c.warn(roundErr)
+					continue
+				}
+			}
+			if limit := c.cfg.maxHistogramBuckets; limit > 0 && len(bucketCounts) > limit && len(bounds) == len(bucketCounts)-1 {
+				c.warn(fmt.Errorf("%w: %d buckets merged into %d", errHistogramDownsampled, len(bucketCounts), limit))
+				bounds, bucketCounts = mergeBuckets(bounds, bucketCounts, limit)
+			}
+			ocBuckets := dist.Buckets
+			if c.cfg.validateExemplarBounds && dist.BucketOptions != nil {
+				ocBuckets = c.dropOutOfBoundsExemplars(dist.BucketOptions.Bounds, ocBuckets)
+			}
+			exemplars, exemplarErr := convertExemplars(ocBuckets)
+			if exemplarErr != nil {
+				err = c.joinErr(err, exemplarErr)
+			}
+			if c.cfg.exemplarResolver != nil {
+				exemplars = append(exemplars, c.cfg.exemplarResolver(c.metricName, attrs)...)
+			}
+			sum := dist.Sum
+			if c.cfg.sumDecimals >= 0 {
+				sum = round(sum, c.cfg.sumDecimals)
+			}
+			pointAttrs := attrs
+			if c.cfg.sumOfSquaredDeviationKey != "" && dist.SumOfSquaredDeviation != 0 {
+				pointAttrs = attribute.NewSet(append(attrs.ToSlice(), attribute.Float64(c.cfg.sumOfSquaredDeviationKey, dist.SumOfSquaredDeviation))...)
+			}
+			point := metricdata.HistogramDataPoint[float64]{
+				Attributes:   pointAttrs,
+				StartTime:    c.truncateTime(t.StartTime),
+				Time:         c.truncateTime(p.Time),
 				Count:        uint64(dist.Count),
-				Sum:          dist.Sum,
-				Bounds:       dist.BucketOptions.Bounds,
+				Sum:          sum,
+				Bounds:       bounds,
 				BucketCounts: bucketCounts,
+				Exemplars:    exemplars,
+			}
+			if c.cfg.histogramExtremaFromExemplars {
+				point.Min, point.Max = exemplarExtrema(exemplars)
+			}
+			points = append(points, point)
+		}
+		series := points[start:]
+		if c.cfg.sortPointsByTime {
+			sort.SliceStable(series, func(i, j int) bool {
+				return series[i].Time.Before(series[j].Time)
 			})
 		}
+		if c.cfg.monotonicTimestamps {
+			distinctTimes(len(series), func(i int) *time.Time { return &series[i].Time })
+		}
+		if c.cfg.timestampResolution > 0 {
+			points = points[:start+len(latestPerTime(series, func(p metricdata.HistogramDataPoint[float64]) time.Time { return p.Time }))]
+		}
+	}
+	if c.mergesCollisions() {
+		var mergeErr error
+		points, mergeErr = mergeCollidingPoints(points, histogramPointKey, mergeHistogramPoint)
+		if mergeErr != nil {
+			c.warn(mergeErr)
+		}
+	}
+	if c.cfg.labelDescriptionsAsExemplarAttrs {
+		addLabelDescriptions(labelKeys, points)
+	}
+	for i := range points {
+		if limit := c.cfg.maxExemplarsPerPoint; limit > 0 {
+			points[i].Exemplars = c.limitExemplars(points[i].Exemplars, limit)
+		}
+		sortExemplars(points[i].Exemplars)
+	}
+	if c.cfg.detectResets {
+		c.detectResets(len(points), func(i int) (attribute.Set, time.Time) {
+			return points[i].Attributes, points[i].StartTime
+		})
+	}
+	if temporality == metricdata.DeltaTemporality {
+		points = c.deltaHistogramPoints(points)
+	}
+	return metricdata.Histogram[float64]{DataPoints: points, Temporality: temporality}, err
+}
+
+// limitExemplars returns at most limit of exemplars, in their order. Exemplars
+// with a trace context are retained over those without one, and exemplars
+// with higher values over those with lower values.
+func (c *Converter) limitExemplars(exemplars []metricdata.Exemplar[float64], limit int) []metricdata.Exemplar[float64] {
+	if len(exemplars) <= limit {
+		return exemplars
+	}
+	order := make([]int, len(exemplars))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := exemplars[order[i]], exemplars[order[j]]
+		if traced, bTraced := len(a.TraceID) > 0, len(b.TraceID) > 0; traced != bTraced {
+			return traced
+		}
+		return a.Value > b.Value
+	})
+	retained := make([]bool, len(exemplars))
+	for _, i := range order[:limit] {
+		retained[i] = true
+	}
+	limited := make([]metricdata.Exemplar[float64], 0, limit)
+	for i, e := range exemplars {
+		if retained[i] {
+			limited = append(limited, e)
+		}
+	}
+	c.warn(fmt.Errorf("%w: %d of %d exemplars dropped", errExemplarsTrimmed, len(exemplars)-limit, len(exemplars)))
+	return limited
+}
+
+// sortExemplars sorts exemplars by time, then value, so that their order
+// does not depend on the order of the buckets they were converted from.
+// Exemplars with the same time and value are sorted by trace ID, then span
+// ID.
+func sortExemplars(exemplars []metricdata.Exemplar[float64]) {
+	sort.SliceStable(exemplars, func(i, j int) bool {
+		a, b := exemplars[i], exemplars[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		if n := bytes.Compare(a.TraceID, b.TraceID); n != 0 {
+			return n < 0
+		}
+		return bytes.Compare(a.SpanID, b.SpanID) < 0
+	})
+}
+
+// exemplarExtrema returns the minimum and maximum values of exemplars. They
+// are undefined if there are no exemplars.
+func exemplarExtrema(exemplars []metricdata.Exemplar[float64]) (metricdata.Extrema[float64], metricdata.Extrema[float64]) {
+	if len(exemplars) == 0 {
+		return metricdata.Extrema[float64]{}, metricdata.Extrema[float64]{}
+	}
+	minValue, maxValue := exemplars[0].Value, exemplars[0].Value
+	for _, e := range exemplars[1:] {
+		minValue = math.Min(minValue, e.Value)
+		maxValue = math.Max(maxValue, e.Value)
+	}
+	return metricdata.NewExtrema(minValue), metricdata.NewExtrema(maxValue)
+}
+
+// addLabelDescriptions adds the descriptions of labelKeys to the filtered
+// attributes of the exemplars of the first data point that has exemplars.
+func addLabelDescriptions(labelKeys []ocmetricdata.LabelKey, points []metricdata.HistogramDataPoint[float64]) {
+	var descriptions []attribute.KeyValue
+	for _, k := range labelKeys {
+		if k.Description != "" {
+			descriptions = append(descriptions, attribute.String("label."+k.Key+".description", k.Description))
+		}
+	}
+	if len(descriptions) == 0 {
+		return
+	}
+	for _, p := range points {
+		if len(p.Exemplars) == 0 {
+			continue
+		}
+		for i := range p.Exemplars {
+			// Copy the filtered attributes, as they can share memory with
+			// those of other exemplars, e.g. those of an exemplar resolver.
+			filtered := p.Exemplars[i].FilteredAttributes
+			attrs := make([]attribute.KeyValue, 0, len(filtered)+len(descriptions))
+			attrs = append(append(attrs, filtered...), descriptions...)
+			sortable := attribute.Sortable(attrs)
+			sort.Stable(&sortable)
+			p.Exemplars[i].FilteredAttributes = attrs
+		}
+		return
+	}
+}
+
+// sumCounts returns the sum of counts.
+func sumCounts(counts []uint64) uint64 {
+	var total uint64
+	for _, n := range counts {
+		total += n
 	}
-	return metricdata.Histogram[float64]{DataPoints: points, Temporality: metricdata.CumulativeTemporality}, err
+	return total
 }
 
 // convertBucketCounts converts from OpenCensus bucket counts to slice of uint64.
@@ -170,21 +1241,429 @@ func convertBucketCounts(buckets []ocmetricdata.Bucket) ([]uint64, error) {
 	return bucketCounts, nil
 }
 
+// convertBounds returns the OpenCensus distribution bounds with any trailing
+// +Inf bounds removed. OpenCensus and OpenTelemetry bounds both exclude the
+// implicit +Inf bound of the overflow bucket, so a +Inf bound is a producer
+// error. The counts of buckets above the removed bounds are merged into the
+// overflow bucket so that there is one more bucket count than bounds.
+func (c *Converter) convertBounds(bounds []float64, bucketCounts []uint64) ([]float64, []uint64) {
+	n := len(bounds)
+	for n > 0 && math.IsInf(bounds[n-1], 1) {
+		n--
+	}
+	if n == len(bounds) {
+		return bounds, bucketCounts
+	}
+	c.warn(fmt.Errorf("%w: %v", errInfiniteBound, bounds))
+	if len(bucketCounts) > n+1 {
+		merged := make([]uint64, n+1)
+		copy(merged, bucketCounts[:n])
+		for _, count := range bucketCounts[n:] {
+			merged[n] += count
+		}
+		bucketCounts = merged
+	}
+	return bounds[:n], bucketCounts
+}
+
+// roundBounds returns bounds rounded to decimals decimal places. It returns
+// an error if distinct bounds are equal after rounding.
+func roundBounds(bounds []float64, decimals int) ([]float64, error) {
+	rounded := make([]float64, len(bounds))
+	for i, b := range bounds {
+		rounded[i] = round(b, decimals)
+		if i > 0 && rounded[i] == rounded[i-1] && bounds[i] != bounds[i-1] {
+			return nil, fmt.Errorf("%w: %v to %d decimal places", errBoundsCollisionAfterRounding, bounds, decimals)
+		}
+	}
+	return rounded, nil
+}
+
+// round returns v rounded to decimals decimal places.
+func round(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// mergeBuckets merges adjacent histogram buckets into n buckets. Buckets are
+// distributed as evenly as possible, the counts of merged buckets are summed,
+// and the bounds between merged buckets are dropped.
+func mergeBuckets(bounds []float64, bucketCounts []uint64, n int) ([]float64, []uint64) {
+	m := len(bucketCounts)
+	mergedCounts := make([]uint64, n)
+	for i, count := range bucketCounts {
+		mergedCounts[i*n/m] += count
+	}
+	mergedBounds := make([]float64, n-1)
+	for j := 1; j < n; j++ {
+		// The first bucket merged into bucket j is the smallest i with
+		// i*n/m >= j. The bound below it is kept.
+		first := (j*m + n - 1) / n
+		mergedBounds[j-1] = bounds[first-1]
+	}
+	return mergedBounds, mergedCounts
+}
+
+// dropOutOfBoundsExemplars returns buckets without the exemplars whose value
+// is outside of the [lower, upper) bounds of their bucket, as defined by the
+// OpenCensus bounds. buckets is not modified. Buckets are not checked if
+// their number does not match bounds.
+func (c *Converter) dropOutOfBoundsExemplars(bounds []float64, buckets []ocmetricdata.Bucket) []ocmetricdata.Bucket {
+	if len(buckets) != len(bounds)+1 {
+		return buckets
+	}
+	checked, copied := buckets, false
+	for i, bucket := range buckets {
+		if bucket.Exemplar == nil {
+			continue
+		}
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if i > 0 {
+			lower = bounds[i-1]
+		}
+		if i < len(bounds) {
+			upper = bounds[i]
+		}
+		if v := bucket.Exemplar.Value; v >= lower && v < upper {
+			continue
+		}
+		c.warn(fmt.Errorf("%w: %v in bucket %d [%v, %v)", errExemplarOutOfBounds, bucket.Exemplar.Value, i, lower, upper))
+		if !copied {
+			checked, copied = append([]ocmetricdata.Bucket(nil), buckets...), true
+		}
+		checked[i].Exemplar = nil
+	}
+	return checked
+}
+
+// exemplarKey identifies duplicate exemplars.
+type exemplarKey struct {
+	value float64
+	time  int64
+}
+
+// convertExemplars converts the exemplars of OpenCensus buckets to
+// OpenTelemetry exemplars, in bucket order. Exemplars with the same value and
+// timestamp as an exemplar of a previous bucket are dropped.
+func convertExemplars(buckets []ocmetricdata.Bucket) ([]metricdata.Exemplar[float64], error) {
+	var exemplars []metricdata.Exemplar[float64]
+	var err error
+	seen := make(map[exemplarKey]struct{})
+	for _, bucket := range buckets {
+		if bucket.Exemplar == nil {
+			continue
+		}
+		key := exemplarKey{value: bucket.Exemplar.Value, time: bucket.Exemplar.Timestamp.UnixNano()}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		exemplar, exemplarErr := convertExemplar(bucket.Exemplar)
+		if exemplarErr != nil {
+			err = errors.Join(err, exemplarErr)
+		}
+		exemplars = append(exemplars, exemplar)
+	}
+	return exemplars, err
+}
+
+// convertExemplar converts an OpenCensus exemplar to an OpenTelemetry
+// exemplar. The span context attachment is converted to the trace and span
+// IDs of the exemplar, and all other attachments are converted to filtered
+// attributes.
+func convertExemplar(ocExemplar *ocmetricdata.Exemplar) (metricdata.Exemplar[float64], error) {
+	exemplar := metricdata.Exemplar[float64]{
+		Value: ocExemplar.Value,
+		Time:  ocExemplar.Timestamp,
+	}
+	var err error
+	for k, v := range ocExemplar.Attachments {
+		switch k {
+		case ocmetricdata.AttachmentKeySpanContext:
+			sc, ok := v.(octrace.SpanContext)
+			if !ok {
+				err = errors.Join(err, fmt.Errorf("%w: %T", errInvalidExemplarSpanContext, v))
+				continue
+			}
+			exemplar.SpanID = sc.SpanID[:]
+			exemplar.TraceID = sc.TraceID[:]
+		case attachmentKeySampleRate:
+			rate, ok := toFloat64(v)
+			if !ok {
+				err = errors.Join(err, fmt.Errorf("%w: %v", errInvalidExemplarSampleRate, v))
+				continue
+			}
+			exemplar.FilteredAttributes = append(exemplar.FilteredAttributes, SampleRateKey.Float64(rate))
+		default:
+			exemplar.FilteredAttributes = append(exemplar.FilteredAttributes, convertKV(k, v))
+		}
+	}
+	// Attachments are a map, so sort them to produce deterministic output.
+	sortable := attribute.Sortable(exemplar.FilteredAttributes)
+	sort.Stable(&sortable)
+	return exemplar, err
+}
+
+// toFloat64 returns the value of a numeric OpenCensus attachment as a
+// float64.
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// convertKV converts an OpenCensus attachment to an OpenTelemetry attribute.
+func convertKV(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case string:
+		return attribute.String(key, v)
+	case fmt.Stringer:
+		return attribute.Stringer(key, v)
+	}
+	return attribute.String(key, fmt.Sprintf("%v", value))
+}
+
 // convertAttrs converts from OpenCensus attribute keys and values to an
-// OpenTelemetry attribute Set.
-func convertAttrs(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) (attribute.Set, error) {
+// OpenTelemetry attribute Set, using the attribute cache if it is enabled.
+// Attribute sets are only cached if their conversion recorded no warnings,
+// so that cache hits do not hide them. Enriched attributes depend on the
+// context of the conversion, so they are never cached.
+func (c *Converter) convertAttrs(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) (attribute.Set, error) {
+	if c.attrCache == nil || len(c.enrichment) > 0 || len(keys) != len(values) {
+		return c.buildAttrs(keys, values)
+	}
+	key := c.attributeCacheKey(keys, values)
+	if set, ok := c.attrCache.get(key); ok {
+		return set, nil
+	}
+	issues := c.errCount + c.omittedErrCount
+	set, err := c.buildAttrs(keys, values)
+	if err == nil && c.errCount+c.omittedErrCount == issues {
+		c.attrCache.put(key, set)
+	}
+	return set, err
+}
+
+// buildAttrs converts from OpenCensus attribute keys and values to an
+// OpenTelemetry attribute Set. If multiple present labels have the same key,
+// as given or after mapping, the attribute has the value of the last of them,
+// as attribute.NewSet keeps the last of duplicate keys. Absent labels do not
+// replace the value of earlier labels with the same key.
+func (c *Converter) buildAttrs(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) (attribute.Set, error) {
 	if len(keys) != len(values) {
-		return attribute.NewSet(), fmt.Errorf("%w: keys(%q) values(%q)", errMismatchedAttributeKeyValues, len(keys), len(values))
+		if !c.cfg.tolerateExtraValues {
+			return attribute.NewSet(), fmt.Errorf("%w: keys(%q) values(%q)", errMismatchedAttributeKeyValues, len(keys), len(values))
+		}
+		c.warn(fmt.Errorf("%w: keys(%d) values(%d)", errMismatchedAttributeKeyValues, len(keys), len(values)))
+		keys, values = c.alignLabels(keys, values)
+	}
+	if len(values) == 0 && len(c.enrichment) == 0 {
+		return c.emptyAttrs(), nil
 	}
-	attrs := []attribute.KeyValue{}
+	attrs, buf := c.keyValueBuffer(len(values) + len(c.enrichment))
 	for i, lv := range values {
 		if !lv.Present {
 			continue
 		}
-		attrs = append(attrs, attribute.KeyValue{
-			Key:   attribute.Key(keys[i].Key),
-			Value: attribute.StringValue(lv.Value),
-		})
+		if c.cfg.attributeAllowList != nil {
+			if _, ok := c.cfg.attributeAllowList[keys[i].Key]; !ok {
+				continue
+			}
+		}
+		if c.cfg.maxAttributeCount >= 0 && len(attrs) == c.cfg.maxAttributeCount {
+			c.warn(fmt.Errorf("%w: limit %d", errTooManyAttributes, c.cfg.maxAttributeCount))
+			break
+		}
+		if c.cfg.labelSplitter != nil {
+			if split := c.cfg.labelSplitter(c.metricName, keys[i].Key, lv.Value); len(split) > 0 {
+				if c.cfg.maxAttributeCount >= 0 && len(attrs)+len(split) > c.cfg.maxAttributeCount {
+					c.warn(fmt.Errorf("%w: limit %d", errTooManyAttributes, c.cfg.maxAttributeCount))
+					break
+				}
+				attrs = append(attrs, split...)
+				continue
+			}
+		}
+		key := attribute.Key(keys[i].Key)
+		if c.cfg.attributeKeyMapper != nil || c.cfg.metricScopedKeyMapper != nil {
+			key = c.mapKey(key)
+			for _, attr := range attrs {
+				if attr.Key == key {
+					c.warn(fmt.Errorf("%w: %q mapped to existing key %q", errAttributeKeyCollision, keys[i].Key, key))
+					break
+				}
+			}
+		}
+		key, ok := c.attributeKey(key)
+		if !ok {
+			continue
+		}
+		value := lv.Value
+		if c.cfg.validateUTF8 && !utf8.ValidString(value) {
+			c.warn(fmt.Errorf("%w: value of %q", errInvalidUTF8, key))
+			value = strings.ToValidUTF8(value, string(utf8.RuneError))
+		}
+		attr := attribute.KeyValue{
+			Key:   key,
+			Value: c.convertLabelValue(keys[i].Key, value),
+		}
+		if c.cfg.attributeValueMapper != nil {
+			attr.Value = c.cfg.attributeValueMapper(attr.Key, attr.Value)
+		}
+		attrs = append(attrs, attr)
+	}
+	attrs = c.enrich(attrs)
+	if buf != nil {
+		// attribute.NewSet copies attrs, so the buffer is released once the
+		// set is built.
+		defer c.releaseKeyValueBuffer(buf, attrs)
+	}
+	if len(attrs) == 0 {
+		return c.emptyAttrs(), nil
 	}
 	return attribute.NewSet(attrs...), nil
 }
+
+// enrich returns attrs with the enrichment attributes. Enrichment attributes
+// with the key of one of attrs are only added with enrichment override.
+func (c *Converter) enrich(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if c.cfg.enrichmentOverride {
+		// attribute.NewSet keeps the last of duplicate keys.
+		return append(attrs, c.enrichment...)
+	}
+	n := len(attrs)
+	for _, e := range c.enrichment {
+		found := false
+		for _, attr := range attrs[:n] {
+			if attr.Key == e.Key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			attrs = append(attrs, e)
+		}
+	}
+	return attrs
+}
+
+// keyValueBuffer returns an empty slice to hold n converted attributes. With
+// a key-value buffer pool, the slice is drawn from the pool and the pooled
+// buffer it must be released to is returned.
+func (c *Converter) keyValueBuffer(n int) ([]attribute.KeyValue, *[]attribute.KeyValue) {
+	if c.cfg.keyValueBufferPool == nil {
+		return make([]attribute.KeyValue, 0, n), nil
+	}
+	buf, ok := c.cfg.keyValueBufferPool.Get().(*[]attribute.KeyValue)
+	if !ok || buf == nil {
+		buf = new([]attribute.KeyValue)
+	}
+	return (*buf)[:0], buf
+}
+
+// releaseKeyValueBuffer returns buf, holding attrs, to the key-value buffer
+// pool. The attributes are zeroed so that the pool does not retain their
+// values.
+func (c *Converter) releaseKeyValueBuffer(buf *[]attribute.KeyValue, attrs []attribute.KeyValue) {
+	for i := range attrs {
+		attrs[i] = attribute.KeyValue{}
+	}
+	*buf = attrs[:0]
+	c.cfg.keyValueBufferPool.Put(buf)
+}
+
+// alignLabels returns keys and values with the same length. Values without a
+// key are given keys with the extra value key prefix followed by their index,
+// and keys without a value are given absent values.
+func (c *Converter) alignLabels(keys []ocmetricdata.LabelKey, values []ocmetricdata.LabelValue) ([]ocmetricdata.LabelKey, []ocmetricdata.LabelValue) {
+	if len(values) < len(keys) {
+		aligned := make([]ocmetricdata.LabelValue, len(keys))
+		copy(aligned, values)
+		return keys, aligned
+	}
+	aligned := make([]ocmetricdata.LabelKey, len(values))
+	copy(aligned, keys)
+	for i := len(keys); i < len(values); i++ {
+		aligned[i] = ocmetricdata.LabelKey{Key: c.cfg.extraValueKeyPrefix + strconv.Itoa(i)}
+	}
+	return aligned, values
+}
+
+// emptyAttrs returns the attributes of data points without attributes.
+func (c *Converter) emptyAttrs() attribute.Set {
+	if c.cfg.emptyAttributeSetKey != nil {
+		return attribute.NewSet(*c.cfg.emptyAttributeSetKey)
+	}
+	return *attribute.EmptySet()
+}
+
+// mapKey returns key, an attribute key of the current metric, mapped by the
+// attribute key mappers.
+func (c *Converter) mapKey(key attribute.Key) attribute.Key {
+	if c.cfg.attributeKeyMapper != nil {
+		key = c.cfg.attributeKeyMapper(key)
+	}
+	if c.cfg.metricScopedKeyMapper != nil {
+		key = c.cfg.metricScopedKeyMapper(c.metricName, key)
+	}
+	return key
+}
+
+// countAttributeSets returns the number of distinct attribute sets of the
+// data points of agg.
+func countAttributeSets(agg metricdata.Aggregation) int {
+	seen := make(map[attribute.Distinct]struct{})
+	eachAttributeSet(agg, func(s attribute.Set) {
+		seen[s.Equivalent()] = struct{}{}
+	})
+	return len(seen)
+}
+
+// eachAttributeSet calls f with the attribute set of each data point of agg.
+func eachAttributeSet(agg metricdata.Aggregation, f func(attribute.Set)) {
+	switch a := agg.(type) {
+	case metricdata.Gauge[int64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	case metricdata.Gauge[float64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	case metricdata.Sum[int64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	case metricdata.Sum[float64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	case metricdata.Histogram[int64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	case metricdata.Histogram[float64]:
+		for _, p := range a.DataPoints {
+			f(p.Attributes)
+		}
+	}
+}
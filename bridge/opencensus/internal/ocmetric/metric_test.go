@@ -15,13 +15,27 @@
 package internal // import "go.opentelemetry.io/otel/bridge/opencensus/opencensusmetric/internal"
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	ocmetricdata "go.opencensus.io/metric/metricdata"
+	octrace "go.opencensus.io/trace"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 )
@@ -30,9 +44,14 @@ func TestConvertMetrics(t *testing.T) {
 	endTime1 := time.Now()
 	endTime2 := endTime1.Add(-time.Millisecond)
 	startTime := endTime2.Add(-time.Minute)
+	spanContext := octrace.SpanContext{
+		TraceID: octrace.TraceID([16]byte{1}),
+		SpanID:  octrace.SpanID([8]byte{2}),
+	}
 	for _, tc := range []struct {
 		desc        string
 		input       []*ocmetricdata.Metric
+		opts        []Option
 		expected    []metricdata.Metrics
 		expectedErr error
 	}{
@@ -574,69 +593,3903 @@ func TestConvertMetrics(t *testing.T) {
 			},
 			expectedErr: errAggregationType,
 		},
-	} {
-		t.Run(tc.desc, func(t *testing.T) {
-			output, err := ConvertMetrics(tc.input)
-			if !errors.Is(err, tc.expectedErr) {
-				t.Errorf("convertAggregation(%+v) = err(%v), want err(%v)", tc.input, err, tc.expectedErr)
-			}
-			metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
-				metricdata.ScopeMetrics{Metrics: tc.expected},
-				metricdata.ScopeMetrics{Metrics: output})
-		})
-	}
-}
-
-func TestConvertAttributes(t *testing.T) {
-	setWithMultipleKeys := attribute.NewSet(
-		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1")},
-		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.StringValue("2")},
-	)
-	for _, tc := range []struct {
-		desc        string
-		inputKeys   []ocmetricdata.LabelKey
-		inputValues []ocmetricdata.LabelValue
-		expected    *attribute.Set
-		expectedErr error
-	}{
 		{
-			desc:     "no attributes",
-			expected: attribute.EmptySet(),
+			desc: "histogram with exemplars",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 3,
+									Sum:   4.5,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{1.0, 2.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{
+											Count: 1,
+											Exemplar: &ocmetricdata.Exemplar{
+												Value:     0.5,
+												Timestamp: endTime1,
+												Attachments: map[string]interface{}{
+													ocmetricdata.AttachmentKeySpanContext: spanContext,
+													"SampleRate":                          10,
+													"bool":                                true,
+												},
+											},
+										},
+										{Count: 0},
+										{
+											Count: 2,
+											Exemplar: &ocmetricdata.Exemplar{
+												Value:     2.5,
+												Timestamp: endTime2,
+												Attachments: map[string]interface{}{
+													"SampleRate": "0.25",
+													"string":     "foo",
+												},
+											},
+										},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/histogram-a",
+					Description: "a testing histogram",
+					Unit:        "1",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   *attribute.EmptySet(),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        3,
+								Sum:          4.5,
+								Bounds:       []float64{1.0, 2.0},
+								BucketCounts: []uint64{1, 0, 2},
+								Exemplars: []metricdata.Exemplar[float64]{
+									{
+										Value:   0.5,
+										Time:    endTime1,
+										TraceID: spanContext.TraceID[:],
+										SpanID:  spanContext.SpanID[:],
+										FilteredAttributes: []attribute.KeyValue{
+											attribute.Bool("bool", true),
+											SampleRateKey.Float64(10),
+										},
+									},
+									{
+										Value: 2.5,
+										Time:  endTime2,
+										FilteredAttributes: []attribute.KeyValue{
+											SampleRateKey.Float64(0.25),
+											attribute.String("string", "foo"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 		{
-			desc:        "different numbers of keys and values",
-			inputKeys:   []ocmetricdata.LabelKey{{Key: "foo"}},
-			expected:    attribute.EmptySet(),
-			expectedErr: errMismatchedAttributeKeyValues,
+			desc: "histogram with invalid exemplar attachments",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 1,
+									Sum:   0.5,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{1.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{
+											Count: 1,
+											Exemplar: &ocmetricdata.Exemplar{
+												Value:     0.5,
+												Timestamp: endTime1,
+												Attachments: map[string]interface{}{
+													ocmetricdata.AttachmentKeySpanContext: "not a span context",
+												},
+											},
+										},
+										{Count: 0},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			expectedErr: errInvalidExemplarSpanContext,
 		},
 		{
-			desc:      "multiple keys and values",
-			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
-			inputValues: []ocmetricdata.LabelValue{
-				{Value: "1", Present: true},
-				{Value: "2", Present: true},
+			desc: "histogram with trailing +Inf bound",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 8,
+									Sum:   100.0,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{1.0, 2.0, math.Inf(1)},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{Count: 1},
+										{Count: 2},
+										{Count: 4},
+										{Count: 1},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
 			},
-			expected: &setWithMultipleKeys,
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/histogram-a",
+					Description: "a testing histogram",
+					Unit:        "1",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   *attribute.EmptySet(),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        8,
+								Sum:          100.0,
+								Bounds:       []float64{1.0, 2.0},
+								BucketCounts: []uint64{1, 2, 5},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errInfiniteBound,
 		},
 		{
-			desc:      "multiple keys and values with some not present",
-			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}},
-			inputValues: []ocmetricdata.LabelValue{
-				{Value: "1", Present: true},
-				{Value: "2", Present: true},
-				{Present: false},
+			desc: "histogram with more buckets than the bucket limit",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 21,
+									Sum:   100.0,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{1.0, 2.0, 3.0, 4.0, 5.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{Count: 1},
+										{Count: 2},
+										{Count: 3},
+										{Count: 4},
+										{Count: 5},
+										{Count: 6},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
 			},
-			expected: &setWithMultipleKeys,
+			opts: []Option{WithMaxHistogramBuckets(3)},
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/histogram-a",
+					Description: "a testing histogram",
+					Unit:        "1",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   *attribute.EmptySet(),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        21,
+								Sum:          100.0,
+								Bounds:       []float64{2.0, 4.0},
+								BucketCounts: []uint64{3, 7, 11},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errHistogramDownsampled,
 		},
-	} {
-		t.Run(tc.desc, func(t *testing.T) {
-			output, err := convertAttrs(tc.inputKeys, tc.inputValues)
-			if !errors.Is(err, tc.expectedErr) {
-				t.Errorf("convertAttrs(keys: %v, values: %v) = err(%v), want err(%v)", tc.inputKeys, tc.inputValues, err, tc.expectedErr)
-			}
-			if !output.Equals(tc.expected) {
-				t.Errorf("convertAttrs(keys: %v, values: %v) = %+v, want %+v", tc.inputKeys, tc.inputValues, output.ToSlice(), tc.expected.ToSlice())
-			}
+		{
+			desc: "series merged after attribute allow list",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:      "foo.com/sum-a",
+						Type:      ocmetricdata.TypeCumulativeInt64,
+						LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "x", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime1, 3)},
+							StartTime:   startTime,
+						}, {
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "y", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime1, 4)},
+							StartTime:   endTime2,
+						}, {
+							LabelValues: []ocmetricdata.LabelValue{{Value: "2", Present: true}, {Value: "y", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime1, 5)},
+							StartTime:   startTime,
+						},
+					},
+				}, {
+					Descriptor: ocmetricdata.Descriptor{
+						Name:      "foo.com/gauge-a",
+						Type:      ocmetricdata.TypeGaugeFloat64,
+						LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "x", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewFloat64Point(endTime1, 1.5)},
+						}, {
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "y", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewFloat64Point(endTime1, 2.5)},
+						},
+					},
+				}, {
+					Descriptor: ocmetricdata.Descriptor{
+						Name:      "foo.com/histogram-a",
+						Type:      ocmetricdata.TypeCumulativeDistribution,
+						LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "x", Present: true}},
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count:         3,
+									Sum:           3.0,
+									BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1.0}},
+									Buckets:       []ocmetricdata.Bucket{{Count: 1}, {Count: 2}},
+								}),
+							},
+							StartTime: startTime,
+						}, {
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {Value: "y", Present: true}},
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count:         2,
+									Sum:           1.0,
+									BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1.0}},
+									Buckets:       []ocmetricdata.Bucket{{Count: 2}, {Count: 0}},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			opts: []Option{WithAttributeAllowList("a")},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/sum-a",
+					Data: metricdata.Sum[int64]{
+						IsMonotonic: true,
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{
+								Attributes: attribute.NewSet(attribute.String("a", "1")),
+								StartTime:  startTime,
+								Time:       endTime1,
+								Value:      7,
+							}, {
+								Attributes: attribute.NewSet(attribute.String("a", "2")),
+								StartTime:  startTime,
+								Time:       endTime1,
+								Value:      5,
+							},
+						},
+					},
+				}, {
+					Name: "foo.com/gauge-a",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{
+							{
+								Attributes: attribute.NewSet(attribute.String("a", "1")),
+								Time:       endTime1,
+								Value:      2.5,
+							},
+						},
+					},
+				}, {
+					Name: "foo.com/histogram-a",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   attribute.NewSet(attribute.String("a", "1")),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        5,
+								Sum:          4.0,
+								Bounds:       []float64{1.0},
+								BucketCounts: []uint64{3, 2},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "series merged after attribute key mapping",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:      "foo.com/sum-a",
+						Type:      ocmetricdata.TypeCumulativeInt64,
+						LabelKeys: []ocmetricdata.LabelKey{{Key: "A"}, {Key: "a"}},
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}, {}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime1, 3)},
+							StartTime:   startTime,
+						}, {
+							LabelValues: []ocmetricdata.LabelValue{{}, {Value: "1", Present: true}},
+							Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime1, 4)},
+							StartTime:   startTime,
+						},
+					},
+				},
+			},
+			opts: []Option{WithAttributeKeyMapper(func(k attribute.Key) attribute.Key {
+				return attribute.Key(strings.ToLower(string(k)))
+			})},
+			expected: []metricdata.Metrics{
+				{
+					Name: "foo.com/sum-a",
+					Data: metricdata.Sum[int64]{
+						IsMonotonic: true,
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{
+								Attributes: attribute.NewSet(attribute.String("a", "1")),
+								StartTime:  startTime,
+								Time:       endTime1,
+								Value:      7,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "histogram with negative bounds",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 4,
+									Sum:   -2.0,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{-10.0, 0.0, 10.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{Count: 0},
+										{Count: 2},
+										{Count: 2},
+										{Count: 0},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/histogram-a",
+					Description: "a testing histogram",
+					Unit:        "1",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{
+							{
+								Attributes:   *attribute.EmptySet(),
+								StartTime:    startTime,
+								Time:         endTime1,
+								Count:        4,
+								Sum:          -2.0,
+								Bounds:       []float64{-10.0, 0.0, 10.0},
+								BucketCounts: []uint64{0, 2, 2, 0},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "histogram with negative bounds rejected",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/histogram-a",
+						Description: "a testing histogram",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeCumulativeDistribution,
+					},
+					TimeSeries: []*ocmetricdata.TimeSeries{
+						{
+							Points: []ocmetricdata.Point{
+								ocmetricdata.NewDistributionPoint(endTime1, &ocmetricdata.Distribution{
+									Count: 4,
+									Sum:   -2.0,
+									BucketOptions: &ocmetricdata.BucketOptions{
+										Bounds: []float64{-10.0, 0.0, 10.0},
+									},
+									Buckets: []ocmetricdata.Bucket{
+										{Count: 0},
+										{Count: 2},
+										{Count: 2},
+										{Count: 0},
+									},
+								}),
+							},
+							StartTime: startTime,
+						},
+					},
+				},
+			},
+			opts:        []Option{WithRejectNegativeBounds()},
+			expected:    []metricdata.Metrics{},
+			expectedErr: errNegativeBounds,
+		},
+		{
+			desc: "metric with empty name",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Description: "a testing gauge",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeGaugeInt64,
+					},
+				},
+			},
+			expected:    []metricdata.Metrics{},
+			expectedErr: errEmptyMetricName,
+		},
+		{
+			desc: "metric with empty name and default metric name",
+			input: []*ocmetricdata.Metric{
+				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name:        "foo.com/gauge-a",
+						Description: "a testing gauge",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeGaugeInt64,
+					},
+				}, {
+					Descriptor: ocmetricdata.Descriptor{
+						Description: "a testing gauge",
+						Unit:        ocmetricdata.UnitDimensionless,
+						Type:        ocmetricdata.TypeGaugeInt64,
+					},
+				},
+			},
+			opts: []Option{WithDefaultMetricName("unnamed_")},
+			expected: []metricdata.Metrics{
+				{
+					Name:        "foo.com/gauge-a",
+					Description: "a testing gauge",
+					Unit:        "1",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{},
+					},
+				}, {
+					Name:        "unnamed_1",
+					Description: "a testing gauge",
+					Unit:        "1",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(tc.input, tc.opts...)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("convertAggregation(%+v) = err(%v), want err(%v)", tc.input, err, tc.expectedErr)
+			}
+			metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+				metricdata.ScopeMetrics{Metrics: tc.expected},
+				metricdata.ScopeMetrics{Metrics: output})
+		})
+	}
+}
+
+func TestConverterLatestGaugePointOnly(t *testing.T) {
+	endTime1 := time.Now()
+	endTime2 := endTime1.Add(-time.Millisecond)
+	startTime := endTime2.Add(-time.Minute)
+	series := []*ocmetricdata.TimeSeries{
+		{
+			Points: []ocmetricdata.Point{
+				ocmetricdata.NewInt64Point(endTime2, 1),
+				ocmetricdata.NewInt64Point(endTime1, 2),
+				ocmetricdata.NewInt64Point(endTime2, 3),
+			},
+			StartTime: startTime,
+		},
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: series,
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeInt64,
+			},
+			TimeSeries: series,
+		},
+	}
+	expected := []metricdata.Metrics{
+		{
+			Name: "foo.com/gauge-a",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: *attribute.EmptySet(),
+						StartTime:  startTime,
+						Time:       endTime1,
+						Value:      2,
+					},
+				},
+			},
+		}, {
+			Name: "foo.com/sum-a",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: *attribute.EmptySet(),
+						StartTime:  startTime,
+						Time:       endTime2,
+						Value:      1,
+					}, {
+						Attributes: *attribute.EmptySet(),
+						StartTime:  startTime,
+						Time:       endTime1,
+						Value:      2,
+					}, {
+						Attributes: *attribute.EmptySet(),
+						StartTime:  startTime,
+						Time:       endTime2,
+						Value:      3,
+					},
+				},
+			},
+		},
+	}
+
+	c := NewConverter(WithLatestGaugePointOnly())
+	output, err := c.ConvertMetrics(input)
+	if err != nil {
+		t.Fatalf("ConvertMetrics(%+v) = err(%v), want nil", input, err)
+	}
+	metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+		metricdata.ScopeMetrics{Metrics: expected},
+		metricdata.ScopeMetrics{Metrics: output})
+	if got := c.Stats().CoalescedGaugePoints; got != 2 {
+		t.Errorf("Stats().CoalescedGaugePoints = %d, want 2", got)
+	}
+	// Original time series must not be modified.
+	if len(series[0].Points) != 3 {
+		t.Errorf("input time series modified: got %d points, want 3", len(series[0].Points))
+	}
+}
+
+func TestConverterStatsAttributeSets(t *testing.T) {
+	now := time.Now()
+	series := func(value string) *ocmetricdata.TimeSeries {
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+		}
+	}
+	gauge := func(name string, ts ...*ocmetricdata.TimeSeries) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: ts,
+		}
+	}
+
+	c := NewConverter()
+	assert.Empty(t, c.Stats().AttributeSets)
+	_, err := c.ConvertMetrics([]*ocmetricdata.Metric{
+		gauge("foo.com/gauge-a", series("1"), series("2"), series("1")),
+		gauge("foo.com/gauge-b"),
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/summary-a",
+				Type: ocmetricdata.TypeSummary,
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, map[string]int{
+		"foo.com/gauge-a": 2,
+		"foo.com/gauge-b": 0,
+	}, c.Stats().AttributeSets, "dropped metrics must not be counted")
+
+	_, err = c.ConvertMetrics([]*ocmetricdata.Metric{gauge("foo.com/gauge-b", series("1"))})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"foo.com/gauge-b": 1}, c.Stats().AttributeSets)
+}
+
+func TestConverterPointFilter(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-time.Hour)
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/sum-a",
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(stale, 1),
+						ocmetricdata.NewInt64Point(now, 2),
+					},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "drop", Present: true}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 3)},
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(stale, &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}),
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}),
+				},
+			}},
+		},
+	}
+	var names []string
+	filter := func(metricName string, attrs attribute.Set, t time.Time) bool {
+		names = append(names, metricName)
+		if v, ok := attrs.Value("a"); ok && v.AsString() == "drop" {
+			return false
+		}
+		return !t.Before(now.Add(-time.Minute))
+	}
+
+	c := NewConverter(WithPointFilter(filter))
+	output, err := c.ConvertMetrics(input)
+	require.NoError(t, err)
+	require.Len(t, output, 2)
+	sumPoints := output[0].Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, sumPoints, 1)
+	assert.Equal(t, int64(2), sumPoints[0].Value)
+	histogramPoints := output[1].Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, histogramPoints, 1)
+	assert.Equal(t, now, histogramPoints[0].Time)
+	assert.Equal(t, 3, c.Stats().FilteredPoints)
+	assert.Equal(t, []string{"foo.com/sum-a", "foo.com/sum-a", "foo.com/sum-a", "foo.com/histogram-a", "foo.com/histogram-a"}, names)
+
+	c = NewConverter()
+	output, err = c.ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Len(t, output[0].Data.(metricdata.Sum[int64]).DataPoints, 3)
+	assert.Len(t, output[1].Data.(metricdata.Histogram[float64]).DataPoints, 2)
+	assert.Zero(t, c.Stats().FilteredPoints)
+}
+
+func TestConverterSortPointsByTime(t *testing.T) {
+	endTime1 := time.Now()
+	endTime2 := endTime1.Add(-time.Millisecond)
+	startTime := endTime2.Add(-time.Minute)
+	dist := &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge-a",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(endTime1, 1),
+						ocmetricdata.NewInt64Point(startTime, 2),
+						ocmetricdata.NewInt64Point(endTime2, 3),
+					},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "2", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(endTime2, 4),
+						ocmetricdata.NewInt64Point(startTime, 5),
+					},
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(endTime1, dist),
+						ocmetricdata.NewDistributionPoint(endTime2, dist),
+					},
+					StartTime: startTime,
+				},
+			},
+		},
+	}
+
+	output, err := ConvertMetrics(input, WithSortPointsByTime())
+	if err != nil {
+		t.Fatalf("ConvertMetrics(%+v) = err(%v), want nil", input, err)
+	}
+	require.Len(t, output, 2)
+
+	gauge, ok := output[0].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	var values []int64
+	for _, dp := range gauge.DataPoints {
+		values = append(values, dp.Value)
+	}
+	// Points are sorted within, not across, time series.
+	assert.Equal(t, []int64{2, 3, 1, 5, 4}, values)
+
+	histogram, ok := output[1].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 2)
+	assert.Equal(t, endTime2, histogram.DataPoints[0].Time)
+	assert.Equal(t, endTime1, histogram.DataPoints[1].Time)
+}
+
+func TestConverterMonotonicTimestamps(t *testing.T) {
+	// A time with nanosecond precision, and a time truncated to milliseconds
+	// as after a protobuf round-trip.
+	precise := time.Unix(1700000000, 123456789)
+	truncated := precise.Truncate(time.Millisecond)
+	dist := &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge-a",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(truncated, 1),
+						ocmetricdata.NewInt64Point(truncated, 2),
+						ocmetricdata.NewInt64Point(truncated.Add(time.Nanosecond), 3),
+						ocmetricdata.NewInt64Point(truncated.Add(-time.Millisecond), 4),
+						ocmetricdata.NewInt64Point(precise, 5),
+					},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "2", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(truncated, 6),
+					},
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(truncated, dist),
+						ocmetricdata.NewDistributionPoint(truncated, dist),
+					},
+				},
+			},
+		},
+	}
+	times := func(t *testing.T, output []metricdata.Metrics) ([]time.Time, []time.Time) {
+		require.Len(t, output, 2)
+		var gaugeTimes, histogramTimes []time.Time
+		for _, dp := range output[0].Data.(metricdata.Gauge[int64]).DataPoints {
+			gaugeTimes = append(gaugeTimes, dp.Time)
+		}
+		for _, dp := range output[1].Data.(metricdata.Histogram[float64]).DataPoints {
+			histogramTimes = append(histogramTimes, dp.Time)
+		}
+		return gaugeTimes, histogramTimes
+	}
+
+	// Times, including their nanoseconds, are converted unchanged by default.
+	output, err := ConvertMetrics(input)
+	require.NoError(t, err)
+	gaugeTimes, histogramTimes := times(t, output)
+	assert.Equal(t, []time.Time{
+		truncated,
+		truncated,
+		truncated.Add(time.Nanosecond),
+		truncated.Add(-time.Millisecond),
+		precise,
+		truncated,
+	}, gaugeTimes)
+	assert.Equal(t, []time.Time{truncated, truncated}, histogramTimes)
+
+	output, err = ConvertMetrics(input, WithMonotonicTimestamps())
+	require.NoError(t, err)
+	gaugeTimes, histogramTimes = times(t, output)
+	assert.Equal(t, []time.Time{
+		truncated,
+		truncated.Add(time.Nanosecond),
+		truncated.Add(2 * time.Nanosecond),
+		// Earlier points are not moved.
+		truncated.Add(-time.Millisecond),
+		precise,
+		// Time series are made distinct independently.
+		truncated,
+	}, gaugeTimes)
+	assert.Equal(t, []time.Time{truncated, truncated.Add(time.Nanosecond)}, histogramTimes)
+}
+
+func TestConverterHistogramExtremaFromExemplars(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							Count:         3,
+							Sum:           4.5,
+							BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1, 2}},
+							Buckets: []ocmetricdata.Bucket{
+								{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+								{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 1.5, Timestamp: now}},
+								{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 2.5, Timestamp: now}},
+							},
+						}),
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							BucketOptions: &ocmetricdata.BucketOptions{},
+						}),
+					},
+				},
+			},
+		},
+	}
+	extrema := func(t *testing.T, opts ...Option) [][2]metricdata.Extrema[float64] {
+		output, err := ConvertMetrics(input, opts...)
+		require.NoError(t, err)
+		require.Len(t, output, 1)
+		var e [][2]metricdata.Extrema[float64]
+		for _, p := range output[0].Data.(metricdata.Histogram[float64]).DataPoints {
+			e = append(e, [2]metricdata.Extrema[float64]{p.Min, p.Max})
+		}
+		return e
+	}
+
+	assert.Equal(t, [][2]metricdata.Extrema[float64]{
+		{metricdata.NewExtrema(0.5), metricdata.NewExtrema(2.5)},
+		{},
+	}, extrema(t, WithHistogramExtremaFromExemplars()))
+	assert.Equal(t, [][2]metricdata.Extrema[float64]{{}, {}}, extrema(t))
+}
+
+func TestConverterExemplarResolver(t *testing.T) {
+	now := time.Now()
+	traceID := octrace.TraceID([16]byte{1})
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/histogram-a",
+				Type:      ocmetricdata.TypeCumulativeDistribution,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "hello", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							Count:         2,
+							Sum:           3,
+							BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+							Buckets: []ocmetricdata.Bucket{
+								{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+								{Count: 1},
+							},
+						}),
+					},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "world", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+							Buckets:       []ocmetricdata.Bucket{{}, {}},
+						}),
+					},
+				},
+			},
+		},
+	}
+	resolved := metricdata.Exemplar[float64]{Value: 2.5, Time: now, TraceID: traceID[:]}
+	var calls []string
+	resolver := func(metricName string, attrs attribute.Set) []metricdata.Exemplar[float64] {
+		v, _ := attrs.Value("a")
+		calls = append(calls, metricName+" "+v.AsString())
+		if v.AsString() != "hello" {
+			return nil
+		}
+		return []metricdata.Exemplar[float64]{resolved}
+	}
+
+	output, err := ConvertMetrics(input, WithExemplarResolver(resolver), WithMetricNamePrefix("app"), WithHistogramExtremaFromExemplars())
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, []string{"foo.com/histogram-a hello", "foo.com/histogram-a world"}, calls)
+	points := output[0].Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, points, 2)
+	assert.Equal(t, []metricdata.Exemplar[float64]{{Value: 0.5, Time: now}, resolved}, points[0].Exemplars)
+	assert.Equal(t, metricdata.NewExtrema(0.5), points[0].Min)
+	assert.Equal(t, metricdata.NewExtrema(2.5), points[0].Max)
+	assert.Empty(t, points[1].Exemplars)
+}
+
+func TestConverterExemplarOrdering(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Second)
+	spanContext := func(b byte) octrace.SpanContext {
+		return octrace.SpanContext{TraceID: octrace.TraceID([16]byte{b}), SpanID: octrace.SpanID([8]byte{b})}
+	}
+	exemplar := func(value float64, t time.Time, sc octrace.SpanContext) *ocmetricdata.Exemplar {
+		return &ocmetricdata.Exemplar{
+			Value:       value,
+			Timestamp:   t,
+			Attachments: map[string]interface{}{ocmetricdata.AttachmentKeySpanContext: sc},
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         4,
+						Sum:           13,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{2, 6, 10}},
+						Buckets: []ocmetricdata.Bucket{
+							{Count: 1, Exemplar: exemplar(1, later, spanContext(1))},
+							{Count: 1, Exemplar: exemplar(5, now, spanContext(2))},
+							{Count: 1, Exemplar: exemplar(7, now, spanContext(3))},
+							{Count: 1},
+						},
+					}),
+				},
+			}},
+		},
+	}
+	resolver := func(string, attribute.Set) []metricdata.Exemplar[float64] {
+		return []metricdata.Exemplar[float64]{
+			{Value: 5, Time: now, TraceID: []byte{0}},
+			{Value: 0.5, Time: now},
+		}
+	}
+	values := func(exemplars []metricdata.Exemplar[float64]) []float64 {
+		var values []float64
+		for _, e := range exemplars {
+			values = append(values, e.Value)
+		}
+		return values
+	}
+
+	c := NewConverter(WithExemplarResolver(resolver))
+	output, err := c.ConvertMetrics(input)
+	require.NoError(t, err)
+	expected := output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Exemplars
+	assert.Equal(t, []float64{0.5, 5, 5, 7, 1}, values(expected))
+	assert.Equal(t, []byte{0}, expected[1].TraceID, "exemplars with the same time and value are sorted by trace ID")
+	for i := 0; i < 10; i++ {
+		output, err := c.ConvertMetrics(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Exemplars)
+	}
+}
+
+func TestConverterValidateExemplarBounds(t *testing.T) {
+	now := time.Now()
+	exemplar := func(value float64) *ocmetricdata.Exemplar {
+		return &ocmetricdata.Exemplar{Value: value, Timestamp: now}
+	}
+	histogram := func(buckets ...ocmetricdata.Bucket) []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{
+			{
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/histogram-a",
+					Type: ocmetricdata.TypeCumulativeDistribution,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+							Count:         int64(len(buckets)),
+							BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{2, 6}},
+							Buckets:       buckets,
+						}),
+					},
+				}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		desc     string
+		input    []*ocmetricdata.Metric
+		options  []Option
+		expected []float64
+		dropped  int
+	}{
+		{
+			desc: "in range",
+			input: histogram(
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(-5)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(2)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(100)},
+			),
+			options:  []Option{WithValidateExemplarBounds()},
+			expected: []float64{-5, 2, 100},
+		},
+		{
+			desc: "out of range",
+			input: histogram(
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(2)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(4)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(6)},
+			),
+			options:  []Option{WithValidateExemplarBounds()},
+			expected: []float64{4, 6},
+			dropped:  1,
+		},
+		{
+			desc: "upper bound is exclusive",
+			input: histogram(
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(1)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(6)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(math.NaN())},
+			),
+			options: []Option{WithValidateExemplarBounds()},
+			// NaN values are outside of every bucket.
+			expected: []float64{1},
+			dropped:  2,
+		},
+		{
+			desc: "not validated by default",
+			input: histogram(
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(2)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(4)},
+				ocmetricdata.Bucket{Count: 1, Exemplar: exemplar(1)},
+			),
+			expected: []float64{2, 4, 1},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			before := tc.input[0].TimeSeries[0].Points[0].Value.(*ocmetricdata.Distribution).Buckets
+			exemplars := make([]*ocmetricdata.Exemplar, len(before))
+			for i, b := range before {
+				exemplars[i] = b.Exemplar
+			}
+
+			output, err := ConvertMetrics(tc.input, tc.options...)
+			if tc.dropped == 0 {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errExemplarOutOfBounds)
+				assert.Equal(t, tc.dropped, strings.Count(err.Error(), errExemplarOutOfBounds.Error()))
+			}
+			require.Len(t, output, 1)
+			var values []float64
+			for _, e := range output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Exemplars {
+				values = append(values, e.Value)
+			}
+			assert.ElementsMatch(t, tc.expected, values)
+			for i, b := range before {
+				assert.Same(t, exemplars[i], b.Exemplar, "input is not modified")
+			}
+		})
+	}
+}
+
+func TestConvertMetricsWithContext(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge-a",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "tenant"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "hello", Present: true}, {Value: "acme", Present: true}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "world", Present: true}, {}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 2)},
+				},
+			},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets:       []ocmetricdata.Bucket{{}, {}},
+					}),
+				},
+			}},
+		},
+	}
+	member, err := baggage.NewMember("tenant", "initech")
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	enrich := func(ctx context.Context) []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("tenant", baggage.FromContext(ctx).Member("tenant").Value()),
+			attribute.String("region", "eu"),
+			{},
+		}
+	}
+	attributeSets := func(metrics []metricdata.Metrics) []attribute.Set {
+		var sets []attribute.Set
+		for _, m := range metrics {
+			eachAttributeSet(m.Data, func(s attribute.Set) { sets = append(sets, s) })
+		}
+		return sets
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		opts     []Option
+		expected []attribute.Set
+	}{
+		{
+			desc: "labels take precedence",
+			expected: []attribute.Set{
+				attribute.NewSet(attribute.String("a", "hello"), attribute.String("tenant", "acme"), attribute.String("region", "eu")),
+				attribute.NewSet(attribute.String("a", "world"), attribute.String("tenant", "initech"), attribute.String("region", "eu")),
+				attribute.NewSet(attribute.String("tenant", "initech"), attribute.String("region", "eu")),
+			},
+		},
+		{
+			desc: "enrichment override",
+			opts: []Option{WithEnrichmentOverride()},
+			expected: []attribute.Set{
+				attribute.NewSet(attribute.String("a", "hello"), attribute.String("tenant", "initech"), attribute.String("region", "eu")),
+				attribute.NewSet(attribute.String("a", "world"), attribute.String("tenant", "initech"), attribute.String("region", "eu")),
+				attribute.NewSet(attribute.String("tenant", "initech"), attribute.String("region", "eu")),
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetricsWithContext(ctx, input, enrich, tc.opts...)
+			require.NoError(t, err)
+			require.Len(t, output, 2)
+			assert.Equal(t, tc.expected, attributeSets(output))
+		})
+	}
+
+	t.Run("no enrichment", func(t *testing.T) {
+		output, err := ConvertMetricsWithContext(ctx, input, nil)
+		require.NoError(t, err)
+		expected, err := ConvertMetrics(input)
+		require.NoError(t, err)
+		assert.Equal(t, attributeSets(expected), attributeSets(output))
+	})
+}
+
+func TestConverterUnitOverride(t *testing.T) {
+	metric := func(name string, unit ocmetricdata.Unit) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: name,
+				Type: ocmetricdata.TypeGaugeInt64,
+				Unit: unit,
+			},
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		metric("foo.com/gauge-a", ocmetricdata.UnitDimensionless),
+		metric("foo.com/gauge-b", ""),
+		metric("foo.com/gauge-c", ocmetricdata.UnitBytes),
+	}
+	units := func(t *testing.T, opts ...Option) []string {
+		output, err := ConvertMetrics(input, opts...)
+		require.NoError(t, err)
+		var units []string
+		for _, m := range output {
+			units = append(units, m.Unit)
+		}
+		return units
+	}
+	mapper := func(_, unit string) string { return "mapped" }
+
+	assert.Equal(t, []string{"1", "", "By"}, units(t))
+	assert.Equal(t, []string{"ms", "By", "By"}, units(t, WithUnitOverride(map[string]string{
+		"foo.com/gauge-a": "ms",
+		"foo.com/gauge-b": "By",
+		"foo.com/gauge-d": "s",
+	})))
+	assert.Equal(t, []string{"ms", "mapped", "mapped"}, units(t,
+		WithMetricNamePrefix("app."),
+		WithUnitMapper(mapper),
+		WithUnitOverride(map[string]string{"foo.com/gauge-a": "s"}),
+		WithUnitOverride(map[string]string{"foo.com/gauge-a": "ms"}),
+	))
+}
+
+func TestConverterFloatGaugeToInt(t *testing.T) {
+	now := time.Now()
+	values := []float64{2.5, -2.5, 1.7, -1.7, 3, math.NaN(), math.Inf(1), 1e19}
+	points := make([]ocmetricdata.Point, len(values))
+	for i, v := range values {
+		points[i] = ocmetricdata.NewFloat64Point(now, v)
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeFloat64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: points}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeFloat64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 1.5)},
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		desc     string
+		mode     FloatToIntMode
+		expected []int64
+	}{
+		{
+			desc:     "round",
+			mode:     FloatToIntRound,
+			expected: []int64{3, -3, 2, -2, 3},
+		},
+		{
+			desc:     "floor",
+			mode:     FloatToIntFloor,
+			expected: []int64{2, -3, 1, -2, 3},
+		},
+		{
+			desc:     "truncate",
+			mode:     FloatToIntTruncate,
+			expected: []int64{2, -2, 1, -1, 3},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, WithFloatGaugeToInt(tc.mode))
+			assert.ErrorIs(t, err, errGaugePrecisionLoss)
+			assert.ErrorContains(t, err, "4 of 8 values")
+			assert.ErrorIs(t, err, errGaugeValueOutOfRange)
+			assert.ErrorContains(t, err, "3 points dropped")
+			require.Len(t, output, 2)
+			gauge, ok := output[0].Data.(metricdata.Gauge[int64])
+			require.True(t, ok, "converted to an int64 gauge")
+			var got []int64
+			for _, p := range gauge.DataPoints {
+				got = append(got, p.Value)
+				assert.Equal(t, now, p.Time)
+			}
+			assert.Equal(t, tc.expected, got)
+			assert.IsType(t, metricdata.Sum[float64]{}, output[1].Data, "sums are not converted")
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		output, err := ConvertMetrics(input)
+		require.NoError(t, err)
+		assert.IsType(t, metricdata.Gauge[float64]{}, output[0].Data)
+	})
+
+	t.Run("exact values", func(t *testing.T) {
+		output, err := ConvertMetrics([]*ocmetricdata.Metric{{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeFloat64},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, -4), ocmetricdata.NewFloat64Point(now, math.MinInt64)},
+			}},
+		}}, WithFloatGaugeToInt(FloatToIntFloor))
+		require.NoError(t, err)
+		gauge := output[0].Data.(metricdata.Gauge[int64])
+		require.Len(t, gauge.DataPoints, 2)
+		assert.Equal(t, int64(-4), gauge.DataPoints[0].Value)
+		assert.Equal(t, int64(math.MinInt64), gauge.DataPoints[1].Value)
+	})
+}
+
+func TestConverterTimestampTruncation(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+	t1 := start.Add(500 * time.Millisecond)
+	t2 := start.Add(900 * time.Millisecond)
+	t3 := start.Add(3 * time.Second)
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				StartTime: start,
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewInt64Point(t1, 1),
+					ocmetricdata.NewInt64Point(t2, 2),
+					ocmetricdata.NewInt64Point(t3, 3),
+				},
+			}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				StartTime: start,
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(t1, &ocmetricdata.Distribution{
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets:       []ocmetricdata.Bucket{{}, {}},
+					}),
+				},
+			}},
+		},
+	}
+	second := func(t time.Time) time.Time { return t.Truncate(time.Second) }
+
+	output, err := ConvertMetrics(input, WithTimestampTruncation(time.Second))
+	require.NoError(t, err)
+	require.Len(t, output, 2)
+	sum := output[0].Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 2, "points truncated to the same time collide")
+	for i, expected := range []struct {
+		time  time.Time
+		value int64
+	}{{second(t1), 2}, {second(t3), 3}} {
+		assert.Equal(t, second(start), sum.DataPoints[i].StartTime)
+		assert.Equal(t, expected.time, sum.DataPoints[i].Time)
+		assert.Equal(t, expected.value, sum.DataPoints[i].Value, "the later colliding point is kept")
+	}
+	histogram := output[1].Data.(metricdata.Histogram[float64])
+	assert.Equal(t, second(start), histogram.DataPoints[0].StartTime)
+	assert.Equal(t, second(t1), histogram.DataPoints[0].Time)
+
+	output, err = ConvertMetrics(input)
+	require.NoError(t, err)
+	sum = output[0].Data.(metricdata.Sum[int64])
+	assert.Equal(t, start, sum.DataPoints[0].StartTime)
+	assert.Equal(t, t1, sum.DataPoints[0].Time)
+}
+
+func TestConverterNilMetrics(t *testing.T) {
+	metric := func(name string) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: name, Type: ocmetricdata.TypeGaugeInt64},
+		}
+	}
+	input := []*ocmetricdata.Metric{nil, metric("foo.com/gauge-a"), nil, nil, metric("foo.com/gauge-b"), nil}
+
+	c := NewConverter()
+	output, err := c.ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Len(t, output, 2)
+	assert.Equal(t, 4, c.Stats().NilMetrics)
+
+	c = NewConverter(WithReportNilMetrics())
+	output, err = c.ConvertMetrics(input)
+	assert.ErrorIs(t, err, errNilMetrics)
+	assert.ErrorContains(t, err, "4 at indices [0 2 3 5]")
+	assert.Len(t, output, 2)
+	assert.Equal(t, 4, c.Stats().NilMetrics)
+
+	output, err = c.ConvertMetrics(input[1:2])
+	require.NoError(t, err)
+	assert.Len(t, output, 1)
+	assert.Equal(t, 0, c.Stats().NilMetrics)
+}
+
+func TestConverterMaxSeriesAge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	dist := &ocmetricdata.Distribution{Count: 1, BucketOptions: &ocmetricdata.BucketOptions{}, Buckets: []ocmetricdata.Bucket{{Count: 1}}}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(now.Add(-2*time.Hour), 1),
+						ocmetricdata.NewInt64Point(now.Add(-time.Hour), 2),
+						ocmetricdata.NewInt64Point(now.Add(-time.Minute), 3),
+					},
+				},
+			},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/histogram-a", Type: ocmetricdata.TypeCumulativeDistribution},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					StartTime: now.Add(-3 * time.Hour),
+					Points:    []ocmetricdata.Point{ocmetricdata.NewDistributionPoint(now.Add(-90*time.Minute), dist)},
+				},
+				{
+					StartTime: now.Add(-3 * time.Hour),
+					Points:    []ocmetricdata.Point{ocmetricdata.NewDistributionPoint(now, dist)},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc     string
+		options  []Option
+		expected []int
+		agedOut  int
+	}{
+		{
+			desc:     "default",
+			expected: []int{3, 2},
+		},
+		{
+			desc:     "max age",
+			options:  []Option{WithMaxSeriesAge(time.Hour)},
+			expected: []int{2, 1},
+			agedOut:  2,
+		},
+		{
+			desc:     "non-positive max age",
+			options:  []Option{WithMaxSeriesAge(-time.Hour)},
+			expected: []int{3, 2},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(append(tc.options, WithClock(func() time.Time { return now }))...)
+			output, err := c.ConvertMetrics(input)
+			require.NoError(t, err)
+			require.Len(t, output, 2)
+			gauge := output[0].Data.(metricdata.Gauge[int64]).DataPoints
+			histogram := output[1].Data.(metricdata.Histogram[float64]).DataPoints
+			assert.Equal(t, tc.expected, []int{len(gauge), len(histogram)})
+			assert.Equal(t, tc.agedOut, c.Stats().AgedOutPoints)
+			if tc.agedOut > 0 {
+				assert.Equal(t, int64(2), gauge[0].Value, "only fresh points are kept")
+				assert.Equal(t, now, histogram[0].Time, "only fresh points are kept")
+			}
+		})
+	}
+}
+
+func TestConverterUnsupportedAggregationHandler(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/summary-a",
+				Type:      ocmetricdata.TypeSummary,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				LabelValues: []ocmetricdata.LabelValue{{Value: "hello", Present: true}},
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewSummaryPoint(now, &ocmetricdata.Summary{Count: 4, Sum: 10, HasCountAndSum: true}),
+				},
+			}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		},
+	}
+	errNoMean := errors.New("summary has no count and sum")
+	// meanGauge converts summaries to a gauge of their mean.
+	meanGauge := func(ocm *ocmetricdata.Metric) (metricdata.Aggregation, error) {
+		var points []metricdata.DataPoint[float64]
+		for _, ts := range ocm.TimeSeries {
+			var attrs []attribute.KeyValue
+			for i, lv := range ts.LabelValues {
+				if lv.Present {
+					attrs = append(attrs, attribute.String(ocm.Descriptor.LabelKeys[i].Key, lv.Value))
+				}
+			}
+			for _, p := range ts.Points {
+				summary, ok := p.Value.(*ocmetricdata.Summary)
+				if !ok || !summary.HasCountAndSum || summary.Count == 0 {
+					return nil, errNoMean
+				}
+				points = append(points, metricdata.DataPoint[float64]{
+					Attributes: attribute.NewSet(attrs...),
+					Time:       p.Time,
+					Value:      summary.Sum / float64(summary.Count),
+				})
+			}
+		}
+		return metricdata.Gauge[float64]{DataPoints: points}, nil
+	}
+
+	output, err := ConvertMetrics(input, WithUnsupportedAggregationHandler(meanGauge))
+	require.NoError(t, err)
+	require.Len(t, output, 2)
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name: "foo.com/summary-a",
+		Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{{
+			Attributes: attribute.NewSet(attribute.String("a", "hello")),
+			Time:       now,
+			Value:      2.5,
+		}}},
+	}, output[0])
+	assert.IsType(t, metricdata.Gauge[int64]{}, output[1].Data, "supported types are not handled")
+
+	output, err = ConvertMetrics(input)
+	assert.ErrorIs(t, err, errAggregationType)
+	assert.Len(t, output, 1)
+
+	failing := func(*ocmetricdata.Metric) (metricdata.Aggregation, error) { return nil, errNoMean }
+	output, err = ConvertMetrics(input, WithUnsupportedAggregationHandler(failing))
+	assert.ErrorIs(t, err, errNoMean)
+	assert.Len(t, output, 1)
+
+	empty := func(*ocmetricdata.Metric) (metricdata.Aggregation, error) { return nil, nil }
+	output, err = ConvertMetrics(input, WithUnsupportedAggregationHandler(empty))
+	assert.ErrorIs(t, err, errAggregationType)
+	assert.Len(t, output, 1)
+}
+
+func TestConverterPointTimeFallback(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-time.Minute)
+	clock := now.Add(time.Hour)
+	dist := &ocmetricdata.Distribution{
+		BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+		Buckets:       []ocmetricdata.Bucket{{}, {}},
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(now, 1),
+						ocmetricdata.NewInt64Point(time.Time{}, 2),
+					},
+					StartTime: startTime,
+				}, {
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Time{}, 3)},
+				},
+			},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points:    []ocmetricdata.Point{ocmetricdata.NewDistributionPoint(time.Time{}, dist)},
+					StartTime: startTime,
+				}, {
+					Points: []ocmetricdata.Point{ocmetricdata.NewDistributionPoint(time.Time{}, dist)},
+				},
+			},
+		},
+	}
+	times := func(t *testing.T, c *Converter) []time.Time {
+		output, err := c.ConvertMetrics(input)
+		require.NoError(t, err)
+		require.Len(t, output, 2)
+		var times []time.Time
+		for _, p := range output[0].Data.(metricdata.Gauge[int64]).DataPoints {
+			times = append(times, p.Time)
+		}
+		for _, p := range output[1].Data.(metricdata.Histogram[float64]).DataPoints {
+			times = append(times, p.Time)
+		}
+		return times
+	}
+
+	clockNow := func() time.Time { return clock }
+	c := NewConverter(WithPointTimeFallback(), WithClock(clockNow))
+	assert.Equal(t, []time.Time{now, startTime, clock, startTime, clock}, times(t, c))
+
+	c = NewConverter(WithClock(clockNow))
+	assert.Equal(t, []time.Time{now, {}, {}, {}, {}}, times(t, c))
+}
+
+func TestConverterValueTypeMismatchHandling(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewInt64Point(now, 1),
+					ocmetricdata.NewFloat64Point(now, 2.6),
+					ocmetricdata.NewFloat64Point(now, 3),
+					ocmetricdata.NewFloat64Point(now, math.NaN()),
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{}),
+				},
+			}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeFloat64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewFloat64Point(now, 1.5),
+					ocmetricdata.NewInt64Point(now, 2),
+				},
+			}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+						Buckets:       []ocmetricdata.Bucket{{}, {}},
+					}),
+					ocmetricdata.NewInt64Point(now, 1),
+				},
+			}},
+		},
+	}
+	type converted struct {
+		ints       []int64
+		floats     []float64
+		histograms int
+	}
+	convert := func(t *testing.T, opts ...Option) (map[string]converted, error) {
+		output, err := ConvertMetrics(input, opts...)
+		result := make(map[string]converted)
+		for _, m := range output {
+			var r converted
+			switch a := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				for _, p := range a.DataPoints {
+					r.ints = append(r.ints, p.Value)
+				}
+			case metricdata.Sum[float64]:
+				for _, p := range a.DataPoints {
+					r.floats = append(r.floats, p.Value)
+				}
+			case metricdata.Histogram[float64]:
+				r.histograms = len(a.DataPoints)
+			}
+			result[m.Name] = r
+		}
+		return result, err
+	}
+
+	t.Run("error", func(t *testing.T) {
+		for _, opts := range [][]Option{nil, {WithValueTypeMismatchHandling(ValueTypeMismatchError)}} {
+			output, err := convert(t, opts...)
+			assert.ErrorIs(t, err, errMismatchedValueTypes)
+			assert.Empty(t, output, "metrics are dropped")
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		output, err := convert(t, WithValueTypeMismatchHandling(ValueTypeMismatchDrop))
+		assert.ErrorIs(t, err, errMismatchedValueTypes)
+		assert.Equal(t, 6, strings.Count(err.Error(), "point dropped"))
+		assert.Equal(t, map[string]converted{
+			"foo.com/gauge-a":     {ints: []int64{1}},
+			"foo.com/sum-a":       {floats: []float64{1.5}},
+			"foo.com/histogram-a": {histograms: 1},
+		}, output)
+	})
+
+	t.Run("coerce", func(t *testing.T) {
+		output, err := convert(t, WithValueTypeMismatchHandling(ValueTypeMismatchCoerce))
+		assert.ErrorIs(t, err, errValueCoerced)
+		assert.Equal(t, 1, strings.Count(err.Error(), errValueCoerced.Error()), "only inexact values are reported")
+		assert.Equal(t, 3, strings.Count(err.Error(), "point dropped"), "NaN and distributions are dropped")
+		assert.Equal(t, map[string]converted{
+			"foo.com/gauge-a":     {ints: []int64{1, 3, 3}},
+			"foo.com/sum-a":       {floats: []float64{1.5, 2}},
+			"foo.com/histogram-a": {histograms: 1},
+		}, output)
+	})
+
+	t.Run("coerce with float to int mode", func(t *testing.T) {
+		output, err := convert(t, WithValueTypeMismatchHandling(ValueTypeMismatchCoerce), WithFloatGaugeToInt(FloatToIntFloor))
+		assert.ErrorIs(t, err, errValueCoerced)
+		assert.Equal(t, []int64{1, 2, 3}, output["foo.com/gauge-a"].ints)
+	})
+}
+
+func TestConverterMaxExemplarsPerPoint(t *testing.T) {
+	now := time.Now()
+	spanContext := octrace.SpanContext{
+		TraceID: octrace.TraceID([16]byte{1}),
+		SpanID:  octrace.SpanID([8]byte{2}),
+	}
+	traced := map[string]interface{}{ocmetricdata.AttachmentKeySpanContext: spanContext}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         5,
+						Sum:           15,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1.5, 2.5, 3.5, 4.5}},
+						Buckets: []ocmetricdata.Bucket{
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 1, Timestamp: now, Attachments: traced}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 2, Timestamp: now}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 3, Timestamp: now, Attachments: traced}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 4, Timestamp: now}},
+							{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 5, Timestamp: now}},
+						},
+					}),
+				},
+			}},
+		},
+	}
+	values := func(t *testing.T, output []metricdata.Metrics) []float64 {
+		require.Len(t, output, 1)
+		var v []float64
+		for _, e := range output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Exemplars {
+			v = append(v, e.Value)
+		}
+		return v
+	}
+
+	output, err := ConvertMetrics(input, WithMaxExemplarsPerPoint(3))
+	assert.ErrorIs(t, err, errExemplarsTrimmed)
+	assert.Equal(t, []float64{1, 3, 5}, values(t, output))
+
+	output, err = ConvertMetrics(input, WithMaxExemplarsPerPoint(1))
+	assert.ErrorIs(t, err, errExemplarsTrimmed)
+	assert.Equal(t, []float64{3}, values(t, output))
+
+	output, err = ConvertMetrics(input, WithMaxExemplarsPerPoint(5))
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3, 4, 5}, values(t, output))
+
+	output, err = ConvertMetrics(input)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3, 4, 5}, values(t, output))
+}
+
+func TestConverterTimingHistogram(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64}},
+		nil,
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/summary-a", Type: ocmetricdata.TypeSummary}},
+	}
+	var names []string
+	var durations []time.Duration
+	c := NewConverter(WithTimingHistogram(func(metricName string, d time.Duration) {
+		names = append(names, metricName)
+		durations = append(durations, d)
+	}))
+	// Each reading of the clock advances it by a millisecond.
+	now := time.Now()
+	c.cfg.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	_, err := c.ConvertMetrics(input)
+	assert.ErrorIs(t, err, errAggregationType)
+	assert.Equal(t, []string{"foo.com/gauge-a", "foo.com/summary-a"}, names)
+	assert.Equal(t, []time.Duration{time.Millisecond, time.Millisecond}, durations)
+}
+
+func TestConverterPreserveSumOfSquaredDeviation(t *testing.T) {
+	now := time.Now()
+	distribution := func(ssd float64) ocmetricdata.Point {
+		return ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+			Count:                 2,
+			Sum:                   3,
+			SumOfSquaredDeviation: ssd,
+			BucketOptions:         &ocmetricdata.BucketOptions{},
+			Buckets:               []ocmetricdata.Bucket{{Count: 2}},
+		})
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/histogram-a",
+				Type:      ocmetricdata.TypeCumulativeDistribution,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+					Points:      []ocmetricdata.Point{distribution(0.5)},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "2", Present: true}},
+					Points:      []ocmetricdata.Point{distribution(0)},
+				},
+			},
+		},
+	}
+	attrs := func(t *testing.T, opts ...Option) []attribute.Set {
+		output, err := ConvertMetrics(input, opts...)
+		require.NoError(t, err)
+		require.Len(t, output, 1)
+		var sets []attribute.Set
+		for _, p := range output[0].Data.(metricdata.Histogram[float64]).DataPoints {
+			sets = append(sets, p.Attributes)
+		}
+		return sets
+	}
+
+	assert.Equal(t, []attribute.Set{
+		attribute.NewSet(attribute.String("a", "1"), attribute.Float64("opencensus.sum_of_squared_deviation", 0.5)),
+		attribute.NewSet(attribute.String("a", "2")),
+	}, attrs(t, WithPreserveSumOfSquaredDeviation("opencensus.sum_of_squared_deviation")))
+	assert.Equal(t, []attribute.Set{
+		attribute.NewSet(attribute.String("a", "1")),
+		attribute.NewSet(attribute.String("a", "2")),
+	}, attrs(t))
+}
+
+func TestConverterEmptyAttributeSetKey(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc     string
+		opts     []Option
+		expected attribute.Set
+	}{
+		{
+			desc:     "empty set",
+			expected: *attribute.EmptySet(),
+		},
+		{
+			desc:     "placeholder",
+			opts:     []Option{WithEmptyAttributeSetKey("opencensus.labels", "none")},
+			expected: attribute.NewSet(attribute.String("opencensus.labels", "none")),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.opts...)
+			require.NoError(t, err)
+			metricdatatest.AssertEqual(t, metricdata.Metrics{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: tc.expected, Time: now, Value: 1},
+					},
+				},
+			}, output[0])
+		})
+	}
+}
+
+func TestConverterSortByStartTime(t *testing.T) {
+	startTime1 := time.Now()
+	startTime2 := startTime1.Add(time.Minute)
+	endTime1 := startTime2.Add(time.Minute)
+	endTime2 := endTime1.Add(time.Minute)
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/sum-a",
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "1", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(endTime2, 1),
+						ocmetricdata.NewInt64Point(endTime1, 2),
+					},
+					StartTime: startTime2,
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "2", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(endTime2, 3),
+					},
+					StartTime: startTime1,
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "3", Present: true}},
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(endTime1, 4),
+						ocmetricdata.NewInt64Point(endTime2, 5),
+					},
+					StartTime: startTime1,
+				},
+			},
+		},
+	}
+	values := func(t *testing.T, output []metricdata.Metrics) []int64 {
+		require.Len(t, output, 1)
+		var v []int64
+		for _, p := range output[0].Data.(metricdata.Sum[int64]).DataPoints {
+			v = append(v, p.Value)
+		}
+		return v
+	}
+
+	output, err := ConvertMetrics(input, WithSortByStartTime())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 3, 5, 2, 1}, values(t, output))
+
+	output, err = ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, values(t, output))
+}
+
+func TestConverterTimestampSanity(t *testing.T) {
+	now := time.Now()
+	// Epoch seconds used as nanoseconds.
+	implausible := time.Unix(0, now.Unix())
+	dist := &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewInt64Point(implausible, 1),
+						ocmetricdata.NewInt64Point(now, 2),
+					},
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewDistributionPoint(now, dist),
+						ocmetricdata.NewDistributionPoint(now.Add(48*time.Hour), dist),
+					},
+				},
+			},
+		},
+	}
+	times := func(t *testing.T, output []metricdata.Metrics) ([]time.Time, []time.Time) {
+		require.Len(t, output, 2)
+		var gaugeTimes, histogramTimes []time.Time
+		for _, p := range output[0].Data.(metricdata.Gauge[int64]).DataPoints {
+			gaugeTimes = append(gaugeTimes, p.Time)
+		}
+		for _, p := range output[1].Data.(metricdata.Histogram[float64]).DataPoints {
+			histogramTimes = append(histogramTimes, p.Time)
+		}
+		return gaugeTimes, histogramTimes
+	}
+
+	output, err := ConvertMetrics(input, WithTimestampSanity(now.Add(-24*time.Hour), now.Add(24*time.Hour)))
+	assert.ErrorIs(t, err, errImplausibleTimestamp)
+	gaugeTimes, histogramTimes := times(t, output)
+	assert.Equal(t, []time.Time{now}, gaugeTimes)
+	assert.Equal(t, []time.Time{now}, histogramTimes)
+
+	output, err = ConvertMetrics(input)
+	require.NoError(t, err)
+	gaugeTimes, histogramTimes = times(t, output)
+	assert.Equal(t, []time.Time{implausible, now}, gaugeTimes)
+	assert.Equal(t, []time.Time{now, now.Add(48 * time.Hour)}, histogramTimes)
+}
+
+func TestConverterMaxErrors(t *testing.T) {
+	now := time.Now()
+	var input []*ocmetricdata.Metric
+	for i := 0; i < 5; i++ {
+		input = append(input, &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: fmt.Sprintf("foo.com/gauge-%d", i),
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewFloat64Point(now, 1.0),
+					},
+				},
+			},
+		})
+	}
+
+	output, err := ConvertMetrics(input, WithMaxErrors(2))
+	assert.Empty(t, output, "metrics with omitted errors must still be dropped")
+	assert.ErrorIs(t, err, errMismatchedValueTypes)
+	assert.Equal(t, 2, strings.Count(err.Error(), errMismatchedValueTypes.Error()))
+	assert.Contains(t, err.Error(), "+3 more errors")
+	assert.NotContains(t, err.Error(), errOmitted.Error())
+
+	output, err = ConvertMetrics(input)
+	assert.Empty(t, output)
+	assert.Equal(t, 5, strings.Count(err.Error(), errMismatchedValueTypes.Error()))
+	assert.NotContains(t, err.Error(), "more errors")
+}
+
+func TestConvertExemplars(t *testing.T) {
+	now := time.Now()
+	buckets := []ocmetricdata.Bucket{
+		{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+		{Count: 1},
+		{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 1.5, Timestamp: now}},
+		{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 2.5, Timestamp: now.Add(-time.Second)}},
+		// Duplicate of the first exemplar.
+		{Count: 1, Exemplar: &ocmetricdata.Exemplar{Value: 0.5, Timestamp: now}},
+	}
+	for i := 0; i < 3; i++ {
+		exemplars, err := convertExemplars(buckets)
+		require.NoError(t, err)
+		assert.Equal(t, []metricdata.Exemplar[float64]{
+			{Value: 0.5, Time: now},
+			{Value: 1.5, Time: now},
+			{Value: 2.5, Time: now.Add(-time.Second)},
+		}, exemplars)
+	}
+}
+
+func TestConverterLabelDescriptionsAsExemplarAttrs(t *testing.T) {
+	now := time.Now()
+	labelKeys := []ocmetricdata.LabelKey{
+		{Key: "a", Description: "the a label"},
+		{Key: "b"},
+	}
+	series := func(value string, exemplar *ocmetricdata.Exemplar) *ocmetricdata.TimeSeries {
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}, {}},
+			Points: []ocmetricdata.Point{
+				ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+					Count:         1,
+					BucketOptions: &ocmetricdata.BucketOptions{},
+					Buckets:       []ocmetricdata.Bucket{{Count: 1, Exemplar: exemplar}},
+				}),
+			},
+		}
+	}
+	exemplar := func() *ocmetricdata.Exemplar {
+		return &ocmetricdata.Exemplar{
+			Value:       0.5,
+			Timestamp:   now,
+			Attachments: map[string]any{"z": "attachment"},
+		}
+	}
+	histogram := func(ts ...*ocmetricdata.TimeSeries) []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{
+			{
+				Descriptor: ocmetricdata.Descriptor{
+					Name:      "foo.com/histogram-a",
+					Type:      ocmetricdata.TypeCumulativeDistribution,
+					LabelKeys: labelKeys,
+				},
+				TimeSeries: ts,
+			},
+		}
+	}
+	exemplars := func(t *testing.T, output []metricdata.Metrics) [][]metricdata.Exemplar[float64] {
+		require.Len(t, output, 1)
+		var e [][]metricdata.Exemplar[float64]
+		for _, p := range output[0].Data.(metricdata.Histogram[float64]).DataPoints {
+			e = append(e, p.Exemplars)
+		}
+		return e
+	}
+	withDescription := metricdata.Exemplar[float64]{
+		Value: 0.5,
+		Time:  now,
+		FilteredAttributes: []attribute.KeyValue{
+			attribute.String("label.a.description", "the a label"),
+			attribute.String("z", "attachment"),
+		},
+	}
+	withoutDescription := metricdata.Exemplar[float64]{
+		Value:              0.5,
+		Time:               now,
+		FilteredAttributes: []attribute.KeyValue{attribute.String("z", "attachment")},
+	}
+
+	input := histogram(series("1", nil), series("2", exemplar()), series("3", exemplar()))
+	output, err := ConvertMetrics(input, WithLabelDescriptionsAsExemplarAttrs())
+	require.NoError(t, err)
+	assert.Equal(t, [][]metricdata.Exemplar[float64]{
+		nil,
+		{withDescription},
+		{withoutDescription},
+	}, exemplars(t, output), "descriptions must only be added to the first point with exemplars")
+
+	output, err = ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Equal(t, [][]metricdata.Exemplar[float64]{
+		nil,
+		{withoutDescription},
+		{withoutDescription},
+	}, exemplars(t, output))
+
+	output, err = ConvertMetrics(histogram(series("1", nil)), WithLabelDescriptionsAsExemplarAttrs())
+	require.NoError(t, err)
+	assert.Equal(t, [][]metricdata.Exemplar[float64]{nil}, exemplars(t, output))
+
+	// The filtered attributes of resolved exemplars have spare capacity that
+	// must not be written to.
+	filtered := make([]attribute.KeyValue, 1, 4)
+	filtered[0] = attribute.String("z", "attachment")
+	resolver := func(string, attribute.Set) []metricdata.Exemplar[float64] {
+		return []metricdata.Exemplar[float64]{{Value: 0.5, Time: now, FilteredAttributes: filtered}}
+	}
+	output, err = ConvertMetrics(histogram(series("1", nil)), WithLabelDescriptionsAsExemplarAttrs(), WithExemplarResolver(resolver))
+	require.NoError(t, err)
+	assert.Equal(t, [][]metricdata.Exemplar[float64]{{withDescription}}, exemplars(t, output))
+	assert.Equal(t, []attribute.KeyValue{attribute.String("z", "attachment"), {}, {}, {}}, filtered[:cap(filtered)])
+}
+
+func TestConvertDescription(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		input       string
+		opts        []Option
+		expected    string
+		expectedErr error
+	}{
+		{
+			desc:     "no limit",
+			input:    "a testing gauge",
+			expected: "a testing gauge",
+		},
+		{
+			desc:     "within limit",
+			input:    "a testing gauge",
+			opts:     []Option{WithMaxDescriptionLength(15)},
+			expected: "a testing gauge",
+		},
+		{
+			desc:        "truncated",
+			input:       "a testing gauge",
+			opts:        []Option{WithMaxDescriptionLength(12)},
+			expected:    "a testing...",
+			expectedErr: errDescriptionTruncated,
+		},
+		{
+			desc: "truncated on rune boundary",
+			// "é" is two bytes, and the limit falls between them.
+			input:       "a testing géuge",
+			opts:        []Option{WithMaxDescriptionLength(15)},
+			expected:    "a testing g...",
+			expectedErr: errDescriptionTruncated,
+		},
+		{
+			desc:        "limit shorter than ellipsis",
+			input:       "a testing gauge",
+			opts:        []Option{WithMaxDescriptionLength(2)},
+			expected:    "a ",
+			expectedErr: errDescriptionTruncated,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(tc.opts...)
+			output := c.convertDescription(tc.input)
+			assert.Equal(t, tc.expected, output)
+			assert.True(t, utf8.ValidString(output))
+			assert.ErrorIs(t, c.warnings, tc.expectedErr)
+		})
+	}
+}
+
+func TestConverterDescriptionFallback(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:        "foo.com/gauge-a",
+				Description: "a testing gauge",
+				Type:        ocmetricdata.TypeGaugeInt64,
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-b",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-c",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		},
+	}
+	catalog := map[string]string{
+		"foo.com/gauge-a": "a cataloged gauge",
+		"foo.com/gauge-b": "another cataloged gauge",
+	}
+	descriptions := func(t *testing.T, opts ...Option) []string {
+		output, err := ConvertMetrics(input, opts...)
+		assert.True(t, err == nil || errors.Is(err, errDescriptionTruncated), err)
+		var d []string
+		for _, m := range output {
+			d = append(d, m.Description)
+		}
+		return d
+	}
+
+	assert.Equal(t, []string{"a testing gauge", "", ""}, descriptions(t))
+	assert.Equal(t,
+		[]string{"a testing gauge", "another cataloged gauge", ""},
+		descriptions(t, WithDescriptionFallback(func(name string) string { return catalog[name] })),
+	)
+	assert.Equal(t,
+		[]string{"a testing gauge", "another cataloged...", ""},
+		descriptions(t, WithDescriptionFallback(func(name string) string { return catalog[name] }), WithMaxDescriptionLength(20)),
+	)
+}
+
+func TestConverterDroppedMetrics(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-b",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewFloat64Point(now, 1.0),
+						ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{}),
+					},
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-distribution",
+				Type: ocmetricdata.TypeGaugeDistribution,
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		},
+	}
+
+	c := NewConverter(WithMaxErrors(1))
+	_, err := c.ConvertMetrics(input)
+	require.Error(t, err)
+	dropped := c.DroppedMetrics()
+	assert.Len(t, dropped, 3)
+	assert.ErrorIs(t, dropped["foo.com/gauge-b"], errMismatchedValueTypes)
+	assert.ErrorIs(t, dropped["foo.com/gauge-distribution"], errAggregationType)
+	assert.ErrorIs(t, dropped[""], errEmptyMetricName, "omitted errors must be recorded")
+
+	_, err = c.ConvertMetrics(input[:1])
+	require.NoError(t, err)
+	assert.Empty(t, c.DroppedMetrics(), "dropped metrics must be reset")
+}
+
+func TestConverterAggregationOverride(t *testing.T) {
+	startTime := time.Now()
+	endTime := startTime.Add(time.Minute)
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points:    []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime, 1)},
+					StartTime: startTime,
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-b",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points:    []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime, 2)},
+					StartTime: startTime,
+				},
+			},
+		},
+	}
+	override := func(name string, ocType ocmetricdata.Type) ocmetricdata.Type {
+		switch name {
+		case "foo.com/gauge-a":
+			return ocmetricdata.TypeCumulativeInt64
+		case "foo.com/gauge-b":
+			return ocmetricdata.TypeSummary
+		}
+		return ocType
+	}
+
+	output, err := ConvertMetrics(input, WithAggregationOverride(override))
+	assert.ErrorIs(t, err, errInvalidAggregationOverride)
+	assert.Contains(t, err.Error(), "foo.com/gauge-b")
+	expected := []metricdata.Metrics{
+		{
+			Name: "foo.com/gauge-a",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(),
+						StartTime:  startTime,
+						Time:       endTime,
+						Value:      1,
+					},
+				},
+			},
+		}, {
+			Name: "foo.com/gauge-b",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(),
+						StartTime:  startTime,
+						Time:       endTime,
+						Value:      2,
+					},
+				},
+			},
+		},
+	}
+	metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+		metricdata.ScopeMetrics{Metrics: expected},
+		metricdata.ScopeMetrics{Metrics: output},
+	)
+}
+
+func TestConverterGaugeNaNPolicy(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeFloat64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						ocmetricdata.NewFloat64Point(now, math.NaN()),
+						ocmetricdata.NewFloat64Point(now.Add(time.Second), 1.5),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc        string
+		opts        []Option
+		expectedErr error
+		check       func(*testing.T, []metricdata.DataPoint[float64])
+	}{
+		{
+			desc: "keep by default",
+			check: func(t *testing.T, points []metricdata.DataPoint[float64]) {
+				require.Len(t, points, 2)
+				assert.True(t, math.IsNaN(points[0].Value))
+				assert.Equal(t, 1.5, points[1].Value)
+			},
+		},
+		{
+			desc: "keep",
+			opts: []Option{WithGaugeNaNPolicy(NaNKeep)},
+			check: func(t *testing.T, points []metricdata.DataPoint[float64]) {
+				require.Len(t, points, 2)
+				assert.True(t, math.IsNaN(points[0].Value))
+			},
+		},
+		{
+			desc: "zero",
+			opts: []Option{WithGaugeNaNPolicy(NaNZero)},
+			check: func(t *testing.T, points []metricdata.DataPoint[float64]) {
+				require.Len(t, points, 2)
+				assert.Equal(t, 0.0, points[0].Value)
+				assert.Equal(t, 1.5, points[1].Value)
+			},
+		},
+		{
+			desc:        "drop",
+			opts:        []Option{WithGaugeNaNPolicy(NaNDrop)},
+			expectedErr: errNaNValue,
+			check: func(t *testing.T, points []metricdata.DataPoint[float64]) {
+				require.Len(t, points, 1)
+				assert.Equal(t, 1.5, points[0].Value)
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, output, 1)
+			tc.check(t, output[0].Data.(metricdata.Gauge[float64]).DataPoints)
+		})
+	}
+}
+
+func TestConverterMetricNamePrefix(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc     string
+		opts     []Option
+		expected []string
+	}{
+		{
+			desc:     "no prefix",
+			expected: []string{"foo.com/gauge-a"},
+		},
+		{
+			desc:     "empty prefix",
+			opts:     []Option{WithMetricNamePrefix("")},
+			expected: []string{"foo.com/gauge-a"},
+		},
+		{
+			desc:     "separator inserted",
+			opts:     []Option{WithMetricNamePrefix("tenant")},
+			expected: []string{"tenant.foo.com/gauge-a"},
+		},
+		{
+			desc:     "dot separator",
+			opts:     []Option{WithMetricNamePrefix("tenant.")},
+			expected: []string{"tenant.foo.com/gauge-a"},
+		},
+		{
+			desc:     "slash separator",
+			opts:     []Option{WithMetricNamePrefix("tenant/")},
+			expected: []string{"tenant/foo.com/gauge-a"},
+		},
+		{
+			desc:     "underscore separator",
+			opts:     []Option{WithMetricNamePrefix("tenant_")},
+			expected: []string{"tenant_foo.com/gauge-a"},
+		},
+		{
+			desc:     "default metric name",
+			opts:     []Option{WithMetricNamePrefix("tenant"), WithDefaultMetricName("unnamed_")},
+			expected: []string{"tenant.foo.com/gauge-a", "tenant.unnamed_1"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(tc.opts...)
+			output, _ := c.ConvertMetrics(input)
+			names := make([]string, len(output))
+			for i, m := range output {
+				names[i] = m.Name
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestConverterConvertMetricsGrouped(t *testing.T) {
+	now := time.Now()
+	metric := func(name string, ocType ocmetricdata.Type, p ocmetricdata.Point) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: name, Type: ocType},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{p}, StartTime: now},
+			},
+		}
+	}
+	dist := &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}
+	input := []*ocmetricdata.Metric{
+		metric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, ocmetricdata.NewInt64Point(now, 1)),
+		metric("foo.com/sum-a", ocmetricdata.TypeCumulativeFloat64, ocmetricdata.NewFloat64Point(now, 1)),
+		metric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, ocmetricdata.NewDistributionPoint(now, dist)),
+		metric("foo.com/gauge-b", ocmetricdata.TypeGaugeInt64, ocmetricdata.NewInt64Point(now, 2)),
+		metric("foo.com/gauge-c", ocmetricdata.TypeGaugeFloat64, ocmetricdata.NewInt64Point(now, 3)),
+	}
+
+	grouped, err := NewConverter().ConvertMetricsGrouped(input)
+	assert.ErrorIs(t, err, errMismatchedValueTypes)
+	names := make(map[reflect.Type][]string)
+	for typ, metrics := range grouped {
+		for _, m := range metrics {
+			names[typ] = append(names[typ], m.Name)
+		}
+	}
+	assert.Equal(t, map[reflect.Type][]string{
+		reflect.TypeOf(metricdata.Gauge[int64]{}):       {"foo.com/gauge-a", "foo.com/gauge-b"},
+		reflect.TypeOf(metricdata.Sum[float64]{}):       {"foo.com/sum-a"},
+		reflect.TypeOf(metricdata.Histogram[float64]{}): {"foo.com/histogram-a"},
+	}, names)
+}
+
+func TestConverterConvertMetricsByTemporality(t *testing.T) {
+	now := time.Now()
+	metric := func(name string, ocType ocmetricdata.Type, p ocmetricdata.Point) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: name, Type: ocType},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{p}, StartTime: now},
+			},
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		metric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, ocmetricdata.NewInt64Point(now, 1)),
+		metric("foo.com/delta-a", ocmetricdata.TypeCumulativeInt64, ocmetricdata.NewInt64Point(now, 2)),
+		metric("foo.com/sum-a", ocmetricdata.TypeCumulativeFloat64, ocmetricdata.NewFloat64Point(now, 3)),
+		metric("foo.com/gauge-b", ocmetricdata.TypeGaugeFloat64, ocmetricdata.NewFloat64Point(now, 4)),
+		metric("foo.com/delta-b", ocmetricdata.TypeCumulativeFloat64, ocmetricdata.NewFloat64Point(now, 5)),
+		metric("foo.com/sum-b", ocmetricdata.TypeCumulativeInt64, ocmetricdata.NewInt64Point(now, 6)),
+	}
+	names := func(metrics []metricdata.Metrics) []string {
+		var out []string
+		for _, m := range metrics {
+			out = append(out, m.Name)
+		}
+		return out
+	}
+
+	cumulative, delta, err := NewConverter().ConvertMetricsByTemporality(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"foo.com/gauge-a", "foo.com/delta-a", "foo.com/sum-a",
+		"foo.com/gauge-b", "foo.com/delta-b", "foo.com/sum-b",
+	}, names(cumulative))
+	assert.Empty(t, delta)
+
+	c := NewConverter(WithTemporalityByName(func(name string, _ ocmetricdata.Type) metricdata.Temporality {
+		if strings.HasPrefix(name, "foo.com/delta-") {
+			return metricdata.DeltaTemporality
+		}
+		return metricdata.CumulativeTemporality
+	}))
+	cumulative, delta, err = c.ConvertMetricsByTemporality(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"foo.com/gauge-a", "foo.com/sum-a", "foo.com/gauge-b", "foo.com/sum-b",
+	}, names(cumulative), "gauges are cumulative")
+	assert.Equal(t, []string{"foo.com/delta-a", "foo.com/delta-b"}, names(delta))
+	for _, m := range delta {
+		assert.Equal(t, metricdata.DeltaTemporality, temporalityOf(m.Data))
+	}
+}
+
+func TestConverterView(t *testing.T) {
+	startTime := time.Now()
+	endTime := startTime.Add(time.Minute)
+	dist := &ocmetricdata.Distribution{
+		Count:         3,
+		Sum:           4.5,
+		BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{1}},
+		Buckets:       []ocmetricdata.Bucket{{Count: 1}, {Count: 2}},
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points:    []ocmetricdata.Point{ocmetricdata.NewDistributionPoint(endTime, dist)},
+					StartTime: startTime,
+				},
+			},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points:    []ocmetricdata.Point{ocmetricdata.NewInt64Point(endTime, 1)},
+					StartTime: startTime,
+				},
+			},
+		},
+	}
+	// countView reduces histograms to the sum of their counts, and returns
+	// an unsupported aggregation for gauges.
+	countView := func(name string, agg metricdata.Aggregation) metricdata.Aggregation {
+		switch a := agg.(type) {
+		case metricdata.Histogram[float64]:
+			points := make([]metricdata.DataPoint[int64], len(a.DataPoints))
+			for i, p := range a.DataPoints {
+				points[i] = metricdata.DataPoint[int64]{
+					Attributes: p.Attributes,
+					StartTime:  p.StartTime,
+					Time:       p.Time,
+					Value:      int64(p.Count),
+				}
+			}
+			return metricdata.Sum[int64]{
+				DataPoints:  points,
+				Temporality: a.Temporality,
+				IsMonotonic: true,
+			}
+		case metricdata.Gauge[int64]:
+			return metricdata.Histogram[int64]{}
+		}
+		return agg
+	}
+
+	output, err := ConvertMetrics(input, WithView(countView))
+	assert.ErrorIs(t, err, errInvalidViewAggregation)
+	assert.Contains(t, err.Error(), "foo.com/gauge-a")
+	expected := []metricdata.Metrics{
+		{
+			Name: "foo.com/histogram-a",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(),
+						StartTime:  startTime,
+						Time:       endTime,
+						Value:      3,
+					},
+				},
+			},
+		}, {
+			Name: "foo.com/gauge-a",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(),
+						StartTime:  startTime,
+						Time:       endTime,
+						Value:      1,
+					},
+				},
+			},
+		},
+	}
+	metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+		metricdata.ScopeMetrics{Metrics: expected},
+		metricdata.ScopeMetrics{Metrics: output},
+	)
+}
+
+func TestUnsupportedMetrics(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64}},
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/summary-a", Type: ocmetricdata.TypeSummary}},
+		nil,
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-distribution", Type: ocmetricdata.TypeGaugeDistribution}},
+		{Descriptor: ocmetricdata.Descriptor{Name: "foo.com/histogram-a", Type: ocmetricdata.TypeCumulativeDistribution}},
+	}
+	assert.Equal(t, []string{"foo.com/summary-a", "foo.com/gauge-distribution"}, UnsupportedMetrics(input))
+	assert.Empty(t, UnsupportedMetrics(nil))
+}
+
+func TestSupportedTypeMatchesConvertAggregation(t *testing.T) {
+	for ocType := ocmetricdata.TypeGaugeInt64; ocType <= ocmetricdata.TypeSummary; ocType++ {
+		_, err := NewConverter().convertAggregation(&ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/metric-a", Type: ocType},
+		})
+		assert.Equal(t, supportedType(ocType), !errors.Is(err, errAggregationType), ocType)
+	}
+}
+
+func TestConverterMetricScopedKeyMapper(t *testing.T) {
+	now := time.Now()
+	gauge := func(name string) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "method"}, {Key: "http.request.method"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "GET", Present: true}, {}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+				}, {
+					LabelValues: []ocmetricdata.LabelValue{{Value: "GET", Present: true}, {Value: "POST", Present: true}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 2)},
+				},
+			},
+		}
+	}
+	mapper := func(metricName string, key attribute.Key) attribute.Key {
+		if strings.HasPrefix(metricName, "http/") && key == "method" {
+			return "http.request.method"
+		}
+		return key
+	}
+
+	output, err := ConvertMetrics([]*ocmetricdata.Metric{gauge("http/requests"), gauge("rpc/requests")}, WithMetricScopedKeyMapper(mapper))
+	assert.ErrorIs(t, err, errAttributeKeyCollision)
+	assert.Contains(t, err.Error(), "http/requests")
+	assert.NotContains(t, err.Error(), "rpc/requests")
+	require.Len(t, output, 2)
+	attrs := func(m metricdata.Metrics) []attribute.Set {
+		var sets []attribute.Set
+		for _, p := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+			sets = append(sets, p.Attributes)
+		}
+		return sets
+	}
+	assert.Equal(t, []attribute.Set{
+		attribute.NewSet(attribute.String("http.request.method", "GET")),
+		attribute.NewSet(attribute.String("http.request.method", "POST")),
+	}, attrs(output[0]))
+	assert.Equal(t, []attribute.Set{
+		attribute.NewSet(attribute.String("method", "GET")),
+		attribute.NewSet(attribute.String("method", "GET"), attribute.String("http.request.method", "POST")),
+	}, attrs(output[1]))
+}
+
+func TestConverterValidateBucketSum(t *testing.T) {
+	now := time.Now()
+	dist := func(count int64, bucketCounts ...int64) ocmetricdata.Point {
+		buckets := make([]ocmetricdata.Bucket, len(bucketCounts))
+		for i, n := range bucketCounts {
+			buckets[i] = ocmetricdata.Bucket{Count: n}
+		}
+		var bounds []float64
+		if len(buckets) > 1 {
+			bounds = []float64{1}
+		}
+		return ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+			Count:         count,
+			BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+			Buckets:       buckets,
+		})
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						dist(3, 1, 2),
+						dist(5, 1, 2),
+						dist(4),
+					},
+				},
+			},
+		},
+	}
+	counts := func(t *testing.T, output []metricdata.Metrics) []uint64 {
+		require.Len(t, output, 1)
+		var c []uint64
+		for _, p := range output[0].Data.(metricdata.Histogram[float64]).DataPoints {
+			c = append(c, p.Count)
+		}
+		return c
+	}
+	for _, tc := range []struct {
+		desc        string
+		opts        []Option
+		expected    []uint64
+		expectedErr error
+	}{
+		{
+			desc:     "not validated",
+			expected: []uint64{3, 5, 4},
+		},
+		{
+			desc:        "validated",
+			opts:        []Option{WithValidateBucketSum(false)},
+			expected:    []uint64{3, 5, 4},
+			expectedErr: errBucketSumMismatch,
+		},
+		{
+			desc:        "validated and dropped",
+			opts:        []Option{WithValidateBucketSum(true)},
+			expected:    []uint64{3, 4},
+			expectedErr: errBucketSumMismatch,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Contains(t, err.Error(), "buckets 3, distribution 5")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, counts(t, output))
+		})
+	}
+}
+
+func TestConverterEmptyBucketHistogramHandling(t *testing.T) {
+	now := time.Now()
+	dist := func(count int64, bucketCounts ...int64) ocmetricdata.Point {
+		buckets := make([]ocmetricdata.Bucket, len(bucketCounts))
+		for i, n := range bucketCounts {
+			buckets[i] = ocmetricdata.Bucket{Count: n}
+		}
+		var bounds []float64
+		for i := 1; i < len(buckets); i++ {
+			bounds = append(bounds, float64(i))
+		}
+		return ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+			Count:         count,
+			BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+			Buckets:       buckets,
+		})
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{
+						dist(3, 1, 2, 0),
+						dist(5, 0, 0, 0),
+						dist(0, 0, 0, 0),
+						dist(4),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc        string
+		opts        []Option
+		expected    [][]uint64
+		expectedErr error
+	}{
+		{
+			desc:        "default",
+			expectedErr: errInconsistentHistogram,
+		},
+		{
+			desc:        "error",
+			opts:        []Option{WithEmptyBucketHistogramHandling(EmptyBucketHistogramError)},
+			expectedErr: errInconsistentHistogram,
+		},
+		{
+			desc:     "drop",
+			opts:     []Option{WithEmptyBucketHistogramHandling(EmptyBucketHistogramDrop)},
+			expected: [][]uint64{{1, 2, 0}, {0, 0, 0}, {}},
+		},
+		{
+			desc:     "place in overflow",
+			opts:     []Option{WithEmptyBucketHistogramHandling(EmptyBucketHistogramPlaceInOverflow)},
+			expected: [][]uint64{{1, 2, 0}, {0, 0, 5}, {0, 0, 0}, {}},
+		},
+		{
+			desc: "place in overflow with validated bucket sum",
+			opts: []Option{
+				WithEmptyBucketHistogramHandling(EmptyBucketHistogramPlaceInOverflow),
+				WithValidateBucketSum(true),
+			},
+			expected: [][]uint64{{1, 2, 0}, {0, 0, 5}, {0, 0, 0}, {}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Contains(t, err.Error(), "count 5, all buckets empty")
+				assert.Empty(t, output)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, output, 1)
+			var counts [][]uint64
+			for _, p := range output[0].Data.(metricdata.Histogram[float64]).DataPoints {
+				counts = append(counts, p.BucketCounts)
+			}
+			assert.Equal(t, tc.expected, counts)
+		})
+	}
+}
+
+func TestMergeBuckets(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		bounds         []float64
+		bucketCounts   []uint64
+		n              int
+		expectedBounds []float64
+		expectedCounts []uint64
+	}{
+		{
+			desc:           "evenly divisible",
+			bounds:         []float64{1, 2, 3, 4, 5},
+			bucketCounts:   []uint64{1, 2, 3, 4, 5, 6},
+			n:              2,
+			expectedBounds: []float64{3},
+			expectedCounts: []uint64{6, 15},
+		},
+		{
+			desc:           "not evenly divisible",
+			bounds:         []float64{1, 2, 3, 4},
+			bucketCounts:   []uint64{1, 2, 3, 4, 5},
+			n:              2,
+			expectedBounds: []float64{3},
+			expectedCounts: []uint64{6, 9},
+		},
+		{
+			desc:           "single bucket",
+			bounds:         []float64{1, 2},
+			bucketCounts:   []uint64{1, 2, 3},
+			n:              1,
+			expectedBounds: []float64{},
+			expectedCounts: []uint64{6},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bounds, counts := mergeBuckets(tc.bounds, tc.bucketCounts, tc.n)
+			assert.Equal(t, tc.expectedBounds, bounds)
+			assert.Equal(t, tc.expectedCounts, counts)
+		})
+	}
+}
+
+// TestNewSetDuplicateKeys pins the resolution of duplicate keys by
+// attribute.NewSet that convertAttrs relies on.
+func TestNewSetDuplicateKeys(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		attrs    []attribute.KeyValue
+		expected []attribute.KeyValue
+	}{
+		{
+			desc:     "same type",
+			attrs:    []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2"), attribute.String("a", "3")},
+			expected: []attribute.KeyValue{attribute.String("a", "3"), attribute.String("b", "2")},
+		},
+		{
+			desc:     "different types",
+			attrs:    []attribute.KeyValue{attribute.Int64("a", 1), attribute.String("a", "1")},
+			expected: []attribute.KeyValue{attribute.String("a", "1")},
+		},
+		{
+			desc:     "many duplicates",
+			attrs:    []attribute.KeyValue{attribute.Bool("a", true), attribute.Bool("a", false), attribute.Float64("a", 1.5)},
+			expected: []attribute.KeyValue{attribute.Float64("a", 1.5)},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			set := attribute.NewSet(tc.attrs...)
+			assert.Equal(t, tc.expected, set.ToSlice())
+		})
+	}
+}
+
+func TestRoundBounds(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		bounds         []float64
+		decimals       int
+		expectedBounds []float64
+		expectedErr    error
+	}{
+		{
+			desc:           "noisy bounds",
+			bounds:         []float64{0.10000000001, 0.9999999999, 2.5},
+			decimals:       2,
+			expectedBounds: []float64{0.1, 1, 2.5},
+		},
+		{
+			desc:           "zero decimals",
+			bounds:         []float64{-1.4, 1.5, 10},
+			decimals:       0,
+			expectedBounds: []float64{-1, 2, 10},
+		},
+		{
+			desc:           "no bounds",
+			bounds:         []float64{},
+			decimals:       2,
+			expectedBounds: []float64{},
+		},
+		{
+			desc:        "collision",
+			bounds:      []float64{1, 1.001, 2},
+			decimals:    2,
+			expectedErr: errBoundsCollisionAfterRounding,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bounds, err := roundBounds(tc.bounds, tc.decimals)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedBounds, bounds)
+		})
+	}
+}
+
+func TestConverterBoundsRounding(t *testing.T) {
+	now := time.Now()
+	series := func(value string, bounds ...float64) *ocmetricdata.TimeSeries {
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points: []ocmetricdata.Point{
+				ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+					Count:         3,
+					BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+					Buckets:       []ocmetricdata.Bucket{{Count: 1}, {Count: 1}, {Count: 1}},
+				}),
+			},
+		}
+	}
+	histogram := func(bounds ...float64) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/histogram-a",
+				Type:      ocmetricdata.TypeCumulativeDistribution,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{series("x", bounds...)},
+		}
+	}
+
+	output, err := ConvertMetrics([]*ocmetricdata.Metric{histogram(0.9999999999, 2.0000000001)}, WithBoundsRounding(3))
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, []float64{1, 2}, output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Bounds)
+
+	output, err = ConvertMetrics([]*ocmetricdata.Metric{histogram(0.9999999999, 2.0000000001)})
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, []float64{0.9999999999, 2.0000000001}, output[0].Data.(metricdata.Histogram[float64]).DataPoints[0].Bounds)
+
+	output, err = ConvertMetrics([]*ocmetricdata.Metric{histogram(1, 1.0001)}, WithBoundsRounding(3))
+	assert.ErrorIs(t, err, errBoundsCollisionAfterRounding)
+	require.Len(t, output, 1)
+	assert.Empty(t, output[0].Data.(metricdata.Histogram[float64]).DataPoints)
+
+	// Only the data points whose bounds collide are dropped.
+	colliding := histogram(1, 1.0001)
+	colliding.TimeSeries = append(colliding.TimeSeries, series("y", 0.9999999999, 2.0000000001))
+	output, err = ConvertMetrics([]*ocmetricdata.Metric{colliding}, WithBoundsRounding(3))
+	assert.ErrorIs(t, err, errBoundsCollisionAfterRounding)
+	require.Len(t, output, 1)
+	points := output[0].Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, points, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("a", "y")), points[0].Attributes)
+	assert.Equal(t, []float64{1, 2}, points[0].Bounds)
+}
+
+func TestConverterHistogramSumRounding(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         3,
+						Sum:           99.99999999997,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: []float64{0.3333333}},
+						Buckets:       []ocmetricdata.Bucket{{Count: 1}, {Count: 2}},
+					}),
+				},
+			}},
+		},
+	}
+	point := func(t *testing.T, opts ...Option) metricdata.HistogramDataPoint[float64] {
+		output, err := ConvertMetrics(input, opts...)
+		require.NoError(t, err)
+		require.Len(t, output, 1)
+		return output[0].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	}
+
+	p := point(t, WithHistogramSumRounding(2))
+	assert.Equal(t, 100.0, p.Sum)
+	assert.Equal(t, uint64(3), p.Count)
+	assert.Equal(t, []float64{0.3333333}, p.Bounds)
+	assert.Equal(t, []uint64{1, 2}, p.BucketCounts)
+
+	assert.Equal(t, 99.99999999997, point(t).Sum)
+	assert.Equal(t, 99.99999999997, point(t, WithHistogramSumRounding(-1)).Sum)
+}
+
+func TestConverterSkipEmptyMetrics(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+			}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{}, {}},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+		},
+	}
+	names := func(metrics []metricdata.Metrics) []string {
+		var names []string
+		for _, m := range metrics {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	c := NewConverter()
+	output, err := c.ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo.com/gauge-a", "foo.com/sum-a", "foo.com/histogram-a"}, names(output))
+	assert.Empty(t, output[1].Data.(metricdata.Sum[int64]).DataPoints)
+	assert.Equal(t, 0, c.Stats().EmptyMetrics)
+
+	c = NewConverter(WithSkipEmptyMetrics())
+	output, err = c.ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo.com/gauge-a"}, names(output))
+	assert.Equal(t, 2, c.Stats().EmptyMetrics)
+}
+
+func TestConvertAttributes(t *testing.T) {
+	setWithMultipleKeys := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1")},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.StringValue("2")},
+	)
+	setWithInvalidUTF8 := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("a\xffb")},
+	)
+	setWithReplacedUTF8 := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("a\uFFFDb")},
+	)
+	setWithInt64Values := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.Int64Value(math.MinInt64)},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.StringValue("2")},
+		attribute.KeyValue{Key: attribute.Key("third"), Value: attribute.Int64Value(3)},
+	)
+	setWithLargeIntString := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("99999999999999999999")},
+	)
+	setWithFloatString := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1.5")},
+	)
+	setWithBoolValues := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.BoolValue(true)},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.BoolValue(false)},
+		attribute.KeyValue{Key: attribute.Key("third"), Value: attribute.StringValue("true")},
+	)
+	setWithInvalidBool := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("yes")},
+	)
+	setWithNormalizedValue := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("get")},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.StringValue("POST")},
+	)
+	setWithExtraValue := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1")},
+		attribute.KeyValue{Key: attribute.Key("label_1"), Value: attribute.StringValue("2")},
+	)
+	setWithNumericValues := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.Int64Value(-1)},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.Float64Value(2.5)},
+		attribute.KeyValue{Key: attribute.Key("third"), Value: attribute.BoolValue(true)},
+		attribute.KeyValue{Key: attribute.Key("fourth"), Value: attribute.StringValue("4")},
+	)
+	setWithInvalidNumericValues := attribute.NewSet(
+		attribute.KeyValue{Key: attribute.Key("first"), Value: attribute.StringValue("1.5")},
+		attribute.KeyValue{Key: attribute.Key("second"), Value: attribute.StringValue("two")},
+	)
+	for _, tc := range []struct {
+		desc        string
+		inputKeys   []ocmetricdata.LabelKey
+		inputValues []ocmetricdata.LabelValue
+		opts        []Option
+		expected    *attribute.Set
+		expectedErr error
+	}{
+		{
+			desc:     "no attributes",
+			expected: attribute.EmptySet(),
+		},
+		{
+			desc:        "different numbers of keys and values",
+			inputKeys:   []ocmetricdata.LabelKey{{Key: "foo"}},
+			expected:    attribute.EmptySet(),
+			expectedErr: errMismatchedAttributeKeyValues,
+		},
+		{
+			desc:      "duplicate keys",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "0", Present: true},
+				{Value: "2", Present: true},
+				{Value: "1", Present: true},
+			},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "duplicate keys with absent last value",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+				{},
+			},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "attribute value mapper",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: " GET", Present: true},
+				{Value: "POST", Present: true},
+			},
+			opts: []Option{WithAttributeValueMapper(func(k attribute.Key, v attribute.Value) attribute.Value {
+				if k != "first" {
+					return v
+				}
+				return attribute.StringValue(strings.ToLower(strings.TrimSpace(v.AsString())))
+			})},
+			expected: &setWithNormalizedValue,
+		},
+		{
+			desc:      "attribute value mapper changing type",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts: []Option{
+				WithInt64Labels("first", "second"),
+				WithAttributeValueMapper(func(_ attribute.Key, v attribute.Value) attribute.Value {
+					return attribute.StringValue(v.Emit())
+				}),
+			},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "extra values tolerated",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+				{},
+			},
+			opts:        []Option{WithTolerateExtraValues("label_")},
+			expected:    &setWithExtraValue,
+			expectedErr: errMismatchedAttributeKeyValues,
+		},
+		{
+			desc:      "missing values tolerated",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts:        []Option{WithTolerateExtraValues("label_")},
+			expected:    &setWithMultipleKeys,
+			expectedErr: errMismatchedAttributeKeyValues,
+		},
+		{
+			desc:      "multiple keys and values",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "multiple keys and values with some not present",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+				{Present: false},
+			},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "more attributes than the max attribute count",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "zero"}, {Key: "first"}, {Key: "second"}, {Key: "third"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Present: false},
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+				{Value: "3", Present: true},
+			},
+			opts:        []Option{WithMaxAttributeCount(2)},
+			expected:    &setWithMultipleKeys,
+			expectedErr: errTooManyAttributes,
+		},
+		{
+			desc:      "zero max attribute count",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+			},
+			opts:        []Option{WithMaxAttributeCount(0)},
+			expected:    attribute.EmptySet(),
+			expectedErr: errTooManyAttributes,
+		},
+		{
+			desc:      "max attribute count not exceeded",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts:     []Option{WithMaxAttributeCount(2)},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "attribute allow list",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "dropped"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "0", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts:     []Option{WithAttributeAllowList("first", "second", "absent")},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "attribute key mapper",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "FIRST"}, {Key: "SECOND"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts: []Option{WithAttributeKeyMapper(func(k attribute.Key) attribute.Key {
+				return attribute.Key(strings.ToLower(string(k)))
+			})},
+			expected: &setWithMultipleKeys,
+		},
+		{
+			desc:      "attribute key mapper collision",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "First"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "0", Present: true},
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts: []Option{WithAttributeKeyMapper(func(k attribute.Key) attribute.Key {
+				return attribute.Key(strings.ToLower(string(k)))
+			})},
+			expected:    &setWithMultipleKeys,
+			expectedErr: errAttributeKeyCollision,
+		},
+		{
+			desc:      "invalid UTF-8 not validated",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "a\xffb", Present: true},
+			},
+			expected: &setWithInvalidUTF8,
+		},
+		{
+			desc:      "invalid UTF-8 replaced",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "a\xffb", Present: true},
+			},
+			opts:        []Option{WithValidateUTF8()},
+			expected:    &setWithReplacedUTF8,
+			expectedErr: errInvalidUTF8,
+		},
+		{
+			desc:      "int64 labels",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "-9223372036854775808", Present: true},
+				{Value: "2", Present: true},
+				{Value: "3", Present: true},
+			},
+			opts:     []Option{WithInt64Labels("first", "third")},
+			expected: &setWithInt64Values,
+		},
+		{
+			desc:      "int64 label out of range",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "99999999999999999999", Present: true},
+			},
+			opts:        []Option{WithInt64Labels("first")},
+			expected:    &setWithLargeIntString,
+			expectedErr: strconv.ErrRange,
+		},
+		{
+			desc:      "int64 label not an integer",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1.5", Present: true},
+			},
+			opts:        []Option{WithInt64Labels("first")},
+			expected:    &setWithFloatString,
+			expectedErr: errInvalidLabelValue,
+		},
+		{
+			desc:      "boolean labels",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "TRUE", Present: true},
+				{Value: "False", Present: true},
+				{Value: "true", Present: true},
+			},
+			opts:     []Option{WithBooleanLabels("first", "second")},
+			expected: &setWithBoolValues,
+		},
+		{
+			desc:      "boolean label not a boolean",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "yes", Present: true},
+			},
+			opts:        []Option{WithBooleanLabels("first")},
+			expected:    &setWithInvalidBool,
+			expectedErr: errInvalidLabelValue,
+		},
+		{
+			desc:      "numeric label types",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}, {Key: "third"}, {Key: "fourth"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "-1", Present: true},
+				{Value: "2.5", Present: true},
+				{Value: "True", Present: true},
+				{Value: "4", Present: true},
+			},
+			opts: []Option{WithNumericLabelTypes(map[string]attribute.Type{
+				"first":  attribute.INT64,
+				"second": attribute.FLOAT64,
+				"third":  attribute.BOOL,
+				"fourth": attribute.STRINGSLICE,
+			})},
+			expected: &setWithNumericValues,
+		},
+		{
+			desc:      "numeric label types override",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1.5", Present: true},
+			},
+			opts: []Option{
+				WithInt64Labels("first"),
+				WithNumericLabelTypes(map[string]attribute.Type{"first": attribute.STRING}),
+			},
+			expected: &setWithFloatString,
+		},
+		{
+			desc:      "numeric label types not parsed",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1.5", Present: true},
+				{Value: "two", Present: true},
+			},
+			opts: []Option{WithNumericLabelTypes(map[string]attribute.Type{
+				"first":  attribute.INT64,
+				"second": attribute.FLOAT64,
+			})},
+			expected:    &setWithInvalidNumericValues,
+			expectedErr: strconv.ErrSyntax,
+		},
+		{
+			desc:      "valid UTF-8 validated",
+			inputKeys: []ocmetricdata.LabelKey{{Key: "first"}, {Key: "second"}},
+			inputValues: []ocmetricdata.LabelValue{
+				{Value: "1", Present: true},
+				{Value: "2", Present: true},
+			},
+			opts:     []Option{WithValidateUTF8()},
+			expected: &setWithMultipleKeys,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(tc.opts...)
+			output, err := c.convertAttrs(tc.inputKeys, tc.inputValues)
+			err = errors.Join(err, c.warnings)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("convertAttrs(keys: %v, values: %v) = err(%v), want err(%v)", tc.inputKeys, tc.inputValues, err, tc.expectedErr)
+			}
+			if !output.Equals(tc.expected) {
+				t.Errorf("convertAttrs(keys: %v, values: %v) = %+v, want %+v", tc.inputKeys, tc.inputValues, output.ToSlice(), tc.expected.ToSlice())
+			}
+		})
+	}
+}
+
+func BenchmarkConvertAttributes(b *testing.B) {
+	for _, n := range []int{0, 1, 2, 5} {
+		keys := make([]ocmetricdata.LabelKey, n)
+		values := make([]ocmetricdata.LabelValue, n)
+		for i := 0; i < n; i++ {
+			keys[i] = ocmetricdata.LabelKey{Key: fmt.Sprintf("key%d", i)}
+			values[i] = ocmetricdata.LabelValue{Value: fmt.Sprintf("value%d", i), Present: true}
+		}
+		c := NewConverter()
+		b.Run(fmt.Sprintf("Labels/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.convertAttrs(keys, values)
+			}
+		})
+		pool := &sync.Pool{}
+		for _, opts := range []struct {
+			name string
+			opts []Option
+		}{
+			{name: "Parallel"},
+			{name: "ParallelPooled", opts: []Option{WithKeyValueBufferPool(pool)}},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", opts.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					c := NewConverter(opts.opts...)
+					for pb.Next() {
+						_, _ = c.convertAttrs(keys, values)
+					}
+				})
+			})
+		}
+	}
+}
+
+func TestConverterKeyValueBufferPool(t *testing.T) {
+	const n = 20
+	ocmetrics := make([]*ocmetricdata.Metric, n)
+	for i := range ocmetrics {
+		ocmetrics[i] = &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      fmt.Sprintf("foo.com/gauge-%d", i),
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}, {Key: "c"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "c" + strconv.Itoa(i), Present: true},
+						{Value: "b" + strconv.Itoa(i), Present: true},
+						{Value: "a" + strconv.Itoa(i), Present: true},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), int64(i))},
+				},
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "x" + strconv.Itoa(i), Present: true},
+						{},
+						{Value: "z" + strconv.Itoa(i), Present: true},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), int64(i))},
+				},
+			},
+		}
+	}
+	expected, err := ConvertMetrics(ocmetrics)
+	require.NoError(t, err)
+
+	var newCount atomic.Int64
+	pool := &sync.Pool{New: func() any {
+		newCount.Add(1)
+		return new([]attribute.KeyValue)
+	}}
+	// Converters sharing the pool concurrently must not observe each
+	// other's buffers, which the race detector reports.
+	const workers = 8
+	outputs := make([][]metricdata.Metrics, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			c := NewConverter(WithKeyValueBufferPool(pool))
+			for i := 0; i < 10; i++ {
+				outputs[w], _ = c.ConvertMetrics(ocmetrics)
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, output := range outputs {
+		require.Len(t, output, n)
+		for i := range output {
+			metricdatatest.AssertEqual(t, expected[i], output[i])
+		}
+	}
+	assert.Less(t, newCount.Load(), int64(workers*10*n*2), "buffers are reused")
+
+	// Buffers returned to the pool do not retain attribute values.
+	buf := pool.Get().(*[]attribute.KeyValue)
+	assert.Empty(t, *buf)
+	for _, kv := range (*buf)[:cap(*buf)] {
+		assert.Equal(t, attribute.KeyValue{}, kv)
+	}
+
+	// Unexpected pool values are ignored.
+	c := NewConverter(WithKeyValueBufferPool(&sync.Pool{New: func() any { return "buffer" }}))
+	output, err := c.ConvertMetrics(ocmetrics)
+	require.NoError(t, err)
+	require.Len(t, output, n)
+	metricdatatest.AssertEqual(t, expected[0], output[0])
+}
+
+func TestConverterErrorPrefix(t *testing.T) {
+	ocmetrics := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/bad-point",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(time.Now(), 1)},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc    string
+		options []Option
+		prefix  string
+	}{
+		{
+			desc:   "default",
+			prefix: "error converting from OpenCensus to OpenTelemetry: ",
+		},
+		{
+			desc:    "custom",
+			options: []Option{WithErrorPrefix("tenant-a")},
+			prefix:  "tenant-a: ",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewConverter(tc.options...).ConvertMetrics(ocmetrics)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, errMismatchedValueTypes)
+			assert.True(t, strings.HasPrefix(err.Error(), tc.prefix), "error %q", err)
+		})
+	}
+}
+
+func TestConverterSuccessHandler(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)}},
+			},
+		},
+		nil,
+		{
+			// Dropped for its point of the wrong value type.
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-b", Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 2)}},
+			},
+		},
+		{
+			// Dropped for its empty name.
+			Descriptor: ocmetricdata.Descriptor{Type: ocmetricdata.TypeGaugeInt64},
+		},
+		{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/sum-a", Type: ocmetricdata.TypeCumulativeFloat64},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 3)}, StartTime: now},
+			},
+		},
+	}
+
+	var handled []metricdata.Metrics
+	c := NewConverter(WithSuccessHandler(func(m metricdata.Metrics) {
+		handled = append(handled, m)
+	}))
+	output, err := c.ConvertMetrics(input)
+	assert.ErrorIs(t, err, errMismatchedValueTypes)
+	require.Len(t, output, 2)
+	require.Len(t, handled, len(output), "called once per converted metric")
+	for i := range output {
+		metricdatatest.AssertEqual(t, output[i], handled[i])
+	}
+}
+
+func TestConverterLogger(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			// Dropped for its point of the wrong value type.
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 1)}},
+			},
+		},
+		{
+			// Converted with a warning for its attributes.
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge-b",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}, {Key: "b"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{{Value: "a", Present: true}, {Value: "b", Present: true}},
+					Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 2)},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc      string
+		verbosity int
+		expected  []string
+	}{
+		{
+			desc:      "debug",
+			verbosity: 8,
+			expected: []string{
+				`"level"=4 "msg"="OpenCensus metric dropped" "metric"="foo.com/gauge-a" "error"="wrong value type for data point: 1"`,
+				`"level"=8 "msg"="warning converting OpenCensus metric" "metric"="foo.com/gauge-b" "error"="too many attributes: limit 1"`,
+			},
+		},
+		{
+			desc:      "info",
+			verbosity: 4,
+			expected: []string{
+				`"level"=4 "msg"="OpenCensus metric dropped" "metric"="foo.com/gauge-a" "error"="wrong value type for data point: 1"`,
+			},
+		},
+		{
+			desc:      "warn",
+			verbosity: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var logged []string
+			logger := funcr.New(func(prefix, args string) {
+				logged = append(logged, args)
+			}, funcr.Options{Verbosity: tc.verbosity})
+
+			output, err := ConvertMetrics(input, WithLogger(logger), WithMaxAttributeCount(1))
+			assert.ErrorIs(t, err, errMismatchedValueTypes)
+			assert.ErrorIs(t, err, errTooManyAttributes)
+			assert.Len(t, output, 1)
+			assert.Equal(t, tc.expected, logged)
 		})
 	}
 }
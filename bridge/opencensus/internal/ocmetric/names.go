@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	errInvalidInstrumentName   = errors.New("invalid instrument name")
+	errInstrumentNameCollision = errors.New("sanitized instrument name collides with another metric")
+	errInvalidAttributeKey     = errors.New("invalid attribute key")
+)
+
+const (
+	// maxInstrumentNameLength is the maximum length of an OpenTelemetry
+	// instrument name.
+	maxInstrumentNameLength = 255
+	// sanitizedNamePrefix is prepended to sanitized names that do not start
+	// with a letter.
+	sanitizedNamePrefix = "oc_"
+)
+
+// instrumentName returns the name of the current metric, of full name name,
+// validated according to the instrument name validation. An error is
+// returned if the metric is dropped.
+func (c *Converter) instrumentName(name string) (string, error) {
+	switch c.cfg.instrumentNameValidation {
+	case InstrumentNameStrict:
+		return name, validateInstrumentName(name)
+	case InstrumentNameSanitize:
+		sanitized := name
+		if validateInstrumentName(name) != nil {
+			sanitized = sanitizeInstrumentName(name)
+		}
+		if orig, ok := c.instrumentNames[sanitized]; ok && orig != name {
+			return "", fmt.Errorf("%w: %q and %q converted as %q", errInstrumentNameCollision, orig, name, sanitized)
+		}
+		c.instrumentNames[sanitized] = name
+		return sanitized, nil
+	default:
+		return name, nil
+	}
+}
+
+// validateInstrumentName returns an error if name is not a valid
+// OpenTelemetry instrument name: it must start with a letter, contain at most
+// 255 characters, and only contain alphanumeric characters, '_', '.', '-',
+// and '/'.
+func validateInstrumentName(name string) error {
+	if len(name) > maxInstrumentNameLength {
+		return fmt.Errorf("%w: %q: longer than %d characters", errInvalidInstrumentName, name, maxInstrumentNameLength)
+	}
+	if name == "" || !isAlpha(rune(name[0])) {
+		return fmt.Errorf("%w: %q: must start with a letter", errInvalidInstrumentName, name)
+	}
+	for _, r := range name[1:] {
+		if !validNameRune(r) {
+			return fmt.Errorf("%w: %q: must only contain [A-Za-z0-9_.-/]", errInvalidInstrumentName, name)
+		}
+	}
+	return nil
+}
+
+// sanitizeInstrumentName returns name as a valid instrument name. Invalid
+// characters are replaced with '_', sanitizedNamePrefix is prepended if name
+// does not start with a letter, and the result is truncated to 255
+// characters.
+func sanitizeInstrumentName(name string) string {
+	var b strings.Builder
+	if name == "" || !isAlpha(rune(name[0])) {
+		b.WriteString(sanitizedNamePrefix)
+	}
+	for _, r := range name {
+		if !validNameRune(r) {
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	sanitized := b.String()
+	if len(sanitized) > maxInstrumentNameLength {
+		sanitized = sanitized[:maxInstrumentNameLength]
+	}
+	return sanitized
+}
+
+// attributeKey returns key validated according to the attribute key
+// validation, and false if the attribute is dropped.
+func (c *Converter) attributeKey(key attribute.Key) (attribute.Key, bool) {
+	if validAttributeKey(key) {
+		return key, true
+	}
+	switch c.cfg.attributeKeyValidation {
+	case AttributeKeyDrop:
+		c.warn(fmt.Errorf("%w: %q dropped", errInvalidAttributeKey, key))
+		return "", false
+	case AttributeKeySanitize:
+		if key == "" {
+			c.warn(fmt.Errorf("%w: empty key dropped", errInvalidAttributeKey))
+			return "", false
+		}
+		sanitized := sanitizeAttributeKey(key)
+		c.warn(fmt.Errorf("%w: %q converted as %q", errInvalidAttributeKey, key, sanitized))
+		return sanitized, true
+	default:
+		c.warn(fmt.Errorf("%w: %q", errInvalidAttributeKey, key))
+		return key, true
+	}
+}
+
+// validAttributeKey returns true if key is not empty and only contains
+// alphanumeric characters, '_', '.', and '-'.
+func validAttributeKey(key attribute.Key) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !validAttributeKeyRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeAttributeKey returns key with invalid characters replaced with
+// '_'.
+func sanitizeAttributeKey(key attribute.Key) attribute.Key {
+	return attribute.Key(strings.Map(func(r rune) rune {
+		if !validAttributeKeyRune(r) {
+			return '_'
+		}
+		return r
+	}, string(key)))
+}
+
+func validAttributeKeyRune(r rune) bool {
+	return isAlpha(r) || ('0' <= r && r <= '9') || r == '_' || r == '.' || r == '-'
+}
+
+func validNameRune(r rune) bool {
+	return isAlpha(r) || ('0' <= r && r <= '9') || r == '_' || r == '.' || r == '-' || r == '/'
+}
+
+func isAlpha(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
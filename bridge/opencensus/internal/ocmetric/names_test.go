@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestInstrumentNames(t *testing.T) {
+	long := "a" + strings.Repeat("b", 300)
+	for _, tc := range []struct {
+		desc      string
+		name      string
+		valid     bool
+		sanitized string
+	}{
+		{
+			desc:      "valid",
+			name:      "foo.com/latency_ms-total",
+			valid:     true,
+			sanitized: "foo.com/latency_ms-total",
+		},
+		{
+			desc:      "maximum length",
+			name:      long[:255],
+			valid:     true,
+			sanitized: long[:255],
+		},
+		{
+			desc:      "too long",
+			name:      long,
+			sanitized: long[:255],
+		},
+		{
+			desc:      "starts with a digit",
+			name:      "2xx_responses",
+			sanitized: "oc_2xx_responses",
+		},
+		{
+			desc:      "starts with an underscore",
+			name:      "_count",
+			sanitized: "oc__count",
+		},
+		{
+			desc:      "illegal characters",
+			name:      "foo.com/latency (ms):p99",
+			sanitized: "foo.com/latency__ms__p99",
+		},
+		{
+			desc:      "non-ASCII characters",
+			name:      "größe",
+			sanitized: "gr__e",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validateInstrumentName(tc.name)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errInvalidInstrumentName)
+			}
+			sanitized := sanitizeInstrumentName(tc.name)
+			assert.Equal(t, tc.sanitized, sanitized)
+			assert.NoError(t, validateInstrumentName(sanitized))
+		})
+	}
+}
+
+func TestConverterInstrumentNameValidation(t *testing.T) {
+	metric := func(name string) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: name,
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		metric("foo.com/gauge-a"),
+		metric("1gauge"),
+		metric("foo.com/gauge b"),
+		metric("foo.com/gauge_b"),
+	}
+	names := func(metrics []metricdata.Metrics) []string {
+		var names []string
+		for _, m := range metrics {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	output, err := ConvertMetrics(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo.com/gauge-a", "1gauge", "foo.com/gauge b", "foo.com/gauge_b"}, names(output))
+
+	output, err = ConvertMetrics(input, WithInstrumentNameValidation(InstrumentNameStrict))
+	assert.ErrorIs(t, err, errInvalidInstrumentName)
+	assert.Equal(t, 2, strings.Count(err.Error(), errInvalidInstrumentName.Error()))
+	assert.Equal(t, []string{"foo.com/gauge-a", "foo.com/gauge_b"}, names(output))
+
+	output, err = ConvertMetrics(input, WithInstrumentNameValidation(InstrumentNameSanitize))
+	assert.ErrorIs(t, err, errInstrumentNameCollision)
+	assert.NotErrorIs(t, err, errInvalidInstrumentName)
+	assert.Equal(t, []string{"foo.com/gauge-a", "oc_1gauge", "foo.com/gauge_b"}, names(output))
+
+	output, err = ConvertMetrics(input, WithMetricNamePrefix("_"), WithInstrumentNameValidation(InstrumentNameStrict))
+	assert.ErrorIs(t, err, errInvalidInstrumentName)
+	assert.Empty(t, output)
+}
+
+func TestConverterAttributeKeyValidation(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/gauge-a",
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "http.method"}, {Key: ""}, {Key: "status code"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					LabelValues: []ocmetricdata.LabelValue{
+						{Value: "GET", Present: true},
+						{Value: "empty", Present: true},
+						{Value: "200", Present: true},
+					},
+					Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(time.Now(), 1)},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc     string
+		options  []Option
+		expected attribute.Set
+		invalid  int
+	}{
+		{
+			desc: "default",
+			expected: attribute.NewSet(
+				attribute.String("http.method", "GET"),
+				attribute.String("", "empty"),
+				attribute.String("status code", "200"),
+			),
+			invalid: 2,
+		},
+		{
+			desc:    "warn",
+			options: []Option{WithValidateAttributeKeys(AttributeKeyWarn)},
+			expected: attribute.NewSet(
+				attribute.String("http.method", "GET"),
+				attribute.String("", "empty"),
+				attribute.String("status code", "200"),
+			),
+			invalid: 2,
+		},
+		{
+			desc:     "drop",
+			options:  []Option{WithValidateAttributeKeys(AttributeKeyDrop)},
+			expected: attribute.NewSet(attribute.String("http.method", "GET")),
+			invalid:  2,
+		},
+		{
+			desc:    "sanitize",
+			options: []Option{WithValidateAttributeKeys(AttributeKeySanitize)},
+			expected: attribute.NewSet(
+				attribute.String("http.method", "GET"),
+				attribute.String("status_code", "200"),
+			),
+			invalid: 2,
+		},
+		{
+			desc: "mapped keys",
+			options: []Option{
+				WithValidateAttributeKeys(AttributeKeyDrop),
+				WithAttributeKeyMapper(func(k attribute.Key) attribute.Key {
+					return attribute.Key(strings.ReplaceAll(string(k), " ", "."))
+				}),
+			},
+			expected: attribute.NewSet(
+				attribute.String("http.method", "GET"),
+				attribute.String("status.code", "200"),
+			),
+			invalid: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, tc.options...)
+			assert.ErrorIs(t, err, errInvalidAttributeKey)
+			assert.Equal(t, tc.invalid, strings.Count(err.Error(), errInvalidAttributeKey.Error()))
+			require.Len(t, output, 1)
+			points := output[0].Data.(metricdata.Gauge[int64]).DataPoints
+			require.Len(t, points, 1)
+			assert.Equal(t, tc.expected, points[0].Attributes)
+		})
+	}
+}
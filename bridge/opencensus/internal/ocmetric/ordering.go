@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"sort"
+	"time"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// metricOrder returns the indices of ocmetrics in the order they are
+// converted, or nil if they are converted in their order.
+//
+// With stable ordering, the order of ocmetrics is compared to the order of
+// the metrics of the previous conversion. Once the same metric names appear
+// in a different order, the order is considered nondeterministic, and the
+// metrics of this and all later conversions are sorted by name.
+func (c *Converter) metricOrder(ocmetrics []*ocmetricdata.Metric) []int {
+	if !c.cfg.stableOrdering {
+		return nil
+	}
+	names := make([]string, len(ocmetrics))
+	for i, ocm := range ocmetrics {
+		if ocm != nil {
+			names[i] = ocm.Descriptor.Name
+		}
+	}
+	if !c.unstableOrder && reordered(c.lastNames, names) {
+		c.unstableOrder = true
+	}
+	c.lastNames = names
+	if !c.unstableOrder {
+		return nil
+	}
+	order := make([]int, len(ocmetrics))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return names[order[i]] < names[order[j]]
+	})
+	return order
+}
+
+// reordered returns true if names has the same names as prev in a different
+// order.
+func reordered(prev, names []string) bool {
+	if len(prev) != len(names) {
+		return false
+	}
+	counts := make(map[string]int, len(names))
+	changed := false
+	for i, name := range names {
+		if name != prev[i] {
+			changed = true
+		}
+		counts[name]++
+		counts[prev[i]]--
+	}
+	if !changed {
+		return false
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sortByAttributes stably sorts the data points of agg by their encoded
+// attributes, then time.
+func sortByAttributes(agg metricdata.Aggregation) {
+	switch a := agg.(type) {
+	case metricdata.Gauge[int64]:
+		sortPointsByAttributes(a.DataPoints, func(p metricdata.DataPoint[int64]) (attribute.Set, time.Time) { return p.Attributes, p.Time })
+	case metricdata.Gauge[float64]:
+		sortPointsByAttributes(a.DataPoints, func(p metricdata.DataPoint[float64]) (attribute.Set, time.Time) { return p.Attributes, p.Time })
+	case metricdata.Sum[int64]:
+		sortPointsByAttributes(a.DataPoints, func(p metricdata.DataPoint[int64]) (attribute.Set, time.Time) { return p.Attributes, p.Time })
+	case metricdata.Sum[float64]:
+		sortPointsByAttributes(a.DataPoints, func(p metricdata.DataPoint[float64]) (attribute.Set, time.Time) { return p.Attributes, p.Time })
+	case metricdata.Histogram[float64]:
+		sortPointsByAttributes(a.DataPoints, func(p metricdata.HistogramDataPoint[float64]) (attribute.Set, time.Time) { return p.Attributes, p.Time })
+	}
+}
+
+// sortPointsByAttributes stably sorts points by the encoding of the
+// attributes, then the time, returned by key.
+func sortPointsByAttributes[P any](points []P, key func(P) (attribute.Set, time.Time)) {
+	s := pointsByAttributes[P]{
+		points: points,
+		attrs:  make([]string, len(points)),
+		times:  make([]time.Time, len(points)),
+	}
+	enc := attribute.DefaultEncoder()
+	for i, p := range points {
+		attrs, t := key(p)
+		s.attrs[i], s.times[i] = attrs.Encoded(enc), t
+	}
+	sort.Stable(s)
+}
+
+// pointsByAttributes sorts points by their encoded attributes, then time.
+type pointsByAttributes[P any] struct {
+	points []P
+	attrs  []string
+	times  []time.Time
+}
+
+func (s pointsByAttributes[P]) Len() int { return len(s.points) }
+
+func (s pointsByAttributes[P]) Less(i, j int) bool {
+	if s.attrs[i] != s.attrs[j] {
+		return s.attrs[i] < s.attrs[j]
+	}
+	return s.times[i].Before(s.times[j])
+}
+
+func (s pointsByAttributes[P]) Swap(i, j int) {
+	s.points[i], s.points[j] = s.points[j], s.points[i]
+	s.attrs[i], s.attrs[j] = s.attrs[j], s.attrs[i]
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestConverterStableOrdering(t *testing.T) {
+	now := time.Now()
+	gauge := func(name string, values ...string) *ocmetricdata.Metric {
+		ts := make([]*ocmetricdata.TimeSeries, len(values))
+		for i, v := range values {
+			ts[i] = &ocmetricdata.TimeSeries{
+				LabelValues: []ocmetricdata.LabelValue{{Value: v, Present: true}},
+				Points:      []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)},
+			}
+		}
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Type:      ocmetricdata.TypeGaugeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: ts,
+		}
+	}
+	order := func(output []metricdata.Metrics) (names, values []string) {
+		for _, m := range output {
+			names = append(names, m.Name)
+			for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+				v, _ := dp.Attributes.Value("a")
+				values = append(values, v.AsString())
+			}
+		}
+		return names, values
+	}
+	for _, tc := range []struct {
+		desc           string
+		opts           []Option
+		batches        [][]*ocmetricdata.Metric
+		expectedNames  []string
+		expectedValues []string
+	}{
+		{
+			desc: "reordered without stable ordering",
+			batches: [][]*ocmetricdata.Metric{
+				{gauge("foo.com/a", "1", "2"), gauge("foo.com/b", "3")},
+				{gauge("foo.com/b", "3"), gauge("foo.com/a", "2", "1")},
+			},
+			expectedNames:  []string{"foo.com/b", "foo.com/a"},
+			expectedValues: []string{"3", "2", "1"},
+		},
+		{
+			desc: "deterministic order",
+			opts: []Option{WithStableOrdering()},
+			batches: [][]*ocmetricdata.Metric{
+				{gauge("foo.com/b", "2", "1"), gauge("foo.com/a", "3")},
+				{gauge("foo.com/b", "2", "1"), gauge("foo.com/a", "3")},
+			},
+			expectedNames:  []string{"foo.com/b", "foo.com/a"},
+			expectedValues: []string{"2", "1", "3"},
+		},
+		{
+			desc: "different metrics",
+			opts: []Option{WithStableOrdering()},
+			batches: [][]*ocmetricdata.Metric{
+				{gauge("foo.com/a", "1"), gauge("foo.com/c", "3")},
+				{gauge("foo.com/c", "3"), gauge("foo.com/b", "2")},
+			},
+			expectedNames:  []string{"foo.com/c", "foo.com/b"},
+			expectedValues: []string{"3", "2"},
+		},
+		{
+			desc: "reordered",
+			opts: []Option{WithStableOrdering()},
+			batches: [][]*ocmetricdata.Metric{
+				{gauge("foo.com/a", "1", "2"), gauge("foo.com/b", "3")},
+				{gauge("foo.com/b", "3"), gauge("foo.com/a", "2", "1")},
+			},
+			expectedNames:  []string{"foo.com/a", "foo.com/b"},
+			expectedValues: []string{"1", "2", "3"},
+		},
+		{
+			desc: "sorted after reordering",
+			opts: []Option{WithStableOrdering()},
+			batches: [][]*ocmetricdata.Metric{
+				{gauge("foo.com/a", "1"), gauge("foo.com/b", "2")},
+				{gauge("foo.com/b", "2"), gauge("foo.com/a", "1")},
+				{gauge("foo.com/c", "4", "3"), gauge("foo.com/b", "2")},
+			},
+			expectedNames:  []string{"foo.com/b", "foo.com/c"},
+			expectedValues: []string{"2", "3", "4"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(tc.opts...)
+			var output []metricdata.Metrics
+			for _, batch := range tc.batches {
+				var err error
+				output, err = c.ConvertMetrics(batch)
+				require.NoError(t, err)
+			}
+			names, values := order(output)
+			assert.Equal(t, tc.expectedNames, names)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+
+	t.Run("reset", func(t *testing.T) {
+		c := NewConverter(WithStableOrdering())
+		_, err := c.ConvertMetrics([]*ocmetricdata.Metric{gauge("foo.com/a", "1"), gauge("foo.com/b", "2")})
+		require.NoError(t, err)
+		_, err = c.ConvertMetrics([]*ocmetricdata.Metric{gauge("foo.com/b", "2"), gauge("foo.com/a", "1")})
+		require.NoError(t, err)
+		c.Reset()
+		output, err := c.ConvertMetrics([]*ocmetricdata.Metric{gauge("foo.com/b", "2"), gauge("foo.com/a", "1")})
+		require.NoError(t, err)
+		names, _ := order(output)
+		assert.Equal(t, []string{"foo.com/b", "foo.com/a"}, names)
+	})
+}
+
+// BenchmarkOrdering compares converting metrics in their OpenCensus order,
+// with stable ordering of a deterministic order, and with sorting.
+func BenchmarkOrdering(b *testing.B) {
+	ocmetrics := histograms(100)
+	reversed := make([]*ocmetricdata.Metric, len(ocmetrics))
+	for i, ocm := range ocmetrics {
+		reversed[len(ocmetrics)-1-i] = ocm
+	}
+
+	b.Run("Unsorted", func(b *testing.B) {
+		c := NewConverter()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			_, _ = c.ConvertMetrics(ocmetrics)
+		}
+	})
+	b.Run("StableOrderingDeterministic", func(b *testing.B) {
+		c := NewConverter(WithStableOrdering())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			_, _ = c.ConvertMetrics(ocmetrics)
+		}
+	})
+	b.Run("StableOrderingSorted", func(b *testing.B) {
+		c := NewConverter(WithStableOrdering())
+		_, _ = c.ConvertMetrics(ocmetrics)
+		_, _ = c.ConvertMetrics(reversed)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			_, _ = c.ConvertMetrics(ocmetrics)
+		}
+	})
+}
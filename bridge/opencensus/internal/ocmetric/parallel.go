@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// parallelResult is the result of the conversion of a metric by a worker of
+// ConvertMetricsParallel.
+type parallelResult struct {
+	metric metricdata.Metrics
+	ok     bool
+	err    error
+	// name is the name of the metric, or empty if it was dropped before its
+	// name was validated.
+	name string
+	// errCount is the number of errors of err.
+	errCount int
+	// stats are the statistics of the conversion of the metric, except its
+	// attribute sets.
+	stats Stats
+	// dropped is the first error that caused the metric to be dropped, by
+	// metric name.
+	dropped map[string]error
+	// duration is the duration of the conversion, if it is measured.
+	duration time.Duration
+}
+
+// ConvertMetricsParallel converts metric data from OpenCensus to
+// OpenTelemetry like ConvertMetrics with opts, using up to workers
+// goroutines to convert metrics concurrently. A workers value less than 1 is
+// treated as 1.
+//
+// The converted metrics and errors are those ConvertMetrics returns, in the
+// same order: name collisions and incompatible types are resolved in the
+// order of ocmetrics once the metrics are converted. The functions passed to
+// opts to convert metrics, e.g. mappers and filters, are called
+// concurrently, while the success handler and timing recorder are called in
+// the order of ocmetrics. With WithMaxErrors, the errors of a metric are
+// either all reported or all omitted. Each worker retains its own state, so
+// stateful options only apply within the metrics converted by a worker.
+func ConvertMetricsParallel(ocmetrics []*ocmetricdata.Metric, workers int, opts ...Option) ([]metricdata.Metrics, error) {
+	c := NewConverter(opts...)
+	order := c.metricOrder(ocmetrics)
+	merged := c.deduplicateDescriptors(ocmetrics)
+
+	// batch holds the metrics to convert, in the order they are converted,
+	// and indices their index in ocmetrics. Nil and merged metrics are nil.
+	batch := make([]*ocmetricdata.Metric, len(ocmetrics))
+	indices := make([]int, len(ocmetrics))
+	var nilIndices []int
+	for n := range ocmetrics {
+		i := n
+		if order != nil {
+			i = order[n]
+		}
+		indices[n] = i
+		ocm := ocmetrics[i]
+		if ocm == nil {
+			c.stats.NilMetrics++
+			nilIndices = append(nilIndices, i)
+			continue
+		}
+		if m, ok := merged[i]; ok {
+			ocm = m
+		}
+		batch[n] = ocm
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(batch) {
+		workers = len(batch)
+	}
+	// Workers report all errors, the maximum number of errors is applied
+	// once the results are merged.
+	workerCfg := c.cfg
+	workerCfg.maxErrors = -1
+	results := make([]parallelResult, len(batch))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			// Each worker has its own Converter as they are not safe for
+			// concurrent use. Only results[n] is written for job n.
+			w := newConverter(workerCfg)
+			for n := range jobs {
+				results[n] = w.convertParallel(indices[n], batch[n])
+			}
+		}()
+	}
+	for n, ocm := range batch {
+		if ocm != nil {
+			jobs <- n
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	otelMetrics := make([]metricdata.Metrics, 0, len(ocmetrics))
+	var err error
+	for n, ocm := range batch {
+		if ocm == nil {
+			continue
+		}
+		r := results[n]
+		if c.cfg.timingRecorder != nil {
+			c.cfg.timingRecorder(ocm.Descriptor.Name, r.duration)
+		}
+		m, ok, metricErr := c.mergeParallel(r)
+		err = errors.Join(err, metricErr)
+		if ok {
+			if c.cfg.successHandler != nil {
+				c.cfg.successHandler(m)
+			}
+			otelMetrics = append(otelMetrics, m)
+		}
+	}
+	if c.cfg.reportNilMetrics && len(nilIndices) > 0 {
+		sort.Ints(nilIndices)
+		err = errors.Join(err, fmt.Errorf("%w: %d at indices %v", errNilMetrics, len(nilIndices), nilIndices))
+	}
+	if c.omittedErrCount > 0 {
+		err = errors.Join(err, fmt.Errorf("+%d more errors", c.omittedErrCount))
+	}
+	return otelMetrics, c.wrapErr(err)
+}
+
+// convertParallel converts ocm, the metric at index i of the converted
+// batch, for ConvertMetricsParallel. The state of the conversion of the
+// previous metric of the worker is discarded, so that names are only
+// checked for collisions once the results are merged.
+func (c *Converter) convertParallel(i int, ocm *ocmetricdata.Metric) parallelResult {
+	c.stats = Stats{AttributeSets: c.stats.AttributeSets}
+	c.errCount = 0
+	c.metricName = ""
+	for name := range c.dropped {
+		delete(c.dropped, name)
+	}
+	for name := range c.instrumentNames {
+		delete(c.instrumentNames, name)
+	}
+	for name := range c.metricKinds {
+		delete(c.metricKinds, name)
+	}
+
+	var r parallelResult
+	var start time.Time
+	if c.cfg.timingRecorder != nil {
+		start = c.cfg.now()
+	}
+	r.metric, r.ok, r.err = c.convertMetric(i, ocm)
+	if c.cfg.timingRecorder != nil {
+		r.duration = c.cfg.now().Sub(start)
+	}
+	r.name, r.errCount, r.stats = c.metricName, c.errCount, c.stats
+	if len(c.dropped) > 0 {
+		r.dropped = make(map[string]error, len(c.dropped))
+		for name, err := range c.dropped {
+			r.dropped[name] = err
+		}
+	}
+	return r
+}
+
+// mergeParallel merges r, the result of the conversion of a metric by a
+// worker, into the conversion of c, checking its name for collisions with
+// the metrics merged before it. It returns the converted metric, false if
+// the metric is dropped, and the errors to report.
+func (c *Converter) mergeParallel(r parallelResult) (metricdata.Metrics, bool, error) {
+	c.metricName, c.metricErr = r.name, nil
+	// Sanitized names are the only ones checked against those of other
+	// metrics. Workers never drop metrics for a collision, so any metric
+	// with a name passed the check.
+	if r.name != "" && c.cfg.instrumentNameValidation == InstrumentNameSanitize {
+		if _, ok, nameErr := c.checkName(r.name); !ok {
+			return metricdata.Metrics{}, false, nameErr
+		}
+	}
+	c.stats.CoalescedGaugePoints += r.stats.CoalescedGaugePoints
+	c.stats.FilteredPoints += r.stats.FilteredPoints
+	c.stats.EmptyMetrics += r.stats.EmptyMetrics
+	c.stats.AgedOutPoints += r.stats.AgedOutPoints
+	for name, dropErr := range r.dropped {
+		if _, ok := c.dropped[name]; !ok {
+			c.dropped[name] = dropErr
+		}
+	}
+	err := r.err
+	if c.cfg.maxErrors >= 0 && c.errCount+r.errCount > c.cfg.maxErrors {
+		c.omittedErrCount += r.errCount
+		err = nil
+	} else {
+		c.errCount += r.errCount
+	}
+	if !r.ok {
+		return metricdata.Metrics{}, false, err
+	}
+	m := r.metric
+	var ok bool
+	if m.Name, ok, err = c.checkType(r.name, m.Name, m.Data, err); !ok {
+		return metricdata.Metrics{}, false, err
+	}
+	c.stats.AttributeSets[r.name] = countAttributeSets(m.Data)
+	return m, true, err
+}
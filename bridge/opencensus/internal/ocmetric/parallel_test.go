@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+)
+
+func histograms(n int) []*ocmetricdata.Metric {
+	now := time.Now()
+	bounds := make([]float64, 50)
+	buckets := make([]ocmetricdata.Bucket, len(bounds)+1)
+	for i := range bounds {
+		bounds[i] = float64(i)
+		buckets[i] = ocmetricdata.Bucket{Count: int64(i)}
+	}
+	ocmetrics := make([]*ocmetricdata.Metric, n)
+	for i := range ocmetrics {
+		ts := make([]*ocmetricdata.TimeSeries, 10)
+		for j := range ts {
+			ts[j] = &ocmetricdata.TimeSeries{
+				LabelValues: []ocmetricdata.LabelValue{{Value: fmt.Sprint(j), Present: true}},
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{
+						Count:         100,
+						Sum:           1000,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+						Buckets:       buckets,
+					}),
+				},
+				StartTime: now,
+			}
+		}
+		ocmetrics[i] = &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      fmt.Sprintf("foo.com/histogram-%d", i),
+				Type:      ocmetricdata.TypeCumulativeDistribution,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: ts,
+		}
+	}
+	return ocmetrics
+}
+
+func TestConvertMetricsParallel(t *testing.T) {
+	input := histograms(20)
+	input[3] = nil
+	input[7].Descriptor.Name = ""
+	input[11].Descriptor.Type = ocmetricdata.TypeSummary
+	input[15].TimeSeries[0].Points[0] = ocmetricdata.NewInt64Point(time.Now(), 1)
+
+	expected, expectedErr := ConvertMetrics(input)
+	for _, workers := range []int{-1, 0, 1, 4, 100} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			output, err := ConvertMetricsParallel(input, workers)
+			assert.Equal(t, expected, output)
+			assert.EqualError(t, err, expectedErr.Error())
+		})
+	}
+
+	output, err := ConvertMetricsParallel(nil, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+}
+
+func TestConvertMetricsParallelRepeatedNames(t *testing.T) {
+	now := time.Now()
+	input := histograms(200)
+	for i, ocm := range input {
+		// Metrics of the same name are histograms or gauges, and sanitized
+		// names collide with the names of other metrics.
+		ocm.Descriptor.Name = fmt.Sprintf("foo.com/metric %d", i%50)
+		if i%4 == 0 {
+			ocm.Descriptor.Name = fmt.Sprintf("foo.com/metric_%d", i%50)
+		}
+		if i%3 == 0 {
+			ocm.Descriptor.Type = ocmetricdata.TypeGaugeInt64
+			for _, ts := range ocm.TimeSeries {
+				ts.Points = []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, int64(i))}
+			}
+		}
+	}
+	input[5] = nil
+
+	for _, tc := range []struct {
+		desc string
+		opts []Option
+	}{
+		{desc: "default"},
+		{desc: "rename", opts: []Option{WithIncompatibleTypeHandling(IncompatibleTypeRename)}},
+		{desc: "first", opts: []Option{WithIncompatibleTypeHandling(IncompatibleTypeFirst)}},
+		{desc: "sanitize", opts: []Option{WithInstrumentNameValidation(InstrumentNameSanitize)}},
+		{desc: "max errors", opts: []Option{WithMaxErrors(3), WithInstrumentNameValidation(InstrumentNameSanitize)}},
+		{desc: "error prefix", opts: []Option{WithErrorPrefix("bridge"), WithReportNilMetrics()}},
+		{desc: "deduplicate", opts: []Option{WithDeduplicateDescriptors()}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			expected, expectedErr := ConvertMetrics(input, tc.opts...)
+			for _, workers := range []int{4, len(input)} {
+				for run := 0; run < 5; run++ {
+					output, err := ConvertMetricsParallel(input, workers, tc.opts...)
+					assert.Equal(t, expected, output)
+					if expectedErr == nil {
+						assert.NoError(t, err)
+						continue
+					}
+					assert.EqualError(t, err, expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConvertHistograms(b *testing.B) {
+	input := histograms(1000)
+	b.Run("Sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ConvertMetrics(input)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		workers := runtime.GOMAXPROCS(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ConvertMetricsParallel(input, workers)
+		}
+	})
+}
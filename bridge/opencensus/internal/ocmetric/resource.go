@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceTypeKey is the resource attribute key the type of an OpenCensus
+// resource is converted to.
+const resourceTypeKey = attribute.Key("opencensus.resourcetype")
+
+// ConvertResourceMetrics converts metric data from OpenCensus to
+// OpenTelemetry. The converted metrics are grouped by the resource of the
+// OpenCensus metrics they were converted from, or by the resource grouping
+// keys, in order of first appearance, and included in scope, in a scope per
+// metric, or in the scope of their source library.
+func (c *Converter) ConvertResourceMetrics(ocmetrics []*ocmetricdata.Metric, scope instrumentation.Scope) ([]*metricdata.ResourceMetrics, error) {
+	type scopeKey struct {
+		res  attribute.Distinct
+		name string
+	}
+	var rms []*metricdata.ResourceMetrics
+	index := make(map[attribute.Distinct]*metricdata.ResourceMetrics)
+	scopeIndex := make(map[scopeKey]int)
+	err := c.convert(ocmetrics, func(ocm *ocmetricdata.Metric, m metricdata.Metrics) {
+		res := c.convertResource(ocm.Resource)
+		key := c.resourceGroup(res)
+		rm, ok := index[key]
+		if !ok {
+			rm = &metricdata.ResourceMetrics{Resource: res}
+			index[key] = rm
+			rms = append(rms, rm)
+		}
+		metricScope := scope
+		if c.cfg.scopePerMetric {
+			metricScope.Name = m.Name
+		}
+		if c.cfg.sourceLibrary != nil {
+			if library := c.cfg.sourceLibrary(ocm.Descriptor.Name); library != "" {
+				metricScope.Name = library
+			}
+		}
+		i, ok := scopeIndex[scopeKey{res: key, name: metricScope.Name}]
+		if !ok {
+			i = len(rm.ScopeMetrics)
+			scopeIndex[scopeKey{res: key, name: metricScope.Name}] = i
+			rm.ScopeMetrics = append(rm.ScopeMetrics, metricdata.ScopeMetrics{Scope: metricScope})
+		}
+		rm.ScopeMetrics[i].Metrics = append(rm.ScopeMetrics[i].Metrics, m)
+	})
+	return rms, err
+}
+
+// resourceGroup returns the key metrics with resource res are grouped by:
+// the attributes of res with one of the resource grouping keys, or all of
+// them if there are no grouping keys.
+func (c *Converter) resourceGroup(res *resource.Resource) attribute.Distinct {
+	if c.cfg.resourceGroupingKeys == nil {
+		return res.Equivalent()
+	}
+	attrs, _ := res.Set().Filter(func(kv attribute.KeyValue) bool {
+		_, ok := c.cfg.resourceGroupingKeys[kv.Key]
+		return ok
+	})
+	return attrs.Equivalent()
+}
+
+// convertResource converts an OpenCensus resource to an OpenTelemetry
+// resource with the global resource and source attributes. If ocres is nil,
+// the fallback resource is used.
+func (c *Converter) convertResource(ocres *ocresource.Resource) *resource.Resource {
+	var res *resource.Resource
+	switch {
+	case ocres != nil:
+		attrs := make([]attribute.KeyValue, 0, len(ocres.Labels)+1)
+		if ocres.Type != "" {
+			attrs = append(attrs, resourceTypeKey.String(ocres.Type))
+		}
+		for k, v := range ocres.Labels {
+			attrs = append(attrs, c.convertResourceLabel(k, v))
+		}
+		res = resource.NewSchemaless(attrs...)
+	case c.cfg.fallbackResource != nil:
+		res = c.cfg.fallbackResource
+	default:
+		res = resource.Empty()
+	}
+	if c.cfg.globalResource != nil {
+		// The attributes of res take precedence, as attribute.NewSet keeps
+		// the last of duplicate keys, and so does its schema URL.
+		attrs := append(c.cfg.globalResource.Attributes(), res.Attributes()...)
+		res = resource.NewWithAttributes(res.SchemaURL(), attrs...)
+	}
+	if len(c.cfg.sourceAttrs) == 0 {
+		return res
+	}
+	return resource.NewWithAttributes(res.SchemaURL(), append(res.Attributes(), c.cfg.sourceAttrs...)...)
+}
+
+// convertResourceLabel converts the OpenCensus resource label with key and
+// value to an attribute of the type configured for key. If value cannot be
+// converted to that type, it is converted to a string.
+func (c *Converter) convertResourceLabel(key, value string) attribute.KeyValue {
+	if t, ok := c.cfg.resourceAttributeTypes[key]; ok {
+		if v, err := parseValue(t, value); err == nil {
+			return attribute.KeyValue{Key: attribute.Key(key), Value: v}
+		}
+	}
+	return attribute.String(key, value)
+}
@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestConvertResourceMetrics(t *testing.T) {
+	scope := instrumentation.Scope{Name: "test"}
+	ocres := &ocresource.Resource{
+		Type:   "host",
+		Labels: map[string]string{"R1": "V1", "R2": "V2"},
+	}
+	res := resource.NewSchemaless(
+		attribute.String("opencensus.resourcetype", "host"),
+		attribute.String("R1", "V1"),
+		attribute.String("R2", "V2"),
+	)
+	fallback := resource.NewSchemaless(attribute.String("service.name", "fallback"))
+	metric := func(name string, ocres *ocresource.Resource) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: name,
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			Resource: ocres,
+		}
+	}
+	grpcLibrary := func(name string) string {
+		if strings.HasPrefix(name, "grpc.io/") {
+			return "ocgrpc"
+		}
+		return ""
+	}
+	expectedMetric := func(name string) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name: name,
+			Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{}},
+		}
+	}
+	for _, tc := range []struct {
+		desc     string
+		input    []*ocmetricdata.Metric
+		opts     []Option
+		expected []*metricdata.ResourceMetrics
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:  "metric with resource",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", ocres)},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc:  "metric with resource and fallback resource",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", ocres)},
+			opts:  []Option{WithFallbackResource(fallback)},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc:  "metric without resource",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", nil)},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: resource.Empty(),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc:  "metric without resource and fallback resource",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", nil)},
+			opts:  []Option{WithFallbackResource(fallback)},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: fallback,
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc: "source attribute",
+			input: []*ocmetricdata.Metric{
+				metric("foo.com/gauge-a", ocres),
+				metric("foo.com/gauge-b", nil),
+			},
+			opts: []Option{
+				WithFallbackResource(fallback),
+				WithSourceAttribute("source", "producer-a"),
+				WithSourceAttribute("R1", "replaced"),
+			},
+			expected: []*metricdata.ResourceMetrics{
+				{
+					Resource: resource.NewSchemaless(
+						attribute.String("opencensus.resourcetype", "host"),
+						attribute.String("R1", "replaced"),
+						attribute.String("R2", "V2"),
+						attribute.String("source", "producer-a"),
+					),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+					}},
+				}, {
+					Resource: resource.NewSchemaless(
+						attribute.String("service.name", "fallback"),
+						attribute.String("R1", "replaced"),
+						attribute.String("source", "producer-a"),
+					),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-b")},
+					}},
+				},
+			},
+		},
+		{
+			desc: "resource attribute types",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", &ocresource.Resource{
+				Type: "host",
+				Labels: map[string]string{
+					"host.cpu.count":   "8",
+					"host.preemptible": "TRUE",
+					"host.load":        "0.75",
+					"host.id":          "not-a-number",
+					"host.name":        "abc",
+				},
+			})},
+			opts: []Option{WithResourceAttributeTypes(map[string]attribute.Type{
+				"host.cpu.count":   attribute.INT64,
+				"host.preemptible": attribute.BOOL,
+				"host.load":        attribute.FLOAT64,
+				"host.id":          attribute.INT64,
+			})},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: resource.NewSchemaless(
+					attribute.String("opencensus.resourcetype", "host"),
+					attribute.Int64("host.cpu.count", 8),
+					attribute.Bool("host.preemptible", true),
+					attribute.Float64("host.load", 0.75),
+					attribute.String("host.id", "not-a-number"),
+					attribute.String("host.name", "abc"),
+				),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc: "resource attributes without types",
+			input: []*ocmetricdata.Metric{metric("foo.com/gauge-a", &ocresource.Resource{
+				Labels: map[string]string{"host.cpu.count": "8"},
+			})},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: resource.NewSchemaless(attribute.String("host.cpu.count", "8")),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope:   scope,
+					Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+				}},
+			}},
+		},
+		{
+			desc: "metrics grouped by resource grouping keys",
+			input: []*ocmetricdata.Metric{
+				metric("foo.com/gauge-a", &ocresource.Resource{
+					Labels: map[string]string{"service.name": "a", "host.name": "h1"},
+				}),
+				metric("foo.com/gauge-b", &ocresource.Resource{
+					Labels: map[string]string{"service.name": "b", "host.name": "h1"},
+				}),
+				metric("foo.com/gauge-c", &ocresource.Resource{
+					Labels: map[string]string{"service.name": "a", "host.name": "h2"},
+				}),
+				metric("foo.com/gauge-d", nil),
+				metric("foo.com/gauge-e", &ocresource.Resource{
+					Labels: map[string]string{"host.name": "h3"},
+				}),
+			},
+			opts: []Option{WithResourceGroupingKeys("service.name")},
+			expected: []*metricdata.ResourceMetrics{
+				{
+					Resource: resource.NewSchemaless(attribute.String("service.name", "a"), attribute.String("host.name", "h1")),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope: scope,
+						Metrics: []metricdata.Metrics{
+							expectedMetric("foo.com/gauge-a"),
+							expectedMetric("foo.com/gauge-c"),
+						},
+					}},
+				}, {
+					Resource: resource.NewSchemaless(attribute.String("service.name", "b"), attribute.String("host.name", "h1")),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-b")},
+					}},
+				}, {
+					Resource: resource.Empty(),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope: scope,
+						Metrics: []metricdata.Metrics{
+							expectedMetric("foo.com/gauge-d"),
+							expectedMetric("foo.com/gauge-e"),
+						},
+					}},
+				},
+			},
+		},
+		{
+			desc: "scope per metric",
+			input: []*ocmetricdata.Metric{
+				metric("foo.com/gauge-a", ocres),
+				metric("foo.com/gauge-b", ocres),
+				metric("foo.com/gauge-a", nil),
+			},
+			opts: []Option{WithScopePerMetric()},
+			expected: []*metricdata.ResourceMetrics{
+				{
+					Resource: res,
+					ScopeMetrics: []metricdata.ScopeMetrics{
+						{
+							Scope:   instrumentation.Scope{Name: "foo.com/gauge-a"},
+							Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+						}, {
+							Scope:   instrumentation.Scope{Name: "foo.com/gauge-b"},
+							Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-b")},
+						},
+					},
+				}, {
+					Resource: resource.Empty(),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   instrumentation.Scope{Name: "foo.com/gauge-a"},
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+					}},
+				},
+			},
+		},
+		{
+			desc: "global resource",
+			input: []*ocmetricdata.Metric{
+				metric("foo.com/gauge-a", &ocresource.Resource{
+					Labels: map[string]string{"R1": "V1"},
+				}),
+				metric("foo.com/gauge-b", nil),
+			},
+			opts: []Option{
+				WithFallbackResource(fallback),
+				WithGlobalResource(resource.NewWithAttributes(
+					"https://opentelemetry.io/schemas/1.21.0",
+					attribute.String("R1", "global"),
+					attribute.String("service.name", "global"),
+					attribute.String("host.name", "global"),
+				)),
+			},
+			expected: []*metricdata.ResourceMetrics{
+				{
+					Resource: resource.NewSchemaless(
+						attribute.String("R1", "V1"),
+						attribute.String("service.name", "global"),
+						attribute.String("host.name", "global"),
+					),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+					}},
+				}, {
+					Resource: resource.NewSchemaless(
+						attribute.String("R1", "global"),
+						attribute.String("service.name", "fallback"),
+						attribute.String("host.name", "global"),
+					),
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-b")},
+					}},
+				},
+			},
+		},
+		{
+			desc: "source library",
+			input: []*ocmetricdata.Metric{
+				metric("grpc.io/client/roundtrip_latency", ocres),
+				metric("foo.com/gauge-a", ocres),
+				metric("grpc.io/server/server_latency", ocres),
+			},
+			opts: []Option{WithSourceLibrary(grpcLibrary)},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{
+					{
+						Scope: instrumentation.Scope{Name: "ocgrpc"},
+						Metrics: []metricdata.Metrics{
+							expectedMetric("grpc.io/client/roundtrip_latency"),
+							expectedMetric("grpc.io/server/server_latency"),
+						},
+					}, {
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+					},
+				},
+			}},
+		},
+		{
+			desc: "source library and scope per metric",
+			input: []*ocmetricdata.Metric{
+				metric("grpc.io/client/roundtrip_latency", ocres),
+				metric("foo.com/gauge-a", ocres),
+			},
+			opts: []Option{WithSourceLibrary(grpcLibrary), WithScopePerMetric()},
+			expected: []*metricdata.ResourceMetrics{{
+				Resource: res,
+				ScopeMetrics: []metricdata.ScopeMetrics{
+					{
+						Scope:   instrumentation.Scope{Name: "ocgrpc"},
+						Metrics: []metricdata.Metrics{expectedMetric("grpc.io/client/roundtrip_latency")},
+					}, {
+						Scope:   instrumentation.Scope{Name: "foo.com/gauge-a"},
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-a")},
+					},
+				},
+			}},
+		},
+		{
+			desc: "metrics grouped by resource",
+			input: []*ocmetricdata.Metric{
+				metric("foo.com/gauge-a", ocres),
+				metric("foo.com/gauge-b", nil),
+				metric("foo.com/gauge-c", &ocresource.Resource{
+					Type:   "host",
+					Labels: map[string]string{"R2": "V2", "R1": "V1"},
+				}),
+			},
+			opts: []Option{WithFallbackResource(fallback)},
+			expected: []*metricdata.ResourceMetrics{
+				{
+					Resource: res,
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope: scope,
+						Metrics: []metricdata.Metrics{
+							expectedMetric("foo.com/gauge-a"),
+							expectedMetric("foo.com/gauge-c"),
+						},
+					}},
+				}, {
+					Resource: fallback,
+					ScopeMetrics: []metricdata.ScopeMetrics{{
+						Scope:   scope,
+						Metrics: []metricdata.Metrics{expectedMetric("foo.com/gauge-b")},
+					}},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := NewConverter(tc.opts...).ConvertResourceMetrics(tc.input, scope)
+			require.NoError(t, err)
+			require.Len(t, output, len(tc.expected))
+			for i := range output {
+				metricdatatest.AssertEqual(t, *tc.expected[i], *output[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var (
+	errNonSemConvMetric    = errors.New("metric name is not in the semantic conventions registry")
+	errNonSemConvAttribute = errors.New("attribute key is not in the semantic conventions registry")
+)
+
+// SemConvRegistry is a registry of the metric names and attribute keys
+// defined by semantic conventions.
+type SemConvRegistry interface {
+	// ValidMetric returns true if name is a metric name of the registry.
+	ValidMetric(name string) bool
+	// ValidAttribute returns true if key is an attribute key of the
+	// registry.
+	ValidAttribute(key string) bool
+}
+
+// validateSemConv records a warning if name, the converted name of the
+// current metric, or the attribute keys of its aggregation agg are not in
+// the semantic conventions registry. Each invalid key is reported once.
+func (c *Converter) validateSemConv(name string, agg metricdata.Aggregation) {
+	registry := c.cfg.semConvRegistry
+	if !registry.ValidMetric(name) {
+		c.warn(fmt.Errorf("%w: %q", errNonSemConvMetric, name))
+	}
+	invalid := make(map[attribute.Key]struct{})
+	eachAttributeSet(agg, func(s attribute.Set) {
+		for iter := s.Iter(); iter.Next(); {
+			key := iter.Attribute().Key
+			if _, ok := invalid[key]; !ok && !registry.ValidAttribute(string(key)) {
+				invalid[key] = struct{}{}
+			}
+		}
+	})
+	keys := make([]string, 0, len(invalid))
+	for key := range invalid {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.warn(fmt.Errorf("%w: %q", errNonSemConvAttribute, key))
+	}
+}
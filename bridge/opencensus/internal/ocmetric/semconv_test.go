@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+)
+
+type testRegistry struct {
+	metrics    map[string]bool
+	attributes map[string]bool
+}
+
+func (r testRegistry) ValidMetric(name string) bool   { return r.metrics[name] }
+func (r testRegistry) ValidAttribute(key string) bool { return r.attributes[key] }
+
+func TestConverterSemConvValidation(t *testing.T) {
+	now := time.Now()
+	sum := func(name string, keys ...string) *ocmetricdata.Metric {
+		labelKeys := make([]ocmetricdata.LabelKey, len(keys))
+		labelValues := make([]ocmetricdata.LabelValue, len(keys))
+		for i, k := range keys {
+			labelKeys[i] = ocmetricdata.LabelKey{Key: k}
+			labelValues[i] = ocmetricdata.LabelValue{Value: "1", Present: true}
+		}
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      name,
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: labelKeys,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{LabelValues: labelValues, Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)}},
+				{LabelValues: labelValues, Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now.Add(time.Second), 2)}},
+			},
+		}
+	}
+	registry := testRegistry{
+		metrics:    map[string]bool{"rpc.server.requests": true},
+		attributes: map[string]bool{"rpc.method": true},
+	}
+	for _, tc := range []struct {
+		desc            string
+		input           *ocmetricdata.Metric
+		opts            []Option
+		expectedErrs    []error
+		expectedInvalid []string
+	}{
+		{
+			desc:  "valid",
+			input: sum("rpc.server.requests", "rpc.method"),
+			opts:  []Option{WithSemConvValidation(registry)},
+		},
+		{
+			desc:            "invalid metric name",
+			input:           sum("foo.com/sum-a", "rpc.method"),
+			opts:            []Option{WithSemConvValidation(registry)},
+			expectedErrs:    []error{errNonSemConvMetric},
+			expectedInvalid: []string{`"foo.com/sum-a"`},
+		},
+		{
+			desc:            "invalid attribute keys",
+			input:           sum("rpc.server.requests", "rpc.method", "path", "host"),
+			opts:            []Option{WithSemConvValidation(registry)},
+			expectedErrs:    []error{errNonSemConvAttribute},
+			expectedInvalid: []string{`"host"`, `"path"`},
+		},
+		{
+			desc:  "prefixed metric name",
+			input: sum("requests", "rpc.method"),
+			opts:  []Option{WithMetricNamePrefix("rpc.server"), WithSemConvValidation(registry)},
+		},
+		{
+			desc:  "no validation",
+			input: sum("foo.com/sum-a", "path"),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics([]*ocmetricdata.Metric{tc.input}, tc.opts...)
+			require.Len(t, output, 1)
+			if len(tc.expectedErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, expectedErr := range tc.expectedErrs {
+				assert.ErrorIs(t, err, expectedErr)
+			}
+			for _, invalid := range tc.expectedInvalid {
+				assert.Contains(t, err.Error(), invalid)
+			}
+			// Each invalid key is reported once per metric.
+			assert.Equal(t, len(tc.expectedInvalid), strings.Count(err.Error(), tc.expectedErrs[0].Error()))
+		})
+	}
+}
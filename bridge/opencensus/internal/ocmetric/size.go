@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// fixedSize is the size of fixed-size numbers and timestamps.
+	fixedSize = 8
+	// boolSize is the size of booleans.
+	boolSize = 1
+)
+
+// EstimateSize returns an estimate of the size in bytes of metrics when
+// exported, e.g. to decide when to flush a batch of converted metrics. It is
+// the size of their names, descriptions, units, attribute keys and values,
+// timestamps, values, bounds, bucket counts, and exemplars.
+//
+// The estimate is not the exact size on the wire: it ignores the framing and
+// field tags of the encoding, as well as compression, and counts all numbers
+// as 8 bytes. It grows with the amount of data of metrics.
+func EstimateSize(metrics []metricdata.Metrics) int {
+	var size int
+	for _, m := range metrics {
+		size += len(m.Name) + len(m.Description) + len(m.Unit)
+		switch a := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			size += dataPointsSize(a.DataPoints)
+		case metricdata.Gauge[float64]:
+			size += dataPointsSize(a.DataPoints)
+		case metricdata.Sum[int64]:
+			size += boolSize + fixedSize + dataPointsSize(a.DataPoints)
+		case metricdata.Sum[float64]:
+			size += boolSize + fixedSize + dataPointsSize(a.DataPoints)
+		case metricdata.Histogram[int64]:
+			size += fixedSize + histogramPointsSize(a.DataPoints)
+		case metricdata.Histogram[float64]:
+			size += fixedSize + histogramPointsSize(a.DataPoints)
+		}
+	}
+	return size
+}
+
+// dataPointsSize returns the estimated size of sum or gauge data points.
+func dataPointsSize[N int64 | float64](points []metricdata.DataPoint[N]) int {
+	var size int
+	for _, p := range points {
+		size += attributesSize(p.Attributes) + 3*fixedSize + exemplarsSize(p.Exemplars)
+	}
+	return size
+}
+
+// histogramPointsSize returns the estimated size of histogram data points.
+func histogramPointsSize[N int64 | float64](points []metricdata.HistogramDataPoint[N]) int {
+	var size int
+	for _, p := range points {
+		// The start time, time, count, and sum.
+		size += attributesSize(p.Attributes) + 4*fixedSize
+		size += fixedSize * (len(p.Bounds) + len(p.BucketCounts))
+		if _, ok := p.Min.Value(); ok {
+			size += fixedSize
+		}
+		if _, ok := p.Max.Value(); ok {
+			size += fixedSize
+		}
+		size += exemplarsSize(p.Exemplars)
+	}
+	return size
+}
+
+// exemplarsSize returns the estimated size of exemplars.
+func exemplarsSize[N int64 | float64](exemplars []metricdata.Exemplar[N]) int {
+	var size int
+	for _, e := range exemplars {
+		size += 2*fixedSize + len(e.SpanID) + len(e.TraceID)
+		for _, kv := range e.FilteredAttributes {
+			size += attributeSize(kv)
+		}
+	}
+	return size
+}
+
+// attributesSize returns the estimated size of attrs.
+func attributesSize(attrs attribute.Set) int {
+	var size int
+	for iter := attrs.Iter(); iter.Next(); {
+		size += attributeSize(iter.Attribute())
+	}
+	return size
+}
+
+// attributeSize returns the estimated size of kv.
+func attributeSize(kv attribute.KeyValue) int {
+	size := len(kv.Key)
+	switch kv.Value.Type() {
+	case attribute.BOOL:
+		size += boolSize
+	case attribute.INT64, attribute.FLOAT64:
+		size += fixedSize
+	case attribute.STRING:
+		size += len(kv.Value.AsString())
+	case attribute.BOOLSLICE:
+		size += boolSize * len(kv.Value.AsBoolSlice())
+	case attribute.INT64SLICE:
+		size += fixedSize * len(kv.Value.AsInt64Slice())
+	case attribute.FLOAT64SLICE:
+		size += fixedSize * len(kv.Value.AsFloat64Slice())
+	case attribute.STRINGSLICE:
+		for _, s := range kv.Value.AsStringSlice() {
+			size += len(s)
+		}
+	}
+	return size
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestEstimateSize(t *testing.T) {
+	now := time.Now()
+	attrs := attribute.NewSet(attribute.String("a", "hello"))
+	gauge := metricdata.Metrics{
+		Name: "foo.com/gauge-a",
+		Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+			{Attributes: attrs, Time: now, Value: 1},
+		}},
+	}
+	histogram := func(bounds ...float64) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name: "foo.com/histogram-a",
+			Data: metricdata.Histogram[float64]{DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{Attributes: attrs, Time: now, Bounds: bounds, BucketCounts: make([]uint64, len(bounds)+1)},
+			}},
+		}
+	}
+
+	// Each batch has more data than the previous one.
+	prev := -1
+	for _, tc := range []struct {
+		desc    string
+		metrics []metricdata.Metrics
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "metric without data points",
+			metrics: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Data: metricdata.Gauge[int64]{}},
+			},
+		},
+		{
+			desc: "with description",
+			metrics: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Description: "a testing gauge", Data: metricdata.Gauge[int64]{}},
+			},
+		},
+		{
+			desc: "gauge data point",
+			metrics: []metricdata.Metrics{
+				{Name: "foo.com/gauge-a", Description: "a testing gauge", Data: gauge.Data},
+			},
+		},
+		{
+			desc: "additional attribute",
+			metrics: []metricdata.Metrics{
+				{
+					Name:        "foo.com/gauge-a",
+					Description: "a testing gauge",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{Attributes: attribute.NewSet(attribute.String("a", "hello"), attribute.Bool("b", true)), Time: now, Value: 1},
+					}},
+				},
+			},
+		},
+		{
+			desc: "exemplar",
+			metrics: []metricdata.Metrics{
+				{
+					Name:        "foo.com/gauge-a",
+					Description: "a testing gauge",
+					Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+						{
+							Attributes: attribute.NewSet(attribute.String("a", "hello"), attribute.Bool("b", true)),
+							Time:       now,
+							Value:      1,
+							Exemplars:  []metricdata.Exemplar[int64]{{Value: 1, Time: now}},
+						},
+					}},
+				},
+			},
+		},
+	} {
+		size := EstimateSize(tc.metrics)
+		assert.Greater(t, size, prev, tc.desc)
+		prev = size
+	}
+
+	t.Run("growing batches", func(t *testing.T) {
+		var metrics []metricdata.Metrics
+		prev := EstimateSize(metrics)
+		for _, m := range []metricdata.Metrics{gauge, histogram(), histogram(1), histogram(1, 2, 3)} {
+			metrics = append(metrics, m)
+			size := EstimateSize(metrics)
+			assert.Greater(t, size, prev)
+			prev = size
+		}
+	})
+
+	t.Run("histogram buckets", func(t *testing.T) {
+		assert.Greater(t, EstimateSize([]metricdata.Metrics{histogram(1, 2)}), EstimateSize([]metricdata.Metrics{histogram(1)}))
+	})
+}
@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var (
+	errInvalidTemporality = errors.New("invalid temporality")
+	errCumulativeReset    = errors.New("cumulative time series reset")
+	errNonMonotonicSum    = errors.New("cumulative sum decreased, converted as non-monotonic")
+	errBoundsChanged      = errors.New("histogram bounds changed, converted as change since start time")
+	errSumCorrected       = errors.New("cumulative sum decreased, values raised to the previous value")
+)
+
+// seriesKey identifies a time series across conversions.
+type seriesKey struct {
+	name  string
+	attrs attribute.Distinct
+}
+
+// cumulativePoint is the last cumulative data point of a time series.
+type cumulativePoint[N int64 | float64] struct {
+	startTime time.Time
+	time      time.Time
+	value     N
+}
+
+// cumulativeHistogramPoint is the last cumulative histogram data point of a
+// time series.
+type cumulativeHistogramPoint struct {
+	startTime    time.Time
+	time         time.Time
+	bounds       []float64
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+// temporality returns the temporality the current metric, of OpenCensus type
+// ocType, is converted to.
+func (c *Converter) temporality(ocType ocmetricdata.Type) metricdata.Temporality {
+	if c.cfg.temporalitySelector == nil {
+		return metricdata.CumulativeTemporality
+	}
+	switch t := c.cfg.temporalitySelector(c.metricName, ocType); t {
+	case metricdata.CumulativeTemporality, metricdata.DeltaTemporality:
+		return t
+	default:
+		c.warn(fmt.Errorf("%w: %s, using cumulative", errInvalidTemporality, t))
+		return metricdata.CumulativeTemporality
+	}
+}
+
+// deltaSumPoints converts cumulative sum data points of the current metric to
+// delta data points. The value of each point is reduced by the value of the
+// previous point of the same time series, and its start time is set to the
+// time of that previous point.
+//
+// If there is no previous point, or the time series was reset since the
+// previous point, the point is the change since its start time and is kept
+// unchanged. Points that are not later than the previous point of their time
+// series, e.g. the same point collected again, are dropped, as their change
+// was already converted.
+func deltaSumPoints[N int64 | float64](c *Converter, points []metricdata.DataPoint[N]) []metricdata.DataPoint[N] {
+	deltas := points[:0]
+	for _, p := range points {
+		key := seriesKey{name: c.metricName, attrs: p.Attributes.Equivalent()}
+		prev, ok := c.cumulative[key].(cumulativePoint[N])
+		sameSeries := ok && prev.startTime.Equal(p.StartTime)
+		if sameSeries && !p.Time.After(prev.time) {
+			continue
+		}
+		c.cumulative[key] = cumulativePoint[N]{startTime: p.StartTime, time: p.Time, value: p.Value}
+		if sameSeries && p.Value >= prev.value {
+			p.StartTime = prev.time
+			p.Value -= prev.value
+		}
+		deltas = append(deltas, p)
+	}
+	return deltas
+}
+
+// detectResets records a warning for each of the n data points of the
+// current cumulative metric with a later start time than a previous data
+// point with the same attributes. The point function returns the attributes
+// and start time of the i-th data point.
+func (c *Converter) detectResets(n int, point func(i int) (attribute.Set, time.Time)) {
+	starts := make(map[attribute.Distinct]time.Time, n)
+	for i := 0; i < n; i++ {
+		attrs, start := point(i)
+		prev, ok := starts[attrs.Equivalent()]
+		if ok && start.After(prev) {
+			c.warn(fmt.Errorf("%w: attributes %s start at %v, previously %v", errCumulativeReset, attrs.Encoded(attribute.DefaultEncoder()), start, prev))
+		}
+		if !ok || start.After(prev) {
+			starts[attrs.Equivalent()] = start
+		}
+	}
+}
+
+// deltaHistogramPoints converts cumulative histogram data points of the
+// current metric to delta data points. The count, sum, and bucket counts of
+// each point are reduced by those of the previous point of the same time
+// series, and its start time is set to the time of that previous point.
+//
+// If there is no previous point, or the time series was reset since the
+// previous point, the point is the change since its start time and is kept
+// unchanged. A warning is recorded if the point is kept unchanged because
+// the bounds changed since the previous point. Points that are not later
+// than the previous point of their time series, e.g. the same point
+// collected again, are dropped, as their change was already converted.
+func (c *Converter) deltaHistogramPoints(points []metricdata.HistogramDataPoint[float64]) []metricdata.HistogramDataPoint[float64] {
+	deltas := points[:0]
+	for _, p := range points {
+		key := seriesKey{name: c.metricName, attrs: p.Attributes.Equivalent()}
+		prev, ok := c.cumulative[key].(cumulativeHistogramPoint)
+		sameSeries := ok && prev.startTime.Equal(p.StartTime)
+		if sameSeries && !p.Time.After(prev.time) {
+			continue
+		}
+		c.cumulative[key] = cumulativeHistogramPoint{
+			startTime:    p.StartTime,
+			time:         p.Time,
+			bounds:       append([]float64(nil), p.Bounds...),
+			count:        p.Count,
+			sum:          p.Sum,
+			bucketCounts: append([]uint64(nil), p.BucketCounts...),
+		}
+		if sameSeries && c.subtractable(prev, p) {
+			p.StartTime = prev.time
+			p.Count -= prev.count
+			p.Sum -= prev.sum
+			for j := range p.BucketCounts {
+				p.BucketCounts[j] -= prev.bucketCounts[j]
+			}
+		}
+		deltas = append(deltas, p)
+	}
+	return deltas
+}
+
+// subtractable returns true if prev, the previous point of the time series
+// of p with the same start time, can be subtracted from p. A warning is
+// recorded if the bounds changed since prev.
+func (c *Converter) subtractable(prev cumulativeHistogramPoint, p metricdata.HistogramDataPoint[float64]) bool {
+	if p.Count < prev.count {
+		return false
+	}
+	if !equalBounds(prev.bounds, p.Bounds) || len(prev.bucketCounts) != len(p.BucketCounts) {
+		c.warn(fmt.Errorf("%w: %v to %v", errBoundsChanged, prev.bounds, p.Bounds))
+		return false
+	}
+	return !decreased(prev.bucketCounts, p.BucketCounts)
+}
+
+// decreased returns true if any of counts is lower than the count of prev at
+// the same index.
+func decreased(prev, counts []uint64) bool {
+	for i, n := range counts {
+		if n < prev[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// handleResets returns the cumulative sum data points of the current metric
+// with the first data point of each time series after a reset handled
+// according to the reset handling. A time series is reset when a data point
+// has a later start time than the previous data points of the time series,
+// in this or a previous conversion.
+func handleResets[N int64 | float64](c *Converter, points []metricdata.DataPoint[N]) []metricdata.DataPoint[N] {
+	handled := make([]metricdata.DataPoint[N], 0, len(points))
+	for _, p := range points {
+		key := seriesKey{name: c.metricName, attrs: p.Attributes.Equivalent()}
+		prev, ok := c.startTimes[key]
+		reset := ok && p.StartTime.After(prev)
+		if !ok || reset {
+			c.startTimes[key] = p.StartTime
+		}
+		if !reset {
+			handled = append(handled, p)
+			continue
+		}
+		switch c.cfg.resetHandling {
+		case ResetZero:
+			handled = append(handled, metricdata.DataPoint[N]{
+				Attributes: p.Attributes,
+				StartTime:  p.StartTime,
+				Time:       p.StartTime,
+			}, p)
+		case ResetSkip:
+		default:
+			handled = append(handled, p)
+		}
+	}
+	return handled
+}
+
+// splitResets sets the start time of the data points of each sum time series
+// from a decrease of its value on to the time of the data point it decreased
+// at, starting a new counter epoch. A decrease is a data point with a lower
+// value than the previous data point of the same time series in points.
+func splitResets[N int64 | float64](points []metricdata.DataPoint[N]) {
+	type epoch struct {
+		value     N
+		startTime time.Time
+		reset     bool
+	}
+	epochs := make(map[attribute.Distinct]epoch)
+	for i, p := range points {
+		key := p.Attributes.Equivalent()
+		e, ok := epochs[key]
+		switch {
+		case ok && p.Value < e.value:
+			e.startTime, e.reset = p.Time, true
+		case !ok:
+			e.startTime = p.StartTime
+		}
+		e.value = p.Value
+		epochs[key] = e
+		if e.reset {
+			points[i].StartTime = e.startTime
+		}
+	}
+}
+
+// correctDecreases raises the value of each sum data point that is lower
+// than the value of the previous data point of the same time series and
+// start time in points to that value, so that the values of each time series
+// do not decrease. It returns the number of raised data points.
+func correctDecreases[N int64 | float64](points []metricdata.DataPoint[N]) int {
+	type last struct {
+		startTime time.Time
+		value     N
+	}
+	lasts := make(map[attribute.Distinct]last)
+	var corrected int
+	for i, p := range points {
+		key := p.Attributes.Equivalent()
+		prev, ok := lasts[key]
+		if ok && prev.startTime.Equal(p.StartTime) && p.Value < prev.value {
+			points[i].Value = prev.value
+			corrected++
+		}
+		lasts[key] = last{startTime: p.StartTime, value: points[i].Value}
+	}
+	return corrected
+}
+
+// monotonic returns false if the current sum metric has decreased, in this or
+// a previous conversion. A decrease is a data point with a lower value than
+// the previous data point of the same time series with the same start time.
+// The first decrease of a metric is recorded as a warning.
+func monotonic[N int64 | float64](c *Converter, points []metricdata.DataPoint[N]) bool {
+	if _, ok := c.nonMonotonic[c.metricName]; ok {
+		return false
+	}
+	decreased := false
+	for _, p := range points {
+		key := seriesKey{name: c.metricName, attrs: p.Attributes.Equivalent()}
+		prev, ok := c.sumValues[key].(cumulativePoint[N])
+		c.sumValues[key] = cumulativePoint[N]{startTime: p.StartTime, time: p.Time, value: p.Value}
+		if ok && prev.startTime.Equal(p.StartTime) && p.Value < prev.value {
+			decreased = true
+		}
+	}
+	if !decreased {
+		return true
+	}
+	c.nonMonotonic[c.metricName] = struct{}{}
+	c.warn(errNonMonotonicSum)
+	return false
+}
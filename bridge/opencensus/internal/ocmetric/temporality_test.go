@@ -0,0 +1,638 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConverterTemporalityByName(t *testing.T) {
+	startTime := time.Now()
+	time1 := startTime.Add(time.Second)
+	time2 := time1.Add(time.Second)
+	time3 := time2.Add(time.Second)
+	sum := func(name string, start, end time.Time, value int64) *ocmetricdata.Metric {
+		return &ocmetricdata.Metric{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: name,
+				Type: ocmetricdata.TypeCumulativeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points:    []ocmetricdata.Point{ocmetricdata.NewInt64Point(end, value)},
+				StartTime: start,
+			}},
+		}
+	}
+	expectedSum := func(name string, temporality metricdata.Temporality, start, end time.Time, value int64) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name: name,
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: temporality,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: *attribute.EmptySet(),
+					StartTime:  start,
+					Time:       end,
+					Value:      value,
+				}},
+			},
+		}
+	}
+
+	c := NewConverter(WithTemporalityByName(func(name string, ocType ocmetricdata.Type) metricdata.Temporality {
+		assert.Equal(t, ocmetricdata.TypeCumulativeInt64, ocType)
+		switch name {
+		case "request_count":
+			return metricdata.DeltaTemporality
+		case "cpu_seconds":
+			return metricdata.CumulativeTemporality
+		}
+		return metricdata.Temporality(0)
+	}))
+
+	for _, tc := range []struct {
+		desc        string
+		input       []*ocmetricdata.Metric
+		expected    []metricdata.Metrics
+		expectedErr error
+	}{
+		{
+			desc: "first conversion",
+			input: []*ocmetricdata.Metric{
+				sum("request_count", startTime, time1, 5),
+				sum("cpu_seconds", startTime, time1, 5),
+			},
+			expected: []metricdata.Metrics{
+				expectedSum("request_count", metricdata.DeltaTemporality, startTime, time1, 5),
+				expectedSum("cpu_seconds", metricdata.CumulativeTemporality, startTime, time1, 5),
+			},
+		},
+		{
+			desc: "second conversion",
+			input: []*ocmetricdata.Metric{
+				sum("request_count", startTime, time2, 8),
+				sum("cpu_seconds", startTime, time2, 8),
+			},
+			expected: []metricdata.Metrics{
+				expectedSum("request_count", metricdata.DeltaTemporality, time1, time2, 3),
+				expectedSum("cpu_seconds", metricdata.CumulativeTemporality, startTime, time2, 8),
+			},
+		},
+		{
+			desc: "repeated collection",
+			input: []*ocmetricdata.Metric{
+				sum("request_count", startTime, time2, 8),
+				sum("cpu_seconds", startTime, time2, 8),
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "request_count",
+					Data: metricdata.Sum[int64]{
+						IsMonotonic: true,
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints:  []metricdata.DataPoint[int64]{},
+					},
+				},
+				expectedSum("cpu_seconds", metricdata.CumulativeTemporality, startTime, time2, 8),
+			},
+		},
+		{
+			desc: "out of order collection",
+			input: []*ocmetricdata.Metric{
+				sum("request_count", startTime, time1, 5),
+			},
+			expected: []metricdata.Metrics{
+				{
+					Name: "request_count",
+					Data: metricdata.Sum[int64]{
+						IsMonotonic: true,
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints:  []metricdata.DataPoint[int64]{},
+					},
+				},
+			},
+		},
+		{
+			desc: "reset",
+			input: []*ocmetricdata.Metric{
+				sum("request_count", time2, time3, 2),
+			},
+			expected: []metricdata.Metrics{
+				expectedSum("request_count", metricdata.DeltaTemporality, time2, time3, 2),
+			},
+		},
+		{
+			desc: "invalid temporality",
+			input: []*ocmetricdata.Metric{
+				sum("other", startTime, time1, 5),
+			},
+			expected: []metricdata.Metrics{
+				expectedSum("other", metricdata.CumulativeTemporality, startTime, time1, 5),
+			},
+			expectedErr: errInvalidTemporality,
+		},
+	} {
+		output, err := c.ConvertMetrics(tc.input)
+		if tc.expectedErr != nil {
+			require.ErrorIs(t, err, tc.expectedErr, tc.desc)
+		} else {
+			require.NoError(t, err, tc.desc)
+		}
+		metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+			metricdata.ScopeMetrics{Metrics: tc.expected},
+			metricdata.ScopeMetrics{Metrics: output})
+	}
+}
+
+func TestConverterReaderTemporality(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/gauge-a", Type: ocmetricdata.TypeGaugeInt64},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: []ocmetricdata.Point{ocmetricdata.NewInt64Point(now, 1)}}},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/sum-a", Type: ocmetricdata.TypeCumulativeFloat64},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: []ocmetricdata.Point{ocmetricdata.NewFloat64Point(now, 1)}}},
+		}, {
+			Descriptor: ocmetricdata.Descriptor{Name: "foo.com/histogram-a", Type: ocmetricdata.TypeCumulativeDistribution},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: []ocmetricdata.Point{
+				ocmetricdata.NewDistributionPoint(now, &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}),
+			}}},
+		},
+	}
+	// deltaPreference selects delta temporality for the instruments the OTLP
+	// exporters prefer it for.
+	deltaPreference := func(kind metric.InstrumentKind) metricdata.Temporality {
+		switch kind {
+		case metric.InstrumentKindCounter, metric.InstrumentKindHistogram, metric.InstrumentKindObservableCounter:
+			return metricdata.DeltaTemporality
+		}
+		return metricdata.CumulativeTemporality
+	}
+	// upDownPreference only selects delta temporality for up-down counters.
+	upDownPreference := func(kind metric.InstrumentKind) metricdata.Temporality {
+		if kind == metric.InstrumentKindUpDownCounter {
+			return metricdata.DeltaTemporality
+		}
+		return metricdata.CumulativeTemporality
+	}
+	for _, tc := range []struct {
+		desc     string
+		selector metric.TemporalitySelector
+		expected metricdata.Temporality
+	}{
+		{
+			desc:     "default",
+			selector: metric.DefaultTemporalitySelector,
+			expected: metricdata.CumulativeTemporality,
+		},
+		{
+			desc:     "delta preference",
+			selector: deltaPreference,
+			expected: metricdata.DeltaTemporality,
+		},
+		{
+			desc:     "up-down counters",
+			selector: upDownPreference,
+			expected: metricdata.CumulativeTemporality,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := ConvertMetrics(input, WithReaderTemporality(tc.selector))
+			require.NoError(t, err)
+			require.Len(t, output, 3)
+			assert.IsType(t, metricdata.Gauge[int64]{}, output[0].Data)
+			assert.Equal(t, tc.expected, output[1].Data.(metricdata.Sum[float64]).Temporality)
+			assert.Equal(t, tc.expected, output[2].Data.(metricdata.Histogram[float64]).Temporality)
+		})
+	}
+}
+
+func TestConverterCounterResetSplit(t *testing.T) {
+	startTime := time.Now()
+	times := make([]time.Time, 5)
+	for i := range times {
+		times[i] = startTime.Add(time.Duration(i+1) * time.Second)
+	}
+	series := func(value string, values ...int64) *ocmetricdata.TimeSeries {
+		points := make([]ocmetricdata.Point, len(values))
+		for i, v := range values {
+			points[i] = ocmetricdata.NewInt64Point(times[i], v)
+		}
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points:      points,
+			StartTime:   startTime,
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/sum-a",
+				Type:      ocmetricdata.TypeCumulativeInt64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				series("reset", 5, 7, 2, 4),
+				series("increasing", 1, 2, 3),
+				series("reset twice", 5, 1, 3, 2, 4),
+			},
+		},
+	}
+	startTimes := func(t *testing.T, opts ...Option) map[string][]time.Time {
+		output, err := ConvertMetrics(input, opts...)
+		require.NoError(t, err)
+		require.Len(t, output, 1)
+		s := make(map[string][]time.Time)
+		for _, p := range output[0].Data.(metricdata.Sum[int64]).DataPoints {
+			v, _ := p.Attributes.Value("a")
+			s[v.AsString()] = append(s[v.AsString()], p.StartTime)
+		}
+		return s
+	}
+
+	assert.Equal(t, map[string][]time.Time{
+		"reset":       {startTime, startTime, times[2], times[2]},
+		"increasing":  {startTime, startTime, startTime},
+		"reset twice": {startTime, times[1], times[1], times[3], times[3]},
+	}, startTimes(t, WithCounterResetSplit()))
+	assert.Equal(t, map[string][]time.Time{
+		"reset":       {startTime, startTime, startTime, startTime},
+		"increasing":  {startTime, startTime, startTime},
+		"reset twice": {startTime, startTime, startTime, startTime, startTime},
+	}, startTimes(t))
+}
+
+func TestConverterMonotonicCorrection(t *testing.T) {
+	startTime := time.Now()
+	series := func(value string, start time.Time, values ...float64) *ocmetricdata.TimeSeries {
+		points := make([]ocmetricdata.Point, len(values))
+		for i, v := range values {
+			points[i] = ocmetricdata.NewFloat64Point(start.Add(time.Duration(i+1)*time.Second), v)
+		}
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points:      points,
+			StartTime:   start,
+		}
+	}
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name:      "foo.com/sum-a",
+				Type:      ocmetricdata.TypeCumulativeFloat64,
+				LabelKeys: []ocmetricdata.LabelKey{{Key: "a"}},
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				series("decreasing", startTime, 5, 3, 8),
+				series("increasing", startTime, 1, 2, 3),
+				series("restarted", startTime, 9),
+				series("restarted", startTime.Add(time.Minute), 2, 1),
+			},
+		},
+	}
+	values := func(t *testing.T, opts ...Option) (map[string][]float64, error) {
+		output, err := ConvertMetrics(input, opts...)
+		require.Len(t, output, 1)
+		v := make(map[string][]float64)
+		for _, p := range output[0].Data.(metricdata.Sum[float64]).DataPoints {
+			a, _ := p.Attributes.Value("a")
+			v[a.AsString()] = append(v[a.AsString()], p.Value)
+		}
+		return v, err
+	}
+
+	corrected, err := values(t, WithMonotonicCorrection())
+	assert.ErrorIs(t, err, errSumCorrected)
+	assert.ErrorContains(t, err, "2 of 9 points")
+	assert.Equal(t, map[string][]float64{
+		"decreasing": {5, 5, 8},
+		"increasing": {1, 2, 3},
+		"restarted":  {9, 2, 2},
+	}, corrected)
+
+	unchanged, err := values(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]float64{
+		"decreasing": {5, 3, 8},
+		"increasing": {1, 2, 3},
+		"restarted":  {9, 2, 1},
+	}, unchanged)
+}
+
+func TestConverterDetectResets(t *testing.T) {
+	startTime1 := time.Now()
+	startTime2 := startTime1.Add(time.Minute)
+	endTime := startTime2.Add(time.Minute)
+	labelKeys := []ocmetricdata.LabelKey{{Key: "a"}}
+	series := func(value string, start time.Time, point ocmetricdata.Point) *ocmetricdata.TimeSeries {
+		return &ocmetricdata.TimeSeries{
+			LabelValues: []ocmetricdata.LabelValue{{Value: value, Present: true}},
+			Points:      []ocmetricdata.Point{point},
+			StartTime:   start,
+		}
+	}
+	sum := &ocmetricdata.Metric{
+		Descriptor: ocmetricdata.Descriptor{
+			Name:      "foo.com/sum-a",
+			Type:      ocmetricdata.TypeCumulativeInt64,
+			LabelKeys: labelKeys,
+		},
+		TimeSeries: []*ocmetricdata.TimeSeries{
+			series("1", startTime1, ocmetricdata.NewInt64Point(endTime, 10)),
+			series("2", startTime2, ocmetricdata.NewInt64Point(endTime, 3)),
+			series("1", startTime2, ocmetricdata.NewInt64Point(endTime, 2)),
+		},
+	}
+	dist := &ocmetricdata.Distribution{BucketOptions: &ocmetricdata.BucketOptions{}}
+	histogram := &ocmetricdata.Metric{
+		Descriptor: ocmetricdata.Descriptor{
+			Name:      "foo.com/histogram-a",
+			Type:      ocmetricdata.TypeCumulativeDistribution,
+			LabelKeys: labelKeys,
+		},
+		TimeSeries: []*ocmetricdata.TimeSeries{
+			series("1", startTime2, ocmetricdata.NewDistributionPoint(endTime, dist)),
+			series("1", startTime1, ocmetricdata.NewDistributionPoint(endTime, dist)),
+		},
+	}
+
+	output, err := ConvertMetrics([]*ocmetricdata.Metric{sum}, WithDetectResets())
+	assert.ErrorIs(t, err, errCumulativeReset)
+	assert.Contains(t, err.Error(), "foo.com/sum-a")
+	assert.Contains(t, err.Error(), "a=1")
+	assert.NotContains(t, err.Error(), "a=2")
+	require.Len(t, output, 1)
+	assert.Len(t, output[0].Data.(metricdata.Sum[int64]).DataPoints, 3, "data points must be kept")
+
+	// An earlier start time after a later one is not a reset.
+	_, err = ConvertMetrics([]*ocmetricdata.Metric{histogram}, WithDetectResets())
+	assert.NoError(t, err)
+
+	_, err = ConvertMetrics([]*ocmetricdata.Metric{sum})
+	assert.NoError(t, err)
+}
+
+func TestConverterDetectNonMonotonic(t *testing.T) {
+	startTime := time.Now()
+	sum := func(start time.Time, values ...int64) []*ocmetricdata.Metric {
+		points := make([]ocmetricdata.Point, len(values))
+		for i, v := range values {
+			points[i] = ocmetricdata.NewInt64Point(start.Add(time.Duration(i+1)*time.Second), v)
+		}
+		return []*ocmetricdata.Metric{
+			{
+				Descriptor: ocmetricdata.Descriptor{
+					Name: "foo.com/sum-a",
+					Type: ocmetricdata.TypeCumulativeInt64,
+				},
+				TimeSeries: []*ocmetricdata.TimeSeries{
+					{Points: points, StartTime: start},
+				},
+			},
+		}
+	}
+	isMonotonic := func(t *testing.T, output []metricdata.Metrics) bool {
+		require.Len(t, output, 1)
+		return output[0].Data.(metricdata.Sum[int64]).IsMonotonic
+	}
+
+	c := NewConverter(WithDetectNonMonotonic())
+	output, err := c.ConvertMetrics(sum(startTime, 1, 2))
+	require.NoError(t, err)
+	assert.True(t, isMonotonic(t, output))
+
+	// A lower value with a later start time is a reset, not a decrease.
+	output, err = c.ConvertMetrics(sum(startTime.Add(time.Minute), 1))
+	require.NoError(t, err)
+	assert.True(t, isMonotonic(t, output))
+
+	output, err = c.ConvertMetrics(sum(startTime.Add(time.Minute), 3, 2))
+	assert.ErrorIs(t, err, errNonMonotonicSum)
+	assert.False(t, isMonotonic(t, output))
+
+	output, err = c.ConvertMetrics(sum(startTime.Add(time.Minute), 4))
+	require.NoError(t, err, "the decrease must only be reported once")
+	assert.False(t, isMonotonic(t, output), "non-monotonicity must be retained")
+
+	c.Reset()
+	output, err = c.ConvertMetrics(sum(startTime.Add(time.Minute), 4))
+	require.NoError(t, err)
+	assert.True(t, isMonotonic(t, output), "Reset must discard the state")
+
+	output, err = ConvertMetrics(sum(startTime, 2, 1))
+	require.NoError(t, err)
+	assert.True(t, isMonotonic(t, output))
+}
+
+func TestConverterDeltaHistograms(t *testing.T) {
+	startTime := time.Now()
+	time1 := startTime.Add(time.Second)
+	time2 := time1.Add(time.Second)
+	time3 := time2.Add(time.Second)
+	time4 := time3.Add(time.Second)
+	histogram := func(end time.Time, bounds []float64, sum float64, counts ...int64) []*ocmetricdata.Metric {
+		buckets := make([]ocmetricdata.Bucket, len(counts))
+		var count int64
+		for i, n := range counts {
+			buckets[i] = ocmetricdata.Bucket{Count: n}
+			count += n
+		}
+		return []*ocmetricdata.Metric{{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/histogram-a",
+				Type: ocmetricdata.TypeCumulativeDistribution,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{
+				Points: []ocmetricdata.Point{
+					ocmetricdata.NewDistributionPoint(end, &ocmetricdata.Distribution{
+						Count:         count,
+						Sum:           sum,
+						BucketOptions: &ocmetricdata.BucketOptions{Bounds: bounds},
+						Buckets:       buckets,
+					}),
+				},
+				StartTime: startTime,
+			}},
+		}}
+	}
+	expected := func(start, end time.Time, bounds []float64, sum float64, counts ...uint64) []metricdata.Metrics {
+		var count uint64
+		for _, n := range counts {
+			count += n
+		}
+		return []metricdata.Metrics{{
+			Name: "foo.com/histogram-a",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.DeltaTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{{
+					Attributes:   *attribute.EmptySet(),
+					StartTime:    start,
+					Time:         end,
+					Bounds:       bounds,
+					Count:        count,
+					Sum:          sum,
+					BucketCounts: counts,
+				}},
+			},
+		}}
+	}
+
+	c := NewConverter(WithTemporalityByName(func(string, ocmetricdata.Type) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}))
+	for _, tc := range []struct {
+		desc        string
+		input       []*ocmetricdata.Metric
+		expected    []metricdata.Metrics
+		expectedErr error
+	}{
+		{
+			desc:     "first conversion is the change since start",
+			input:    histogram(time1, []float64{1, 2}, 10, 1, 2, 3),
+			expected: expected(startTime, time1, []float64{1, 2}, 10, 1, 2, 3),
+		},
+		{
+			desc:     "stable bounds",
+			input:    histogram(time2, []float64{1, 2}, 15, 2, 2, 5),
+			expected: expected(time1, time2, []float64{1, 2}, 5, 1, 0, 2),
+		},
+		{
+			desc:        "changed bounds",
+			input:       histogram(time3, []float64{1, 5}, 20, 3, 4, 5),
+			expected:    expected(startTime, time3, []float64{1, 5}, 20, 3, 4, 5),
+			expectedErr: errBoundsChanged,
+		},
+		{
+			desc:     "stable after changed bounds",
+			input:    histogram(time4, []float64{1, 5}, 21, 4, 4, 5),
+			expected: expected(time3, time4, []float64{1, 5}, 1, 1, 0, 0),
+		},
+		{
+			desc:  "repeated collection",
+			input: histogram(time4, []float64{1, 5}, 21, 4, 4, 5),
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{Temporality: metricdata.DeltaTemporality},
+			}},
+		},
+		{
+			desc:  "out of order collection",
+			input: histogram(time3, []float64{1, 5}, 20, 3, 4, 5),
+			expected: []metricdata.Metrics{{
+				Name: "foo.com/histogram-a",
+				Data: metricdata.Histogram[float64]{Temporality: metricdata.DeltaTemporality},
+			}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			output, err := c.ConvertMetrics(tc.input)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			metricdatatest.AssertEqual[metricdata.ScopeMetrics](t,
+				metricdata.ScopeMetrics{Metrics: tc.expected},
+				metricdata.ScopeMetrics{Metrics: output},
+			)
+		})
+	}
+}
+
+func TestConverterResetHandling(t *testing.T) {
+	startTime1 := time.Now()
+	startTime2 := startTime1.Add(time.Minute)
+	startTime3 := startTime2.Add(time.Minute)
+	time1 := startTime1.Add(time.Second)
+	time2 := startTime2.Add(time.Second)
+	time3 := time2.Add(time.Second)
+	sum := func(start time.Time, points ...ocmetricdata.Point) []*ocmetricdata.Metric {
+		return []*ocmetricdata.Metric{{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/sum-a",
+				Type: ocmetricdata.TypeCumulativeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{{Points: points, StartTime: start}},
+		}}
+	}
+	point := func(start, end time.Time, value int64) metricdata.DataPoint[int64] {
+		return metricdata.DataPoint[int64]{
+			Attributes: *attribute.EmptySet(),
+			StartTime:  start,
+			Time:       end,
+			Value:      value,
+		}
+	}
+	for _, tc := range []struct {
+		desc     string
+		handling ResetHandling
+		expected []metricdata.DataPoint[int64]
+	}{
+		{
+			desc:     "passthrough",
+			handling: ResetPassthrough,
+			expected: []metricdata.DataPoint[int64]{
+				point(startTime2, time2, 2),
+				point(startTime2, time3, 3),
+			},
+		},
+		{
+			desc:     "zero",
+			handling: ResetZero,
+			expected: []metricdata.DataPoint[int64]{
+				point(startTime2, startTime2, 0),
+				point(startTime2, time2, 2),
+				point(startTime2, time3, 3),
+			},
+		},
+		{
+			desc:     "skip",
+			handling: ResetSkip,
+			expected: []metricdata.DataPoint[int64]{
+				point(startTime2, time3, 3),
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewConverter(WithResetHandling(tc.handling))
+			points := func(input []*ocmetricdata.Metric) []metricdata.DataPoint[int64] {
+				output, err := c.ConvertMetrics(input)
+				require.NoError(t, err)
+				require.Len(t, output, 1)
+				return output[0].Data.(metricdata.Sum[int64]).DataPoints
+			}
+
+			assert.Equal(t, []metricdata.DataPoint[int64]{point(startTime1, time1, 10)}, points(sum(startTime1, ocmetricdata.NewInt64Point(time1, 10))))
+			assert.Equal(t, tc.expected, points(sum(startTime2,
+				ocmetricdata.NewInt64Point(time2, 2),
+				ocmetricdata.NewInt64Point(time3, 3),
+			)))
+
+			c.Reset()
+			assert.Equal(t, []metricdata.DataPoint[int64]{point(startTime3, time3, 1)}, points(sum(startTime3, ocmetricdata.NewInt64Point(time3, 1))), "Reset must discard the state")
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// MetricManifestEntry describes the shape of a converted metric, without its
+// values and timestamps.
+type MetricManifestEntry = internal.MetricManifestEntry
+
+// Manifest returns the manifest of metrics: an entry for each metric, sorted
+// by name, then aggregation. The manifest only depends on the shape of the
+// metrics, so it can be compared to a golden manifest to detect changes of
+// the conversion of a fixed input.
+func Manifest(metrics []metricdata.Metrics) []MetricManifestEntry {
+	return internal.Manifest(metrics)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestManifest(t *testing.T) {
+	now := time.Now()
+	batch, err := ConvertWithResource([]*ocmetricdata.Metric{
+		ocMetric("foo.com/sum-a", ocmetricdata.TypeCumulativeInt64, []string{"b", "a"},
+			ocSeries(now, []string{"1", "2"}, ocmetricdata.NewInt64Point(now, 1)),
+		),
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeFloat64, nil, ocSeries(now, nil, ocmetricdata.NewFloat64Point(now, 1))),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []MetricManifestEntry{
+		{
+			Name:          "foo.com/gauge-a",
+			Aggregation:   "Gauge[float64]",
+			AttributeKeys: []string{},
+		},
+		{
+			Name:          "foo.com/sum-a",
+			Aggregation:   "Sum[int64]",
+			Temporality:   metricdata.CumulativeTemporality,
+			AttributeKeys: []string{"a", "b"},
+		},
+	}, Manifest(batch.Metrics))
+}
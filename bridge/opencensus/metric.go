@@ -16,10 +16,12 @@ package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
 
 import (
 	"context"
+	"sync"
 
 	ocmetricdata "go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 
+	"go.opentelemetry.io/otel/attribute"
 	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -29,28 +31,129 @@ import (
 // MetricProducer implements the [go.opentelemetry.io/otel/sdk/metric.Producer] to provide metrics
 // from OpenCensus to the OpenTelemetry SDK.
 type MetricProducer struct {
-	manager *metricproducer.Manager
+	fetch     func() []*ocmetricdata.Metric
+	converter *metricConverter
 }
 
 // NewMetricProducer returns a metric.Producer that fetches metrics from
 // OpenCensus.
 func NewMetricProducer(opts ...MetricOption) *MetricProducer {
+	manager := metricproducer.GlobalManager()
 	return &MetricProducer{
-		manager: metricproducer.GlobalManager(),
+		fetch: func() []*ocmetricdata.Metric {
+			data := []*ocmetricdata.Metric{}
+			for _, ocProducer := range manager.GetAll() {
+				data = append(data, ocProducer.Read()...)
+			}
+			return data
+		},
+		converter: newMetricConverter(newMetricConfig(opts)),
+	}
+}
+
+// NewOpenCensusProducer returns a metric.Producer that converts the
+// OpenCensus metrics returned by fetch to OpenTelemetry on each collection
+// cycle. Unlike the producer returned by NewMetricProducer, it does not read
+// metrics from the OpenCensus global producer manager.
+func NewOpenCensusProducer(fetch func() []*ocmetricdata.Metric, opts ...MetricOption) *MetricProducer {
+	return &MetricProducer{
+		fetch:     fetch,
+		converter: newMetricConverter(newMetricConfig(opts)),
 	}
 }
 
 var _ metric.Producer = (*MetricProducer)(nil)
 
-// Produce fetches metrics from the OpenCensus manager,
+// Produce fetches metrics from OpenCensus,
 // translates them to OpenTelemetry's data model, and returns them.
-func (p *MetricProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
-	producers := p.manager.GetAll()
-	data := []*ocmetricdata.Metric{}
-	for _, ocProducer := range producers {
-		data = append(data, ocProducer.Read()...)
+func (p *MetricProducer) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
+	return p.converter.convert(ctx, p.fetch())
+}
+
+// ConversionStats are statistics about a conversion of OpenCensus metrics.
+type ConversionStats struct {
+	// CoalescedGaugePoints is the number of gauge points dropped because a
+	// later point of the same time series was kept.
+	CoalescedGaugePoints int
+	// AttributeSets is the number of distinct attribute sets of the data
+	// points of each converted metric, by metric name. A sudden increase
+	// indicates a cardinality explosion.
+	AttributeSets map[string]int
+	// FilteredPoints is the number of data points excluded by the point
+	// filter.
+	FilteredPoints int
+	// EmptyMetrics is the number of metrics without data points dropped.
+	EmptyMetrics int
+	// NilMetrics is the number of nil metrics skipped.
+	NilMetrics int
+	// AgedOutPoints is the number of data points dropped because they are
+	// older than the maximum series age.
+	AgedOutPoints int
+}
+
+// Stats returns statistics about the last collection of p.
+func (p *MetricProducer) Stats() ConversionStats {
+	p.converter.mu.Lock()
+	defer p.converter.mu.Unlock()
+	stats := p.converter.converter.Stats()
+	return ConversionStats{
+		CoalescedGaugePoints: stats.CoalescedGaugePoints,
+		AttributeSets:        stats.AttributeSets,
+		FilteredPoints:       stats.FilteredPoints,
+		EmptyMetrics:         stats.EmptyMetrics,
+		NilMetrics:           stats.NilMetrics,
+		AgedOutPoints:        stats.AgedOutPoints,
+	}
+}
+
+// DroppedMetrics returns the names of the metrics dropped by the last
+// collection of p, along with the first error that caused each of them to
+// be dropped. Metrics dropped because of an empty name are mapped from the
+// empty name.
+func (p *MetricProducer) DroppedMetrics() map[string]error {
+	p.converter.mu.Lock()
+	defer p.converter.mu.Unlock()
+	return p.converter.converter.DroppedMetrics()
+}
+
+// UnsupportedMetrics returns the names of the metrics of ocmetrics with an
+// OpenCensus type that cannot be converted, e.g. summaries, in order. The
+// metrics are not converted, so this is a cheap check of which metrics a
+// conversion would drop because of their type.
+func UnsupportedMetrics(ocmetrics []*ocmetricdata.Metric) []string {
+	return internal.UnsupportedMetrics(ocmetrics)
+}
+
+// ConvertMetricsWithContext converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, adding the attributes of WithContextEnrichment, called
+// with ctx, to the attributes of each data point.
+func ConvertMetricsWithContext(ctx context.Context, ocmetrics []*ocmetricdata.Metric, opts ...MetricOption) ([]metricdata.Metrics, error) {
+	conf := newMetricConfig(opts)
+	return internal.ConvertMetricsWithContext(ctx, ocmetrics, conf.enrich, conf.converterOptions...)
+}
+
+// metricConverter converts OpenCensus metrics to OpenTelemetry metrics of the
+// bridge scope. Its converter is kept across collections, as the conversion
+// is stateful with some options, e.g. to delta temporality.
+type metricConverter struct {
+	mu        sync.Mutex
+	converter *internal.Converter
+	enrich    func(context.Context) []attribute.KeyValue
+}
+
+// newMetricConverter returns a metricConverter configured with conf.
+func newMetricConverter(conf metricConfig) *metricConverter {
+	return &metricConverter{
+		converter: internal.NewConverter(conf.converterOptions...),
+		enrich:    conf.enrich,
 	}
-	otelmetrics, err := internal.ConvertMetrics(data)
+}
+
+// convert converts data to OpenTelemetry metrics of the bridge scope.
+func (c *metricConverter) convert(ctx context.Context, data []*ocmetricdata.Metric) ([]metricdata.ScopeMetrics, error) {
+	c.mu.Lock()
+	otelmetrics, err := c.converter.ConvertMetricsWithContext(ctx, data, c.enrich)
+	c.mu.Unlock()
 	if len(otelmetrics) == 0 {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ocmetricdata "go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
@@ -46,6 +47,10 @@ func TestMetricProducer(t *testing.T) {
 			desc: "success",
 			input: []*ocmetricdata.Metric{
 				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/gauge-a",
+						Type: ocmetricdata.TypeGaugeInt64,
+					},
 					Resource: &ocresource.Resource{
 						Labels: map[string]string{
 							"R1": "V1",
@@ -69,6 +74,7 @@ func TestMetricProducer(t *testing.T) {
 				},
 				Metrics: []metricdata.Metrics{
 					{
+						Name: "foo.com/gauge-a",
 						Data: metricdata.Gauge[int64]{
 							DataPoints: []metricdata.DataPoint[int64]{
 								{
@@ -95,6 +101,10 @@ func TestMetricProducer(t *testing.T) {
 					},
 				},
 				{
+					Descriptor: ocmetricdata.Descriptor{
+						Name: "foo.com/gauge-a",
+						Type: ocmetricdata.TypeGaugeInt64,
+					},
 					Resource: &ocresource.Resource{
 						Labels: map[string]string{
 							"R1": "V1",
@@ -118,6 +128,7 @@ func TestMetricProducer(t *testing.T) {
 				},
 				Metrics: []metricdata.Metrics{
 					{
+						Name: "foo.com/gauge-a",
 						Data: metricdata.Gauge[int64]{
 							DataPoints: []metricdata.DataPoint[int64]{
 								{
@@ -152,6 +163,154 @@ func TestMetricProducer(t *testing.T) {
 	}
 }
 
+func TestOpenCensusProducer(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		{
+			Descriptor: ocmetricdata.Descriptor{
+				Name: "foo.com/gauge-a",
+				Type: ocmetricdata.TypeGaugeInt64,
+			},
+			TimeSeries: []*ocmetricdata.TimeSeries{
+				{
+					StartTime: now,
+					Points: []ocmetricdata.Point{
+						{Value: int64(123), Time: now},
+					},
+				},
+			},
+		},
+	}
+	expected := metricdata.ScopeMetrics{
+		Scope: instrumentation.Scope{
+			Name:    scopeName,
+			Version: Version(),
+		},
+		Metrics: []metricdata.Metrics{
+			{
+				Name: "foo.com/gauge-a",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{
+						{
+							Attributes: attribute.NewSet(),
+							StartTime:  now,
+							Time:       now,
+							Value:      123,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var fetches int
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric {
+		fetches++
+		return input
+	})
+	for i := 1; i <= 2; i++ {
+		output, err := producer.Produce(context.Background())
+		require.NoError(t, err)
+		require.Len(t, output, 1)
+		metricdatatest.AssertEqual(t, expected, output[0])
+		require.Equal(t, i, fetches, "metrics must be fetched on each collection")
+	}
+}
+
+func TestMetricProducerStats(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		nil,
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"},
+			ocSeries(now, []string{"1"}, ocmetricdata.NewInt64Point(now, 1), ocmetricdata.NewInt64Point(now.Add(time.Second), 2)),
+			ocSeries(now, []string{"2"}, ocmetricdata.NewInt64Point(now, 3)),
+		),
+		ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil),
+	}
+	producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, WithLatestGaugePointOnly())
+	assert.Empty(t, producer.DroppedMetrics())
+
+	_, err := producer.Produce(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, ConversionStats{
+		CoalescedGaugePoints: 1,
+		AttributeSets:        map[string]int{"foo.com/gauge-a": 2},
+		NilMetrics:           1,
+	}, producer.Stats())
+	dropped := producer.DroppedMetrics()
+	assert.Len(t, dropped, 1)
+	assert.Contains(t, dropped, "foo.com/summary-a")
+}
+
+type tenantKey struct{}
+
+// tenantEnrichment returns the tenant of ctx as an attribute.
+func tenantEnrichment(ctx context.Context) []attribute.KeyValue {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return []attribute.KeyValue{attribute.String("tenant", tenant)}
+}
+
+func TestMetricProducerContextEnrichment(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"tenant"},
+			ocSeries(time.Time{}, []string{"oc"}, ocmetricdata.NewInt64Point(now, 1)),
+		),
+	}
+	for _, tc := range []struct {
+		desc     string
+		opts     []MetricOption
+		expected attribute.Set
+	}{
+		{
+			desc:     "labels take precedence",
+			opts:     []MetricOption{WithContextEnrichment(tenantEnrichment)},
+			expected: attribute.NewSet(attribute.String("tenant", "oc")),
+		},
+		{
+			desc:     "override",
+			opts:     []MetricOption{WithContextEnrichment(tenantEnrichment), WithEnrichmentOverride()},
+			expected: attribute.NewSet(attribute.String("tenant", "a")),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), tenantKey{}, "a")
+			producer := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }, tc.opts...)
+			output, err := producer.Produce(ctx)
+			require.NoError(t, err)
+			require.Len(t, output, 1)
+			require.Len(t, output[0].Metrics, 1)
+			gauge, ok := output[0].Metrics[0].Data.(metricdata.Gauge[int64])
+			require.True(t, ok)
+			require.Len(t, gauge.DataPoints, 1)
+			assert.Equal(t, tc.expected, gauge.DataPoints[0].Attributes)
+		})
+	}
+}
+
+func TestConvertMetricsWithContext(t *testing.T) {
+	now := time.Now()
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil,
+			ocSeries(time.Time{}, nil, ocmetricdata.NewInt64Point(now, 1)),
+		),
+	}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "a")
+	output, err := ConvertMetricsWithContext(ctx, input, WithContextEnrichment(tenantEnrichment))
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name: "foo.com/gauge-a",
+		Data: metricdata.Gauge[int64]{
+			DataPoints: []metricdata.DataPoint[int64]{{
+				Attributes: attribute.NewSet(attribute.String("tenant", "a")),
+				Time:       now,
+				Value:      1,
+			}},
+		},
+	}, output[0])
+}
+
 type fakeOCProducer struct {
 	metrics []*ocmetricdata.Metric
 }
@@ -159,3 +318,15 @@ type fakeOCProducer struct {
 func (f *fakeOCProducer) Read() []*ocmetricdata.Metric {
 	return f.metrics
 }
+
+func TestUnsupportedMetrics(t *testing.T) {
+	input := []*ocmetricdata.Metric{
+		ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, nil),
+		ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil),
+		ocMetric("foo.com/histogram-a", ocmetricdata.TypeCumulativeDistribution, nil),
+	}
+	assert.Equal(t, []string{"foo.com/summary-a"}, UnsupportedMetrics(input))
+
+	_, err := NewOpenCensusProducer(func() []*ocmetricdata.Metric { return input }).Produce(context.Background())
+	assert.Error(t, err, "unsupported metrics are not converted")
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"errors"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/bridge/opencensus/internal/transform"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// MarshalConvertedJSON returns the OTLP/JSON encoding of an export request
+// containing metrics, converted by the bridge, with the resource res and the
+// instrumentation scope of the bridge. It is intended to inspect the output
+// of the bridge, for example when debugging or reporting bugs. Metrics that
+// cannot be encoded are omitted, and an error is returned.
+func MarshalConvertedJSON(metrics []metricdata.Metrics, res *resource.Resource) ([]byte, error) {
+	rm, err := transform.ResourceMetrics(&metricdata.ResourceMetrics{
+		Resource: res,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{
+				Name:    scopeName,
+				Version: Version(),
+			},
+			Metrics: metrics,
+		}},
+	})
+	data, mErr := protojson.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{rm},
+	})
+	if mErr != nil {
+		return nil, errors.Join(err, mErr)
+	}
+	return data, err
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestMarshalConvertedJSON(t *testing.T) {
+	metrics := []metricdata.Metrics{
+		{
+			Name: "foo.com/gauge-a",
+			Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{
+				{Time: time.Unix(0, 1), Value: 1},
+			}},
+		},
+		{
+			Name: "foo.com/histogram-a",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{{
+					Time:         time.Unix(0, 1),
+					Count:        1,
+					Sum:          0.5,
+					BucketCounts: []uint64{1},
+					Exemplars: []metricdata.Exemplar[float64]{{
+						FilteredAttributes: []attribute.KeyValue{attribute.String("a", "1")},
+						Time:               time.Unix(0, 1),
+						Value:              0.5,
+					}},
+				}},
+			},
+		},
+	}
+	res := resource.NewSchemaless(attribute.String("R1", "V1"))
+	expected := fmt.Sprintf(`{"resourceMetrics":[{"resource":{"attributes":[{"key":"R1","value":{"stringValue":"V1"}}]},`+
+		`"scopeMetrics":[{"scope":{"name":%q,"version":%q},"metrics":[`+
+		`{"name":"foo.com/gauge-a","gauge":{"dataPoints":[{"timeUnixNano":"1","asInt":"1"}]}},`+
+		`{"name":"foo.com/histogram-a","histogram":{"dataPoints":[{"timeUnixNano":"1","count":"1","sum":0.5,"bucketCounts":["1"],`+
+		`"exemplars":[{"filteredAttributes":[{"key":"a","value":{"stringValue":"1"}}],"timeUnixNano":"1","asDouble":0.5}]}],`+
+		`"aggregationTemporality":"AGGREGATION_TEMPORALITY_CUMULATIVE"}}]}]}]}`, scopeName, Version())
+
+	output, err := MarshalConvertedJSON(metrics, res)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(output))
+
+	output, err = MarshalConvertedJSON(nil, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"resourceMetrics":[{"resource":{},"scopeMetrics":[{"scope":{"name":%q,"version":%q}}]}]}`, scopeName, Version()), string(output))
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// ConvertMetricsParallel converts OpenCensus metrics to OpenTelemetry as
+// configured by opts, using up to workers goroutines to convert metrics
+// concurrently. A workers value less than 1 is treated as 1.
+//
+// The converted metrics and errors are those a MetricProducer returns, in
+// the same order: name collisions and incompatible types are resolved in the
+// order of ocmetrics once the metrics are converted. The functions passed to
+// opts to convert metrics, e.g. mappers and filters, are called
+// concurrently, while the success handler and timing recorder are called in
+// the order of ocmetrics. With WithMaxErrors, the errors of a metric are
+// either all reported or all omitted. Each worker retains its own state, so
+// stateful options only apply within the metrics converted by a worker.
+func ConvertMetricsParallel(ocmetrics []*ocmetricdata.Metric, workers int, opts ...MetricOption) ([]metricdata.Metrics, error) {
+	return internal.ConvertMetricsParallel(ocmetrics, workers, newMetricConfig(opts).converterOptions...)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func TestConvertMetricsParallel(t *testing.T) {
+	now := time.Now()
+	var input []*ocmetricdata.Metric
+	for i := 0; i < 50; i++ {
+		typ := ocmetricdata.TypeGaugeInt64
+		if i%7 == 0 {
+			typ = ocmetricdata.TypeCumulativeInt64
+		}
+		input = append(input, ocMetric(fmt.Sprintf("foo.com/metric-%d", i%10), typ, []string{"a"},
+			ocSeries(now, []string{fmt.Sprint(i)}, ocmetricdata.NewInt64Point(now, int64(i))),
+		))
+	}
+	input = append(input, nil, ocMetric("foo.com/summary-a", ocmetricdata.TypeSummary, nil))
+
+	for _, opts := range [][]MetricOption{
+		nil,
+		{WithMaxErrors(3)},
+		{WithDefaultMetricName("unnamed-")},
+	} {
+		expected, expectedErr := ConvertWithResource(input, opts...)
+		for _, workers := range []int{1, 4, len(input)} {
+			output, err := ConvertMetricsParallel(input, workers, opts...)
+			assert.Equal(t, expectedErr, err)
+			metricdatatest.AssertEqual(t,
+				metricdata.ScopeMetrics{Metrics: expected.Metrics},
+				metricdata.ScopeMetrics{Metrics: output})
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	internal "go.opentelemetry.io/otel/bridge/opencensus/internal/ocmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// EstimateSize returns an estimate of the size in bytes of metrics when
+// exported, e.g. to decide when to flush a batch of converted metrics. It is
+// the size of their names, descriptions, units, attribute keys and values,
+// timestamps, values, bounds, bucket counts, and exemplars.
+//
+// The estimate is not the exact size on the wire: it ignores the framing and
+// field tags of the encoding, as well as compression, and counts all numbers
+// as 8 bytes. It grows with the amount of data of metrics.
+func EstimateSize(metrics []metricdata.Metrics) int {
+	return internal.EstimateSize(metrics)
+}
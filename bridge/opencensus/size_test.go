@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocmetricdata "go.opencensus.io/metric/metricdata"
+)
+
+func TestEstimateSize(t *testing.T) {
+	now := time.Now()
+	gauge := func(values ...string) []*ocmetricdata.Metric {
+		m := ocMetric("foo.com/gauge-a", ocmetricdata.TypeGaugeInt64, []string{"a"})
+		for _, v := range values {
+			m.TimeSeries = append(m.TimeSeries, ocSeries(now, []string{v}, ocmetricdata.NewInt64Point(now, 1)))
+		}
+		return []*ocmetricdata.Metric{m}
+	}
+	size := func(ocmetrics []*ocmetricdata.Metric) int {
+		batch, err := ConvertWithResource(ocmetrics)
+		require.NoError(t, err)
+		return EstimateSize(batch.Metrics)
+	}
+
+	assert.Zero(t, EstimateSize(nil))
+	one, two := size(gauge("1")), size(gauge("1", "2"))
+	assert.Greater(t, one, len("foo.com/gauge-a"))
+	assert.Greater(t, two, one, "size grows with the data points")
+}
@@ -564,12 +564,60 @@ var (
 	}
 )
 
+var (
+	traceID = []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10}
+	spanID  = []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8}
+
+	otelExemplarsInt64 = []metricdata.Exemplar[int64]{
+		{
+			FilteredAttributes: []attribute.KeyValue{attribute.String("user", "alice")},
+			Time:               end,
+			Value:              2,
+			SpanID:             spanID,
+			TraceID:            traceID,
+		},
+	}
+	otelExemplarsFloat64 = []metricdata.Exemplar[float64]{
+		{
+			FilteredAttributes: []attribute.KeyValue{attribute.String("user", "alice")},
+			Time:               end,
+			Value:              2.5,
+			SpanID:             spanID,
+			TraceID:            traceID,
+		},
+	}
+
+	pbExemplarsInt64 = []*mpb.Exemplar{
+		{
+			FilteredAttributes: []*cpb.KeyValue{pbAlice},
+			TimeUnixNano:       uint64(end.UnixNano()),
+			Value:              &mpb.Exemplar_AsInt{AsInt: 2},
+			SpanId:             spanID,
+			TraceId:            traceID,
+		},
+	}
+	pbExemplarsFloat64 = []*mpb.Exemplar{
+		{
+			FilteredAttributes: []*cpb.KeyValue{pbAlice},
+			TimeUnixNano:       uint64(end.UnixNano()),
+			Value:              &mpb.Exemplar_AsDouble{AsDouble: 2.5},
+			SpanId:             spanID,
+			TraceId:            traceID,
+		},
+	}
+)
+
 func TestTransformations(t *testing.T) {
 	// Run tests from the "bottom-up" of the metricdata data-types and halt
 	// when a failure occurs to ensure the clearest failure message (as
 	// opposed to the opposite of testing from the top-down which will obscure
 	// errors deep inside the structs).
 
+	// Exemplars.
+	assert.Equal(t, pbExemplarsInt64, Exemplars(otelExemplarsInt64))
+	assert.Equal(t, pbExemplarsFloat64, Exemplars(otelExemplarsFloat64))
+	assert.Nil(t, Exemplars[int64](nil))
+
 	// DataPoint types.
 	assert.Equal(t, pbHDP, HistogramDataPoints(otelHDPInt64))
 	assert.Equal(t, pbHDP, HistogramDataPoints(otelHDPFloat64))